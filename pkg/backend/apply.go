@@ -26,7 +26,9 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
@@ -80,6 +82,13 @@ const (
 
 func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack,
 	op UpdateOperation, apply Applier) (engine.ResourceChanges, result.Result) {
+	changes, _, res := previewThenPrompt(ctx, kind, stack, op, apply)
+	return changes, res
+}
+
+// previewThenPrompt is like PreviewThenPrompt, but also returns the step and summary events issued by the preview.
+func previewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack,
+	op UpdateOperation, apply Applier) (engine.ResourceChanges, []engine.Event, result.Result) {
 	// create a channel to hear about the update events from the engine. this will be used so that
 	// we can build up the diff display in case the user asks to see the details of the diff
 
@@ -90,7 +99,10 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 	eventsChannel := make(chan engine.Event)
 
 	var events []engine.Event
+	collected := make(chan struct{})
 	go func() {
+		defer close(collected)
+
 		// pull the events from the channel and store them locally
 		for e := range eventsChannel {
 			if e.Type == engine.ResourcePreEvent ||
@@ -114,19 +126,22 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 	changes, res := apply(ctx, kind, stack, op, opts, eventsChannel)
 	if res != nil {
 		close(eventsChannel)
-		return changes, res
+		<-collected
+		return changes, events, res
 	}
 
 	// If there are no changes, or we're auto-approving or just previewing, we can skip the confirmation prompt.
 	if op.Opts.AutoApprove || kind == apitype.PreviewUpdate {
 		close(eventsChannel)
-		return changes, nil
+		<-collected
+		return changes, events, nil
 	}
 
 	// Otherwise, ensure the user wants to proceed.
 	res = confirmBeforeUpdating(kind, stack, events, op.Opts)
 	close(eventsChannel)
-	return changes, res
+	<-collected
+	return changes, events, res
 }
 
 // confirmBeforeUpdating asks the user whether to proceed. A nil error means yes.
@@ -191,15 +206,80 @@ func confirmBeforeUpdating(kind apitype.UpdateKind, stack Stack,
 	}
 }
 
+// NewStepApprover returns a step approver that shows each step's detailed diff and asks the user whether to apply or
+// skip it, or to abort the update.
+func NewStepApprover(opts display.Options) engine.StepApprover {
+	const (
+		apply = "apply"
+		skip  = "skip"
+		abort = "abort"
+	)
+
+	opts.ShowReplacementSteps, opts.ShowSameResources = true, true
+	return func(step engine.StepEventMetadata) (engine.StepApproval, error) {
+		seen := make(map[resource.URN]engine.StepEventMetadata)
+		event := engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+			Metadata: step,
+			Planning: true,
+		})
+		diff := display.RenderDiffEvent(apitype.UpdateUpdate, event, seen, opts)
+		_, err := os.Stdout.WriteString("\n" + diff)
+		contract.IgnoreError(err)
+
+		surveycore.DisableColor = true
+		surveycore.QuestionIcon = ""
+		surveycore.SelectFocusIcon = opts.Color.Colorize(colors.BrightGreen + ">" + colors.Reset)
+
+		verb := string(step.Op)
+		switch step.Op {
+		case deploy.OpCreateReplacement, deploy.OpDeleteReplaced:
+			verb = string(deploy.OpReplace)
+		}
+		prompt := "\b" + opts.Color.Colorize(
+			colors.SpecPrompt+fmt.Sprintf("Do you want to %s %s?", verb, step.URN.Name())+colors.Reset)
+
+		cmdutil.EndKeypadTransmitMode()
+
+		var response string
+		if err := survey.AskOne(&survey.Select{
+			Message: prompt,
+			Options: []string{apply, skip, abort},
+			Default: skip,
+		}, &response, nil); err != nil {
+			return engine.StepAbort, fmt.Errorf("confirmation cancelled, not proceeding with the update: %w", err)
+		}
+
+		switch response {
+		case apply:
+			return engine.StepApprove, nil
+		case skip:
+			return engine.StepSkip, nil
+		default:
+			return engine.StepAbort, nil
+		}
+	}
+}
+
 func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind, stack Stack,
 	op UpdateOperation, apply Applier) (engine.ResourceChanges, result.Result) {
 	// Preview the operation to the user and ask them if they want to proceed.
-
+	//
+	// Change guards are checked as steps are generated, so if any are configured, always preview first. This ensures
+	// that a refused deletion or replacement fails the operation before any step executes. A resource that sets the
+	// refuseReplace option is instead refused as its steps are generated, before any of them execute.
+	if op.Opts.SkipPreview && hasChangeGuards(op) {
+		cmdutil.Diag().Warningf(diag.Message("", "ignoring --skip-preview: a preview is required to check change "+
+			"guards before any resources are modified"))
+		op.Opts.SkipPreview = false
+	}
 	if !op.Opts.SkipPreview {
-		changes, res := PreviewThenPrompt(ctx, kind, stack, op, apply)
+		changes, events, res := previewThenPrompt(ctx, kind, stack, op, apply)
 		if res != nil || kind == apitype.PreviewUpdate {
 			return changes, res
 		}
+
+		// The steps planned by the preview are used to estimate the update's progress.
+		op.Opts.Display.PlannedSteps = display.PlannedSteps(events)
 	}
 
 	// Perform the change (!DryRun) and show the cloud link to the result.
@@ -211,6 +291,14 @@ func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind,
 	return apply(ctx, kind, stack, op, opts, nil /*events*/)
 }
 
+// hasChangeGuards returns true if the given operation is configured to refuse to delete or replace any resources.
+func hasChangeGuards(op UpdateOperation) bool {
+	if len(op.Opts.Engine.RefuseReplace) > 0 || len(op.Opts.Engine.RefuseDelete) > 0 {
+		return true
+	}
+	return op.Proj != nil && op.Proj.Options != nil && op.Proj.Options.Guard != nil
+}
+
 func createDiff(updateKind apitype.UpdateKind, events []engine.Event, displayOpts display.Options) string {
 	buff := &bytes.Buffer{}
 
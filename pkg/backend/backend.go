@@ -238,8 +238,9 @@ type QueryOperation struct {
 
 // StackConfiguration holds the configuration for a stack and it's associated decrypter.
 type StackConfiguration struct {
-	Config    config.Map
-	Decrypter config.Decrypter
+	Config        config.Map
+	Decrypter     config.Decrypter
+	PolicyWaivers []deploy.PolicyWaiver
 }
 
 // UpdateOptions is the full set of update options, including backend and engine options.
@@ -0,0 +1,622 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// browserFilter restricts the preview browser to resources with particular operations.
+type browserFilter struct {
+	name    string
+	matches func(op deploy.StepOp) bool
+}
+
+var browserFilters = []browserFilter{
+	{"all", func(op deploy.StepOp) bool { return true }},
+	{"changes", func(op deploy.StepOp) bool { return op != deploy.OpSame && op != deploy.OpRead }},
+	{"creates", func(op deploy.StepOp) bool { return op == deploy.OpCreate || op == deploy.OpImport }},
+	{"updates", func(op deploy.StepOp) bool { return op == deploy.OpUpdate }},
+	{"replaces", func(op deploy.StepOp) bool {
+		return op == deploy.OpReplace || op == deploy.OpCreateReplacement || op == deploy.OpDeleteReplaced ||
+			op == deploy.OpImportReplacement
+	}},
+	{"deletes", func(op deploy.StepOp) bool { return op == deploy.OpDelete }},
+}
+
+// browserKey is a key pressed in the preview browser. Printable keys are reported as keyRune.
+type browserKey int
+
+const (
+	keyRune browserKey = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyEscape
+	keyBackspace
+)
+
+// browserNode is a resource in the preview browser's tree.
+type browserNode struct {
+	row      ResourceRow
+	parent   *browserNode
+	children []*browserNode
+}
+
+func (node *browserNode) urn() resource.URN {
+	return node.row.Step().URN
+}
+
+// browserLine is a resource that is currently visible in the preview browser, along with its depth in the tree.
+type browserLine struct {
+	node  *browserNode
+	depth int
+}
+
+// previewBrowser is a full-screen view of the resource rows of a completed preview. It lets the user collapse and
+// expand components, filter by operation, search by name, view a resource's detailed diff, and mark resources as
+// targets or replacements for a follow-up update.
+type previewBrowser struct {
+	display *ProgressDisplay
+	roots   []*browserNode
+
+	collapsed map[*browserNode]bool
+	filter    int
+	search    string
+	searching bool
+
+	cursor int
+	offset int
+	height int
+
+	targets  map[resource.URN]bool
+	replaces map[resource.URN]bool
+	marked   []resource.URN
+
+	// The lines of the detailed diff being viewed, if any.
+	detail       []string
+	detailOffset int
+}
+
+// newPreviewBrowser creates a browser over the rows of the given display, which must have finished.
+func newPreviewBrowser(display *ProgressDisplay) *previewBrowser {
+	b := &previewBrowser{
+		display:   display,
+		collapsed: make(map[*browserNode]bool),
+		targets:   make(map[resource.URN]bool),
+		replaces:  make(map[resource.URN]bool),
+	}
+
+	// Build the same tree the progress display shows, minus its header.
+	treeNodes := display.generateTreeNodes()
+	treeNodes = display.filterOutUnnecessaryNodesAndSetDisplayTimes(treeNodes)
+	sortNodes(treeNodes)
+
+	var convert func(nodes []*treeNode, parent *browserNode) []*browserNode
+	convert = func(nodes []*treeNode, parent *browserNode) []*browserNode {
+		var result []*browserNode
+		for _, n := range nodes {
+			row, ok := n.row.(ResourceRow)
+			if !ok {
+				continue
+			}
+			node := &browserNode{row: row, parent: parent}
+			node.children = convert(n.childNodes, node)
+			result = append(result, node)
+		}
+		return result
+	}
+	b.roots = convert(treeNodes, nil)
+	return b
+}
+
+// op returns the operation shown for the given node.
+func (b *previewBrowser) op(node *browserNode) deploy.StepOp {
+	return b.display.getStepOp(node.row.Step())
+}
+
+// matches returns true if the given node satisfies the current filter and search.
+func (b *previewBrowser) matches(node *browserNode) bool {
+	if !browserFilters[b.filter].matches(b.op(node)) {
+		return false
+	}
+	if b.search == "" {
+		return true
+	}
+	search := strings.ToLower(b.search)
+	urn := node.urn()
+	return strings.Contains(strings.ToLower(string(urn.Name())), search) ||
+		strings.Contains(strings.ToLower(string(urn.Type())), search)
+}
+
+// lines returns the visible lines of the tree. A node is visible if it or any of its descendants matches the current
+// filter and search, and none of its ancestors is collapsed.
+func (b *previewBrowser) lines() []browserLine {
+	var result []browserLine
+	var visit func(nodes []*browserNode, depth int)
+	visit = func(nodes []*browserNode, depth int) {
+		for _, node := range nodes {
+			if !b.visible(node) {
+				continue
+			}
+			result = append(result, browserLine{node: node, depth: depth})
+			if !b.collapsed[node] {
+				visit(node.children, depth+1)
+			}
+		}
+	}
+	visit(b.roots, 0)
+	return result
+}
+
+func (b *previewBrowser) visible(node *browserNode) bool {
+	if b.matches(node) {
+		return true
+	}
+	for _, child := range node.children {
+		if b.visible(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// selected returns the node under the cursor, if any.
+func (b *previewBrowser) selected(lines []browserLine) *browserNode {
+	if b.cursor < 0 || b.cursor >= len(lines) {
+		return nil
+	}
+	return lines[b.cursor].node
+}
+
+// moveTo moves the cursor to the given node, if it is visible.
+func (b *previewBrowser) moveTo(lines []browserLine, node *browserNode) {
+	for i, line := range lines {
+		if line.node == node {
+			b.cursor = i
+			return
+		}
+	}
+}
+
+// toggleMark adds the given URN to or removes it from the given set of marks, remembering the order in which
+// resources were first marked.
+func (b *previewBrowser) toggleMark(marks map[resource.URN]bool, urn resource.URN) {
+	if urn == "" || urn == b.display.stackUrn {
+		return
+	}
+	if marks[urn] {
+		delete(marks, urn)
+		return
+	}
+	marks[urn] = true
+	for _, m := range b.marked {
+		if m == urn {
+			return
+		}
+	}
+	b.marked = append(b.marked, urn)
+}
+
+// handleKey updates the browser in response to a key press. It returns true if the browser should exit.
+func (b *previewBrowser) handleKey(key browserKey, ch rune) bool {
+	page := b.height - 4
+	if page < 1 {
+		page = 1
+	}
+
+	// While viewing a detailed diff, keys scroll the diff or return to the tree.
+	if b.detail != nil {
+		switch {
+		case key == keyUp || key == keyRune && ch == 'k':
+			b.detailOffset--
+		case key == keyDown || key == keyRune && ch == 'j':
+			b.detailOffset++
+		case key == keyPageUp:
+			b.detailOffset -= page
+		case key == keyPageDown || key == keyRune && ch == ' ':
+			b.detailOffset += page
+		case key == keyEscape || key == keyEnter || key == keyLeft || key == keyRune && ch == 'q':
+			b.detail = nil
+		}
+		if max := len(b.detail) - page; b.detailOffset > max {
+			b.detailOffset = max
+		}
+		if b.detailOffset < 0 {
+			b.detailOffset = 0
+		}
+		return false
+	}
+
+	// While typing a search, keys edit the search text.
+	if b.searching {
+		switch key {
+		case keyRune:
+			b.search += string(ch)
+		case keyBackspace:
+			if b.search != "" {
+				_, size := utf8.DecodeLastRuneInString(b.search)
+				b.search = b.search[:len(b.search)-size]
+			}
+		case keyEnter:
+			b.searching = false
+		case keyEscape:
+			b.search, b.searching = "", false
+		}
+		b.cursor = 0
+		return false
+	}
+
+	lines := b.lines()
+	node := b.selected(lines)
+	switch {
+	case key == keyUp || key == keyRune && ch == 'k':
+		b.cursor--
+	case key == keyDown || key == keyRune && ch == 'j':
+		b.cursor++
+	case key == keyPageUp:
+		b.cursor -= page
+	case key == keyPageDown:
+		b.cursor += page
+	case key == keyHome || key == keyRune && ch == 'g':
+		b.cursor = 0
+	case key == keyEnd || key == keyRune && ch == 'G':
+		b.cursor = len(lines) - 1
+	case key == keyLeft || key == keyRune && ch == 'h':
+		if node != nil {
+			if len(node.children) > 0 && !b.collapsed[node] {
+				b.collapsed[node] = true
+			} else if node.parent != nil {
+				b.moveTo(lines, node.parent)
+			}
+		}
+	case key == keyRight || key == keyRune && ch == 'l':
+		if node != nil && len(node.children) > 0 {
+			if b.collapsed[node] {
+				delete(b.collapsed, node)
+			} else {
+				b.cursor++
+			}
+		}
+	case key == keyRune && ch == ' ':
+		if node != nil && len(node.children) > 0 {
+			b.collapsed[node] = !b.collapsed[node]
+		}
+	case key == keyEnter:
+		if node != nil {
+			b.detail, b.detailOffset = b.renderDetail(node), 0
+		}
+	case key == keyRune && ch == 'f':
+		b.filter, b.cursor = (b.filter+1)%len(browserFilters), 0
+	case key == keyRune && ch == '/':
+		b.search, b.searching = "", true
+	case key == keyEscape:
+		b.search = ""
+	case key == keyRune && ch == 't':
+		if node != nil {
+			b.toggleMark(b.targets, node.urn())
+		}
+	case key == keyRune && ch == 'r':
+		if node != nil {
+			b.toggleMark(b.replaces, node.urn())
+		}
+	case key == keyRune && ch == 'q':
+		return true
+	}
+
+	if b.cursor >= len(b.lines()) {
+		b.cursor = len(b.lines()) - 1
+	}
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+	return false
+}
+
+// renderDetail renders the detailed diff of the given node's step.
+func (b *previewBrowser) renderDetail(node *browserNode) []string {
+	step := node.row.Step()
+	var buf bytes.Buffer
+	if step.URN == "" {
+		fprintIgnoreError(&buf, "No changes are planned for this resource.")
+	} else {
+		opts := b.display.opts
+		opts.SummaryDiff = false
+		renderDiff(&buf, step, true /*planning*/, opts.Debug, make(map[resource.URN]engine.StepEventMetadata), opts)
+	}
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+}
+
+// render renders the browser to a screen of the given size. Lines may contain color directives and are not trimmed to
+// the width of the screen.
+func (b *previewBrowser) render(height int) []string {
+	b.height = height
+	body := height - 3
+	if body < 1 {
+		body = 1
+	}
+
+	var title string
+	if b.detail != nil {
+		title = colors.SpecHeadline + "Detailed diff" + colors.Reset
+	} else {
+		title = fmt.Sprintf("%sPreview of %s%s  filter: %s", colors.SpecHeadline, b.display.stack, colors.Reset,
+			browserFilters[b.filter].name)
+		if b.search != "" || b.searching {
+			title += fmt.Sprintf("  search: %s", b.search)
+			if b.searching {
+				title += "_"
+			}
+		}
+		if n := len(b.targets) + len(b.replaces); n > 0 {
+			title += fmt.Sprintf("  marked: %d", n)
+		}
+	}
+	screen := []string{title, ""}
+
+	var help string
+	if b.detail != nil {
+		start, end := window(len(b.detail), b.detailOffset, body-1)
+		screen = append(screen, b.detail[start:end]...)
+		help = "↑↓ scroll  esc back"
+	} else {
+		screen = append(screen, b.renderTree(body-1)...)
+		help = "↑↓ move  ←→ collapse/expand  enter diff  f filter  / search  t target  r replace  q quit"
+	}
+	for len(screen) < height-1 {
+		screen = append(screen, "")
+	}
+	return append(screen, colors.SpecUnimportant+help+colors.Reset)
+}
+
+// renderTree renders the header and the visible lines of the tree, scrolled so that the cursor is visible.
+func (b *previewBrowser) renderTree(rows int) []string {
+	lines := b.lines()
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	}
+	if b.cursor >= b.offset+rows {
+		b.offset = b.cursor - rows + 1
+	}
+	if b.offset < 0 {
+		b.offset = 0
+	}
+
+	// Gather the columns of every visible line so that they can be aligned. The type column is prefixed with the
+	// cursor, marks and tree structure.
+	table := [][]string{b.display.headerRow.ColorizedColumns()}
+	table[0] = append([]string{""}, table[0]...)
+	for i, line := range lines {
+		node := line.node
+		columns := node.row.ColorizedColumns()
+
+		prefix := "  "
+		if i == b.cursor {
+			prefix = colors.SpecHeadline + "> " + colors.Reset
+		}
+		var marks string
+		if b.targets[node.urn()] {
+			marks += colors.SpecInfo + "T" + colors.Reset
+		}
+		if b.replaces[node.urn()] {
+			marks += colors.SpecInfo + "R" + colors.Reset
+		}
+
+		expander := "  "
+		if len(node.children) > 0 {
+			expander = "▾ "
+			if b.collapsed[node] {
+				expander = "▸ "
+			}
+		}
+		columns[typeColumn] = strings.Repeat("  ", line.depth) + expander + columns[typeColumn]
+		table = append(table, append([]string{prefix + marks}, columns...))
+	}
+	removeInfoColumnIfUnneeded(table)
+
+	widths := make([]int, len(table[0]))
+	for _, columns := range table {
+		for i, column := range columns {
+			if w := utf8.RuneCountInString(b.display.uncolorizeString(column)); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	format := func(columns []string) string {
+		var line string
+		for i, column := range columns {
+			line += column
+			if i < len(columns)-1 {
+				line += strings.Repeat(" ", widths[i]-utf8.RuneCountInString(b.display.uncolorizeString(column))+1)
+			}
+		}
+		return strings.TrimRight(line, " ")
+	}
+
+	result := []string{format(table[0])}
+	start, end := window(len(lines), b.offset, rows)
+	for _, columns := range table[1+start : 1+end] {
+		result = append(result, format(columns))
+	}
+	return result
+}
+
+// window returns the bounds of the given number of rows starting at the given offset within a list of n lines.
+func window(n, offset, rows int) (int, int) {
+	if offset > n {
+		offset = n
+	}
+	end := offset + rows
+	if end > n {
+		end = n
+	}
+	return offset, end
+}
+
+// followUpCommand returns the `pulumi up` command that updates the marked resources, if any were marked.
+func (b *previewBrowser) followUpCommand() string {
+	if len(b.targets) == 0 && len(b.replaces) == 0 {
+		return ""
+	}
+
+	args := []string{"pulumi", "up"}
+	if b.display.stack != "" {
+		args = append(args, "--stack", string(b.display.stack))
+	}
+	for _, urn := range b.marked {
+		if b.targets[urn] {
+			args = append(args, "--target", fmt.Sprintf("'%s'", urn))
+		}
+	}
+	for _, urn := range b.marked {
+		if b.replaces[urn] {
+			args = append(args, "--replace", fmt.Sprintf("'%s'", urn))
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// readBrowserKey reads a single key press from the terminal.
+func readBrowserKey(r *bufio.Reader) (browserKey, rune, error) {
+	ch, _, err := r.ReadRune()
+	if err != nil {
+		return keyRune, 0, err
+	}
+
+	switch ch {
+	case '\r', '\n':
+		return keyEnter, ch, nil
+	case 0x7f, 0x08:
+		return keyBackspace, ch, nil
+	case 0x03, 0x04:
+		// Treat Ctrl-C and Ctrl-D as a request to quit.
+		return keyRune, 'q', nil
+	case 0x1b:
+		// A lone escape is the escape key; otherwise this is the start of an escape sequence.
+		if r.Buffered() == 0 {
+			return keyEscape, ch, nil
+		}
+		seq := []rune{}
+		for r.Buffered() > 0 {
+			next, _, err := r.ReadRune()
+			if err != nil {
+				return keyEscape, ch, err
+			}
+			seq = append(seq, next)
+			if len(seq) > 1 && (next >= 'A' && next <= 'Z' || next == '~') {
+				break
+			}
+		}
+		switch string(seq) {
+		case "[A", "OA":
+			return keyUp, 0, nil
+		case "[B", "OB":
+			return keyDown, 0, nil
+		case "[C", "OC":
+			return keyRight, 0, nil
+		case "[D", "OD":
+			return keyLeft, 0, nil
+		case "[5~":
+			return keyPageUp, 0, nil
+		case "[6~":
+			return keyPageDown, 0, nil
+		case "[H", "OH", "[1~":
+			return keyHome, 0, nil
+		case "[F", "OF", "[4~":
+			return keyEnd, 0, nil
+		}
+		return keyEscape, ch, nil
+	}
+	return keyRune, ch, nil
+}
+
+// browse runs the preview browser on the terminal until the user quits, then prints the command that updates any
+// marked resources.
+func (display *ProgressDisplay) browse(stdin *os.File, stdout io.Writer) error {
+	b := newPreviewBrowser(display)
+
+	fd := int(stdin.Fd())
+	state, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+
+	// Switch to the alternate screen and hide the cursor while browsing.
+	fprintIgnoreError(stdout, "\x1b[?1049h\x1b[?25l")
+	err = b.run(stdin, stdout)
+	fprintIgnoreError(stdout, "\x1b[?25h\x1b[?1049l")
+	contract.IgnoreError(terminal.Restore(fd, state))
+	if err != nil {
+		return err
+	}
+
+	if command := b.followUpCommand(); command != "" {
+		fprintIgnoreError(stdout, "To update the marked resources, run:\n    "+command+"\n")
+	}
+	return nil
+}
+
+func (b *previewBrowser) run(stdin io.Reader, stdout io.Writer) error {
+	reader := bufio.NewReader(stdin)
+	for {
+		width, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			return err
+		}
+
+		var screen bytes.Buffer
+		screen.WriteString("\x1b[H\x1b[2J")
+		for i, line := range b.render(height) {
+			if i > 0 {
+				screen.WriteString("\r\n")
+			}
+			screen.WriteString(b.display.opts.Color.Colorize(colors.TrimColorizedString(line, width-1)))
+		}
+		if _, err = stdout.Write(screen.Bytes()); err != nil {
+			return err
+		}
+
+		key, ch, err := readBrowserKey(reader)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if b.handleKey(key, ch) {
+			return nil
+		}
+	}
+}
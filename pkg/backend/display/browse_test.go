@@ -0,0 +1,148 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+func TestPreviewBrowser(t *testing.T) {
+	t.Parallel()
+
+	display := &ProgressDisplay{
+		opts:                   Options{Color: colors.Never},
+		stack:                  "dev",
+		proj:                   "proj",
+		isPreview:              true,
+		isTerminal:             true,
+		done:                   true,
+		eventUrnToResourceRow:  make(map[resource.URN]ResourceRow),
+		colorizedToUncolorized: make(map[string]string),
+	}
+	display.stackUrn = resource.DefaultRootStackURN(display.stack, display.proj)
+	display.headerRow = &headerRowData{display: display}
+
+	urn := func(typ tokens.Type, name string) resource.URN {
+		return resource.NewURN(display.stack, display.proj, "", typ, tokens.QName(name))
+	}
+	compURN := urn("my:component:Comp", "comp")
+	aURN, bURN, cURN := urn("pkgA:m:typA", "a"), urn("pkgA:m:typA", "b"), urn("pkgA:m:typA", "c")
+	addRow := func(index int, op deploy.StepOp, u, parent resource.URN) {
+		state := &resource.State{URN: u, Type: u.Type(), Parent: parent,
+			Inputs: resource.PropertyMap{"x": resource.NewStringProperty("y")}}
+		display.eventUrnToResourceRow[u] = &resourceRowData{
+			display:           display,
+			displayOrderIndex: index,
+			diagInfo:          &DiagInfo{},
+			step: engine.StepEventMetadata{Op: op, URN: u, Type: u.Type(),
+				New: &engine.StepEventStateMetadata{URN: u, Type: u.Type(), Parent: parent, State: state, Inputs: state.Inputs},
+				Res: &engine.StepEventStateMetadata{URN: u, Type: u.Type(), Parent: parent, State: state, Inputs: state.Inputs}},
+		}
+	}
+	addRow(1, deploy.OpSame, display.stackUrn, "")
+	addRow(2, deploy.OpSame, compURN, display.stackUrn)
+	addRow(3, deploy.OpCreate, aURN, compURN)
+	addRow(4, deploy.OpCreateReplacement, bURN, compURN)
+	addRow(5, deploy.OpDelete, cURN, display.stackUrn)
+
+	b := newPreviewBrowser(display)
+	names := func() []string {
+		var result []string
+		for _, line := range b.lines() {
+			result = append(result, string(line.node.urn().Name()))
+		}
+		return result
+	}
+	press := func(keys ...interface{}) {
+		for _, k := range keys {
+			switch k := k.(type) {
+			case browserKey:
+				b.handleKey(k, 0)
+			case rune:
+				b.handleKey(keyRune, k)
+			}
+		}
+	}
+
+	assert.Equal(t, []string{"proj-dev", "comp", "a", "b", "c"}, names())
+
+	// Filters keep the ancestors of matching resources for context.
+	press('f', 'f')
+	assert.Equal(t, "creates", browserFilters[b.filter].name)
+	assert.Equal(t, []string{"proj-dev", "comp", "a"}, names())
+	press('f', 'f')
+	assert.Equal(t, []string{"proj-dev", "comp", "b"}, names())
+	press('f')
+	assert.Equal(t, []string{"proj-dev", "c"}, names())
+	press('f')
+	assert.Equal(t, "all", browserFilters[b.filter].name)
+
+	// Searching matches resource names.
+	press('/', 'b', keyEnter)
+	assert.Equal(t, []string{"proj-dev", "comp", "b"}, names())
+	press(keyEscape)
+	assert.Equal(t, []string{"proj-dev", "comp", "a", "b", "c"}, names())
+
+	// Components can be collapsed and expanded.
+	press(keyDown, keyLeft)
+	assert.Equal(t, []string{"proj-dev", "comp", "c"}, names())
+	press(keyRight)
+	assert.Equal(t, []string{"proj-dev", "comp", "a", "b", "c"}, names())
+
+	// Resources can be marked as targets or replacements for a follow-up update.
+	press(keyDown, 't', keyDown, 'r')
+	assert.Equal(t, "pulumi up --stack dev --target '"+string(aURN)+"' --replace '"+string(bURN)+"'",
+		b.followUpCommand())
+	press('r')
+	assert.Equal(t, "pulumi up --stack dev --target '"+string(aURN)+"'", b.followUpCommand())
+
+	// The detailed diff of the selected resource can be opened and closed.
+	press(keyUp, keyEnter)
+	assert.NotEmpty(t, b.detail)
+	screen := b.render(10)
+	assert.Len(t, screen, 10)
+	assert.Contains(t, strings.Join(screen, "\n"), "x")
+	press(keyEscape)
+	assert.Nil(t, b.detail)
+
+	screen = b.render(10)
+	assert.Len(t, screen, 10)
+	assert.Contains(t, colors.Never.Colorize(screen[5]), "> T")
+}
+
+func TestReadBrowserKey(t *testing.T) {
+	t.Parallel()
+
+	r := bufio.NewReader(strings.NewReader("\x1b[Aj\r\x1b[6~\x7f"))
+	for _, expected := range []struct {
+		key browserKey
+		ch  rune
+	}{{keyUp, 0}, {keyRune, 'j'}, {keyEnter, '\r'}, {keyPageDown, 0}, {keyBackspace, 0x7f}} {
+		key, ch, err := readBrowserKey(r)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.key, key)
+		assert.Equal(t, expected.ch, ch)
+	}
+}
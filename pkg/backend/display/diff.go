@@ -98,7 +98,7 @@ func RenderDiffEvent(action apitype.UpdateKind, event engine.Event,
 	seen map[resource.URN]engine.StepEventMetadata, opts Options) string {
 
 	switch event.Type {
-	case engine.CancelEvent:
+	case engine.CancelEvent, engine.ProviderCallEvent:
 		return ""
 
 		// Currently, prelude, summary, and stdout events are printed the same for both the diff and
@@ -124,6 +124,8 @@ func RenderDiffEvent(action apitype.UpdateKind, event engine.Event,
 		return renderDiffDiagEvent(event.Payload().(engine.DiagEventPayload), opts)
 	case engine.PolicyViolationEvent:
 		return renderDiffPolicyViolationEvent(event.Payload().(engine.PolicyViolationEventPayload), opts)
+	case engine.PolicyRemediationEvent:
+		return renderDiffPolicyRemediationEvent(event.Payload().(engine.PolicyRemediationEventPayload), opts)
 
 	default:
 		contract.Failf("unknown event type '%s'", event.Type)
@@ -142,6 +144,23 @@ func renderDiffPolicyViolationEvent(payload engine.PolicyViolationEventPayload,
 	return opts.Color.Colorize(payload.Prefix + payload.Message)
 }
 
+func renderDiffPolicyRemediationEvent(payload engine.PolicyRemediationEventPayload, opts Options) string {
+	return renderPolicyRemediation(payload, 0, opts)
+}
+
+// renderPolicyRemediation renders the policy that remediated a resource, followed by the changes it made to the
+// resource's inputs.
+func renderPolicyRemediation(payload engine.PolicyRemediationEventPayload, indent int, opts Options) string {
+	var b bytes.Buffer
+	fprintfIgnoreError(&b, "%s%s[%s]  %s v%s %s %s (%s: %s)\n", engine.GetIndentationString(indent),
+		colors.SpecInfo, apitype.Remediate, payload.PolicyPackName, payload.PolicyPackVersion, colors.Reset,
+		payload.PolicyName, payload.ResourceURN.Type(), payload.ResourceURN.Name())
+	if diff := payload.Before.Diff(payload.After); diff != nil {
+		engine.PrintObjectDiff(&b, *diff, diff.ChangedKeys(), true /*planning*/, indent+2, opts.SummaryDiff, opts.Debug)
+	}
+	return opts.Color.Colorize(b.String())
+}
+
 func renderStdoutColorEvent(payload engine.StdoutEventPayload, opts Options) string {
 	return opts.Color.Colorize(payload.Message)
 }
@@ -0,0 +1,142 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+)
+
+func TestEncodedValueDiff(t *testing.T) {
+	t.Parallel()
+
+	step := func(old, new string, detailed bool) engine.StepEventMetadata {
+		urn := resource.URN("urn:pulumi:dev::proj::pkgA:m:typA::res")
+		olds := resource.PropertyMap{"doc": resource.NewStringProperty(old)}
+		news := resource.PropertyMap{"doc": resource.NewStringProperty(new)}
+		m := engine.StepEventMetadata{
+			Op:    deploy.OpUpdate,
+			URN:   urn,
+			Type:  urn.Type(),
+			Old:   &engine.StepEventStateMetadata{URN: urn, Type: urn.Type(), Inputs: olds, Outputs: olds},
+			New:   &engine.StepEventStateMetadata{URN: urn, Type: urn.Type(), Inputs: news},
+			Res:   &engine.StepEventStateMetadata{URN: urn, Type: urn.Type(), Inputs: news},
+			Diffs: []resource.PropertyKey{"doc"},
+		}
+		if detailed {
+			m.DetailedDiff = map[string]plugin.PropertyDiff{"doc": {Kind: plugin.DiffUpdate}}
+		}
+		return m
+	}
+	render := func(m engine.StepEventMetadata) string {
+		var buf bytes.Buffer
+		renderDiff(&buf, m, true, false, map[resource.URN]engine.StepEventMetadata{}, Options{Color: colors.Never})
+		return buf.String()
+	}
+
+	const (
+		oldPolicy = `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject"}]}`
+		newPolicy = `{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": "s3:GetObject"}],
+			"Id": "policy"}`
+	)
+
+	// JSON documents are diffed structurally, both with and without a detailed diff from the provider.
+	for _, detailed := range []bool{true, false} {
+		out := render(step(oldPolicy, newPolicy, detailed))
+		assert.Contains(t, out, "~ doc: (json) {")
+		assert.Contains(t, out, `+ Id       : "policy"`)
+		assert.Contains(t, out, `~ Effect: "Allow" => "Deny"`)
+		assert.NotContains(t, out, "Statement\": [")
+	}
+
+	changes, ok := encodedValueChanges(step(oldPolicy, newPolicy, true), "doc", false)
+	assert.True(t, ok)
+	assert.Equal(t, []encodedValueChange{
+		{Kind: "add", Path: "Id", New: "policy"},
+		{Kind: "update", Path: "Statement[0].Effect", Old: "Allow", New: "Deny"},
+	}, changes)
+
+	// YAML documents are diffed structurally, too.
+	oldManifest := "kind: ConfigMap\nmetadata:\n  name: config\ndata:\n  key: one\n"
+	newManifest := "kind: ConfigMap\nmetadata:\n  name: config\ndata:\n  key: two\n  \"other.key\": three\n"
+	out := render(step(oldManifest, newManifest, true))
+	assert.Contains(t, out, "~ doc: (yaml) {")
+	assert.Contains(t, out, `~ key      : "one" => "two"`)
+	changes, ok = encodedValueChanges(step(oldManifest, newManifest, true), "doc", false)
+	assert.True(t, ok)
+	assert.Equal(t, []encodedValueChange{
+		{Kind: "update", Path: "data.key", Old: "one", New: "two"},
+		{Kind: "add", Path: `data["other.key"]`, New: "three"},
+	}, changes)
+
+	// Documents that only differ in formatting are reported as unchanged.
+	out = render(step(oldPolicy, "\n"+oldPolicy+"\n", true))
+	assert.NotContains(t, out, "~ doc")
+	assert.Contains(t, out, "doc: (json) {")
+	changes, ok = encodedValueChanges(step(oldPolicy, "\n"+oldPolicy+"\n", true), "doc", false)
+	assert.True(t, ok)
+	assert.Empty(t, changes)
+
+	// The JSON preview still lists the property, with an empty encoded diff.
+	digest, err := json.Marshal(previewDetailedDiff(step(oldPolicy, "\n"+oldPolicy+"\n", true)))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"doc": {"kind": "update", "inputDiff": false, "encodedDiff": []}}`, string(digest))
+
+	// Other strings are diffed as before.
+	out = render(step("one", "two", true))
+	assert.Contains(t, out, `~ doc: "one" => "two"`)
+	_, ok = encodedValueChanges(step("one", "two", true), "doc", false)
+	assert.False(t, ok)
+	_, ok = encodedValueChanges(step("a: b", "a: c", true), "doc", false)
+	assert.False(t, ok)
+}
+
+func TestRenderPolicyRemediation(t *testing.T) {
+	t.Parallel()
+
+	payload := engine.PolicyRemediationEventPayload{
+		ResourceURN:       "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs",
+		PolicyName:        "private-buckets",
+		PolicyPackName:    "pack",
+		PolicyPackVersion: "1.0.0",
+		Before: resource.PropertyMap{
+			"acl":  resource.NewStringProperty("public-read"),
+			"name": resource.NewStringProperty("logs"),
+		},
+		After: resource.PropertyMap{
+			"acl":  resource.NewStringProperty("private"),
+			"name": resource.NewStringProperty("logs"),
+			"tags": resource.NewObjectProperty(resource.PropertyMap{"owner": resource.NewStringProperty("me")}),
+		},
+	}
+	actual := renderPolicyRemediation(payload, 0, Options{Color: colors.Never})
+
+	// Only the properties that the policy changed are shown.
+	expected := "[remediate]  pack v1.0.0  private-buckets (aws:s3/bucket:Bucket: logs)\n" +
+		"      ~ acl : \"public-read\" => \"private\"\n" +
+		"      + tags: {\n" +
+		"          + owner: \"me\"\n" +
+		"        }\n"
+	assert.Equal(t, expected, actual)
+}
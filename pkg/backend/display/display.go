@@ -42,6 +42,21 @@ func ShowEvents(
 	if opts.EventLogPath != "" {
 		events, done = startEventLogger(events, done, opts)
 	}
+	if opts.EventSink != "" {
+		events, done = startEventSink(events, done, opts)
+	}
+	if opts.ProfilePath != "" && !isPreview {
+		events, done = startProfiler(events, done, opts)
+	}
+	if opts.ExplainURN != "" && isPreview && !opts.JSONDisplay {
+		events, done = startExplainer(events, done, opts)
+	}
+	if opts.ReportPath != "" && isPreview {
+		events, done = startReporter(events, done, stack, opts)
+	}
+	if opts.PolicyReportPath != "" {
+		events, done = startPolicyReporter(events, done, opts)
+	}
 
 	streamPreview := cmdutil.IsTruthy(os.Getenv("PULUMI_ENABLE_STREAMING_JSON_PREVIEW"))
 
@@ -105,21 +120,30 @@ func startEventLogger(events <-chan engine.Event, done chan<- bool, opts Options
 		return events, done
 	}
 
+	sequence := 0
+	encoder := json.NewEncoder(logFile)
+	encoder.SetEscapeHTML(false)
+	return passThroughEvents(events, done, func(e engine.Event) {
+		if err := logJSONEvent(encoder, e, opts, sequence); err != nil {
+			logging.V(7).Infof("failed to log event: %v", err)
+		}
+		sequence++
+	}, func() {
+		contract.IgnoreError(logFile.Close())
+	})
+}
+
+// passThroughEvents wraps the given event channels so that handle is called with each event before it is passed on to
+// the display, until the operation is cancelled. Once the display has finished, finish is called, if it is non-nil.
+func passThroughEvents(events <-chan engine.Event, done chan<- bool, handle func(e engine.Event),
+	finish func()) (<-chan engine.Event, chan<- bool) {
+
 	outEvents, outDone := make(chan engine.Event), make(chan bool)
 	go func() {
 		defer close(done)
-		defer func() {
-			contract.IgnoreError(logFile.Close())
-		}()
 
-		sequence := 0
-		encoder := json.NewEncoder(logFile)
-		encoder.SetEscapeHTML(false)
 		for e := range events {
-			if err = logJSONEvent(encoder, e, opts, sequence); err != nil {
-				logging.V(7).Infof("failed to log event: %v", err)
-			}
-			sequence++
+			handle(e)
 
 			outEvents <- e
 
@@ -129,6 +153,10 @@ func startEventLogger(events <-chan engine.Event, done chan<- bool, opts Options
 		}
 
 		<-outDone
+
+		if finish != nil {
+			finish()
+		}
 	}()
 
 	return outEvents, outDone
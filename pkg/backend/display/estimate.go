@@ -0,0 +1,221 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+const (
+	// overdueFactor is how many times longer than its history a step must take before it is flagged as overdue.
+	overdueFactor = 2
+	// overdueMinimum is how much longer than its history a step must take before it is flagged as overdue. This keeps
+	// steps that usually finish in a second or two from being flagged because of ordinary jitter.
+	overdueMinimum = 10 * time.Second
+)
+
+// PlannedStep describes the work that a preview planned for a resource. The steps planned by the preview that precedes
+// an update are used to estimate the update's progress.
+type PlannedStep struct {
+	Op           deploy.StepOp  // the most significant operation planned for the resource.
+	Type         tokens.Type    // the resource's type.
+	Dependencies []resource.URN // the resources that must be processed before this resource.
+}
+
+// PlannedSteps returns the steps planned for each resource by the given preview events.
+func PlannedSteps(events []engine.Event) map[resource.URN]PlannedStep {
+	planned := make(map[resource.URN]PlannedStep)
+	for _, e := range events {
+		if e.Type != engine.ResourcePreEvent {
+			continue
+		}
+		step := e.Payload().(engine.ResourcePreEventPayload).Metadata
+
+		// A replacement is planned as several steps for the same resource; any step that does work is enough to
+		// know that the resource will take time.
+		if existing, has := planned[step.URN]; has && !isEstimatedOp(step.Op) && isEstimatedOp(existing.Op) {
+			continue
+		}
+
+		var deps []resource.URN
+		state := step.New
+		if state == nil {
+			state = step.Old
+		}
+		if state != nil && state.State != nil {
+			deps = append(deps, state.State.Dependencies...)
+			if state.Parent != "" {
+				deps = append(deps, state.Parent)
+			}
+			if ref, err := providers.ParseReference(state.Provider); err == nil {
+				deps = append(deps, ref.URN())
+			}
+		}
+		planned[step.URN] = PlannedStep{Op: step.Op, Type: step.Type, Dependencies: deps}
+	}
+	return planned
+}
+
+// isEstimatedOp returns true if steps with the given operation do work that is expected to take time.
+func isEstimatedOp(op deploy.StepOp) bool {
+	switch op {
+	case deploy.OpSame, deploy.OpReadDiscard, deploy.OpDiscardReplaced, deploy.OpRemovePendingReplace:
+		return false
+	default:
+		return true
+	}
+}
+
+// progressEstimator estimates the time remaining in an update from the steps planned for the update and the step
+// durations recorded by previous updates.
+type progressEstimator struct {
+	history  map[resource.URN]engine.ResourceHistory
+	planned  map[resource.URN]PlannedStep
+	expected map[resource.URN]time.Duration // the time each planned resource is expected to take on its own.
+	started  map[resource.URN]time.Time
+	finished map[resource.URN]bool
+}
+
+// newProgressEstimator creates an estimator for the given planned steps from the given history. A resource with no
+// history of its own is expected to take as long as the average for its type. It returns nil if no steps were planned
+// or there is no history to base an estimate on.
+func newProgressEstimator(history map[resource.URN]engine.ResourceHistory,
+	planned map[resource.URN]PlannedStep) *progressEstimator {
+
+	// Compute the average step duration for each type, as a fallback for resources that have no history.
+	totals := make(map[tokens.Type]time.Duration)
+	counts := make(map[tokens.Type]int)
+	for urn, h := range history {
+		if h.StepDuration > 0 && urn.IsValid() {
+			totals[urn.Type()] += h.StepDuration
+			counts[urn.Type()]++
+		}
+	}
+
+	expected := make(map[resource.URN]time.Duration)
+	known := false
+	for urn, step := range planned {
+		if !isEstimatedOp(step.Op) {
+			continue
+		}
+		d := history[urn].StepDuration
+		if d <= 0 && counts[step.Type] > 0 {
+			d = totals[step.Type] / time.Duration(counts[step.Type])
+		}
+		if d > 0 {
+			expected[urn] = d
+			known = true
+		}
+	}
+	if !known {
+		return nil
+	}
+
+	return &progressEstimator{
+		history:  history,
+		planned:  planned,
+		expected: expected,
+		started:  make(map[resource.URN]time.Time),
+		finished: make(map[resource.URN]bool),
+	}
+}
+
+// start records that a step for the given resource started at the given time.
+func (e *progressEstimator) start(urn resource.URN, at time.Time) {
+	if _, ok := e.started[urn]; !ok {
+		e.started[urn] = at
+	}
+}
+
+// finish records that the steps for the given resource have finished.
+func (e *progressEstimator) finish(urn resource.URN) {
+	e.finished[urn] = true
+}
+
+// cost returns the time the given resource is still expected to take on its own.
+func (e *progressEstimator) cost(urn resource.URN, now time.Time) time.Duration {
+	if e.finished[urn] {
+		return 0
+	}
+	expected := e.expected[urn]
+	if start, ok := e.started[urn]; ok {
+		expected -= now.Sub(start)
+	}
+	if expected < 0 {
+		return 0
+	}
+	return expected
+}
+
+// remaining returns the estimated time until the update finishes: the longest chain of dependent resources that have
+// not yet finished, weighted by how long each resource's planned steps are still expected to take.
+func (e *progressEstimator) remaining(now time.Time) time.Duration {
+	finishes := make(map[resource.URN]time.Duration)
+	visiting := make(map[resource.URN]bool)
+
+	var finish func(urn resource.URN) time.Duration
+	finish = func(urn resource.URN) time.Duration {
+		if d, ok := finishes[urn]; ok {
+			return d
+		}
+		step, ok := e.planned[urn]
+		if !ok || e.finished[urn] || visiting[urn] {
+			return 0
+		}
+
+		visiting[urn] = true
+		var before time.Duration
+		for _, dep := range step.Dependencies {
+			if d := finish(dep); d > before {
+				before = d
+			}
+		}
+		visiting[urn] = false
+
+		d := before + e.cost(urn, now)
+		finishes[urn] = d
+		return d
+	}
+
+	var longest time.Duration
+	for urn := range e.planned {
+		if d := finish(urn); d > longest {
+			longest = d
+		}
+	}
+	return longest
+}
+
+// overdue returns true and the usual duration of the given resource's step if the step is still running and has taken
+// much longer than it did in previous updates.
+func (e *progressEstimator) overdue(urn resource.URN, now time.Time) (time.Duration, bool) {
+	start, ok := e.started[urn]
+	if !ok || e.finished[urn] {
+		return 0, false
+	}
+	usual := e.history[urn].StepDuration
+	if usual <= 0 {
+		return 0, false
+	}
+
+	elapsed := now.Sub(start)
+	return usual, elapsed > overdueFactor*usual && elapsed-usual > overdueMinimum
+}
@@ -0,0 +1,136 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+func TestProgressEstimator(t *testing.T) {
+	t.Parallel()
+
+	// a and b are independent; c depends on both of them.
+	a, b, c := resource.URN("a"), resource.URN("b"), resource.URN("c")
+	e := newProgressEstimator(map[resource.URN]engine.ResourceHistory{
+		a: {StepDuration: 30 * time.Second},
+		b: {StepDuration: 10 * time.Second},
+		c: {StepDuration: 20 * time.Second, Dependencies: []resource.URN{a, b}},
+	}, map[resource.URN]PlannedStep{
+		a: {Op: deploy.OpUpdate},
+		b: {Op: deploy.OpUpdate},
+		c: {Op: deploy.OpUpdate, Dependencies: []resource.URN{a, b}},
+	})
+	assert.NotNil(t, e)
+
+	origin := time.Unix(0, 0)
+	assert.Equal(t, 50*time.Second, e.remaining(origin))
+
+	e.start(a, origin)
+	e.start(b, origin)
+	assert.Equal(t, 40*time.Second, e.remaining(origin.Add(10*time.Second)))
+
+	e.finish(b)
+	e.finish(a)
+	e.start(c, origin.Add(30*time.Second))
+	assert.Equal(t, 15*time.Second, e.remaining(origin.Add(35*time.Second)))
+
+	// A step that runs past its history contributes nothing further to the estimate, and is eventually flagged.
+	assert.Equal(t, time.Duration(0), e.remaining(origin.Add(55*time.Second)))
+	_, overdue := e.overdue(c, origin.Add(65*time.Second))
+	assert.False(t, overdue)
+	usual, overdue := e.overdue(c, origin.Add(75*time.Second))
+	assert.True(t, overdue)
+	assert.Equal(t, 20*time.Second, usual)
+
+	e.finish(c)
+	_, overdue = e.overdue(c, origin.Add(75*time.Second))
+	assert.False(t, overdue)
+}
+
+func TestProgressEstimatorPlannedSteps(t *testing.T) {
+	t.Parallel()
+
+	typ := tokens.Type("pkgA:m:typA")
+	urn := func(name string) resource.URN {
+		return resource.NewURN("dev", "proj", "", typ, tokens.QName(name))
+	}
+	same, updated, created, last := urn("same"), urn("updated"), urn("created"), urn("last")
+	history := map[resource.URN]engine.ResourceHistory{
+		same:    {StepDuration: 100 * time.Second},
+		updated: {StepDuration: 20 * time.Second},
+		last:    {StepDuration: 40 * time.Second},
+	}
+
+	// Resources that are unchanged cost nothing, and new resources are expected to take the average for their type.
+	e := newProgressEstimator(history, map[resource.URN]PlannedStep{
+		same:    {Op: deploy.OpSame, Type: typ},
+		updated: {Op: deploy.OpUpdate, Type: typ, Dependencies: []resource.URN{same}},
+		created: {Op: deploy.OpCreate, Type: typ, Dependencies: []resource.URN{updated}},
+	})
+	assert.NotNil(t, e)
+	assert.Equal(t, 20*time.Second+(160*time.Second)/3, e.remaining(time.Unix(0, 0)))
+
+	// Nothing is estimated if no steps were planned, or if none of them do any work.
+	assert.Nil(t, newProgressEstimator(history, nil))
+	assert.Nil(t, newProgressEstimator(history, map[resource.URN]PlannedStep{same: {Op: deploy.OpSame, Type: typ}}))
+}
+
+func TestPlannedSteps(t *testing.T) {
+	t.Parallel()
+
+	typ := tokens.Type("pkgA:m:typA")
+	a := resource.NewURN("dev", "proj", "", typ, "a")
+	b := resource.NewURN("dev", "proj", "", typ, "b")
+	state := func(urn resource.URN, deps ...resource.URN) *engine.StepEventStateMetadata {
+		return &engine.StepEventStateMetadata{
+			URN:   urn,
+			Type:  typ,
+			State: &resource.State{URN: urn, Type: typ, Dependencies: deps},
+		}
+	}
+	pre := func(op deploy.StepOp, old, new *engine.StepEventStateMetadata) engine.Event {
+		urn := new.URN
+		return engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+			Metadata: engine.StepEventMetadata{Op: op, URN: urn, Type: typ, Old: old, New: new},
+		})
+	}
+
+	planned := PlannedSteps([]engine.Event{
+		pre(deploy.OpSame, state(a), state(a)),
+		pre(deploy.OpCreateReplacement, state(b), state(b, a)),
+		pre(deploy.OpReplace, state(b), state(b, a)),
+		pre(deploy.OpDeleteReplaced, state(b), state(b, a)),
+	})
+	assert.Equal(t, map[resource.URN]PlannedStep{
+		a: {Op: deploy.OpSame, Type: typ},
+		b: {Op: deploy.OpDeleteReplaced, Type: typ, Dependencies: []resource.URN{a}},
+	}, planned)
+}
+
+func TestProgressEstimatorWithoutHistory(t *testing.T) {
+	t.Parallel()
+
+	planned := map[resource.URN]PlannedStep{"a": {Op: deploy.OpUpdate}}
+	assert.Nil(t, newProgressEstimator(nil, planned))
+	assert.Nil(t, newProgressEstimator(map[resource.URN]engine.ResourceHistory{"a": {}}, planned))
+}
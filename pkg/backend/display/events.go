@@ -1,13 +1,20 @@
 package display
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 )
 
@@ -66,6 +73,48 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 			PolicyPackVersionTag: p.PolicyPackVersion,
 			EnforcementLevel:     string(p.EnforcementLevel),
 		}
+		if p.Waiver != nil {
+			apiEvent.PolicyEvent.Waiver = &apitype.PolicyWaiverEvent{
+				Reason:  p.Waiver.Reason,
+				Expires: p.Waiver.Expires.Format(time.RFC3339),
+				Expired: p.Waiver.Expired,
+			}
+		}
+
+	case engine.PolicyRemediationEvent:
+		p, ok := e.Payload().(engine.PolicyRemediationEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		before, err := stack.SerializeProperties(p.Before, config.BlindingCrypter, false /* showSecrets */)
+		contract.IgnoreError(err)
+		after, err := stack.SerializeProperties(p.After, config.BlindingCrypter, false /* showSecrets */)
+		contract.IgnoreError(err)
+		apiEvent.PolicyRemediationEvent = &apitype.PolicyRemediationEvent{
+			ResourceURN:          string(p.ResourceURN),
+			Color:                string(p.Color),
+			PolicyName:           p.PolicyName,
+			PolicyPackName:       p.PolicyPackName,
+			PolicyPackVersion:    p.PolicyPackVersion,
+			PolicyPackVersionTag: p.PolicyPackVersion,
+			Before:               before,
+			After:                after,
+		}
+
+	case engine.ProviderCallEvent:
+		p, ok := e.Payload().(engine.ProviderCallEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		apiEvent.ProviderCallEvent = &apitype.ProviderCallEvent{
+			Provider:  string(p.Provider),
+			Method:    p.Method,
+			URN:       string(p.URN),
+			Token:     string(p.Token),
+			StartTime: p.StartTime.UnixNano(),
+			EndTime:   p.EndTime.UnixNano(),
+			Failed:    p.Failed,
+		}
 
 	case engine.PreludeEvent:
 		p, ok := e.Payload().(engine.PreludeEventPayload)
@@ -104,8 +153,9 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 			return apiEvent, eventTypePayloadMismatch
 		}
 		apiEvent.ResourcePreEvent = &apitype.ResourcePreEvent{
-			Metadata: convertStepEventMetadata(p.Metadata),
-			Planning: p.Planning,
+			Metadata:  convertStepEventMetadata(p.Metadata),
+			Planning:  p.Planning,
+			StartTime: unixNanos(p.StartTime),
 		}
 
 	case engine.ResourceOutputsEvent:
@@ -114,8 +164,10 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 			return apiEvent, eventTypePayloadMismatch
 		}
 		apiEvent.ResOutputsEvent = &apitype.ResOutputsEvent{
-			Metadata: convertStepEventMetadata(p.Metadata),
-			Planning: p.Planning,
+			Metadata:  convertStepEventMetadata(p.Metadata),
+			Planning:  p.Planning,
+			StartTime: unixNanos(p.StartTime),
+			EndTime:   unixNanos(p.EndTime),
 		}
 
 	case engine.ResourceOperationFailed:
@@ -124,9 +176,11 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 			return apiEvent, eventTypePayloadMismatch
 		}
 		apiEvent.ResOpFailedEvent = &apitype.ResOpFailedEvent{
-			Metadata: convertStepEventMetadata(p.Metadata),
-			Status:   int(p.Status),
-			Steps:    p.Steps,
+			Metadata:  convertStepEventMetadata(p.Metadata),
+			Status:    int(p.Status),
+			Steps:     p.Steps,
+			StartTime: unixNanos(p.StartTime),
+			EndTime:   unixNanos(p.EndTime),
 		}
 
 	default:
@@ -186,7 +240,31 @@ func convertStepEventMetadata(md engine.StepEventMetadata) apitype.StepEventMeta
 		DetailedDiff: detailedDiff,
 		Logical:      md.Logical,
 		Provider:     md.Provider,
+
+		ReplaceReasons: convertReplaceReasons(md.ReplaceReasons),
+	}
+}
+
+func convertReplaceReasons(reasons []deploy.ReplaceReason) []apitype.ReplaceReason {
+	if len(reasons) == 0 {
+		return nil
 	}
+
+	result := make([]apitype.ReplaceReason, len(reasons))
+	for i, r := range reasons {
+		var props []string
+		for _, k := range r.Properties {
+			props = append(props, string(k))
+		}
+		result[i] = apitype.ReplaceReason{
+			Kind:            string(r.Kind),
+			Properties:      props,
+			Upstream:        string(r.Upstream),
+			UpstreamReasons: convertReplaceReasons(r.UpstreamReasons),
+			Description:     r.String(),
+		}
+	}
+	return result
 }
 
 // convertStepEventStateMetadata converts the internal StepEventStateMetadata to the API type
@@ -210,13 +288,329 @@ func convertStepEventStateMetadata(md *engine.StepEventStateMetadata) *apitype.S
 		Type: string(md.Type),
 		URN:  string(md.URN),
 
-		Custom:     md.Custom,
-		Delete:     md.Delete,
-		ID:         string(md.ID),
-		Parent:     string(md.Parent),
-		Protect:    md.Protect,
-		Inputs:     inputs,
-		Outputs:    outputs,
-		InitErrors: md.InitErrors,
+		Custom:         md.Custom,
+		Delete:         md.Delete,
+		ID:             string(md.ID),
+		Parent:         string(md.Parent),
+		Protect:        md.Protect,
+		RetainOnDelete: md.RetainOnDelete,
+		Inputs:         inputs,
+		Outputs:        outputs,
+		InitErrors:     md.InitErrors,
+	}
+}
+
+// ConvertJSONEvent converts an apitype.EngineEvent, such as one read back from an event log, into the engine.Event it
+// was converted from. isPreview is recorded in the prelude and summary events, as it is not part of the API type.
+//
+// IMPORTANT: ConvertEngineEvent is lossy, so the converted event does not contain any secret values, the resources'
+// complete states or the timing information that is not part of the API type. Secrets are replaced with "[secret]".
+func ConvertJSONEvent(apiEvent apitype.EngineEvent, isPreview bool) (engine.Event, error) {
+	timestamp := time.Unix(int64(apiEvent.Timestamp), 0)
+
+	switch {
+	case apiEvent.CancelEvent != nil:
+		return engine.NewEvent(engine.CancelEvent, nil), nil
+
+	case apiEvent.StdoutEvent != nil:
+		p := apiEvent.StdoutEvent
+		return engine.NewEvent(engine.StdoutColorEvent, engine.StdoutEventPayload{
+			Message: p.Message,
+			Color:   colors.Colorization(p.Color),
+		}), nil
+
+	case apiEvent.DiagnosticEvent != nil:
+		p := apiEvent.DiagnosticEvent
+		return engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+			URN:       resource.URN(p.URN),
+			Prefix:    p.Prefix,
+			Message:   p.Message,
+			Color:     colors.Colorization(p.Color),
+			Severity:  diag.Severity(p.Severity),
+			StreamID:  int32(p.StreamID),
+			Ephemeral: p.Ephemeral,
+		}), nil
+
+	case apiEvent.PolicyEvent != nil:
+		p := apiEvent.PolicyEvent
+		var waiver *engine.PolicyViolationWaiver
+		if p.Waiver != nil {
+			expires, err := time.Parse(time.RFC3339, p.Waiver.Expires)
+			if err != nil {
+				return engine.Event{}, fmt.Errorf("parsing policy waiver expiry: %w", err)
+			}
+			waiver = &engine.PolicyViolationWaiver{Reason: p.Waiver.Reason, Expires: expires, Expired: p.Waiver.Expired}
+		}
+		return engine.NewEvent(engine.PolicyViolationEvent, engine.PolicyViolationEventPayload{
+			ResourceURN:       resource.URN(p.ResourceURN),
+			Message:           p.Message,
+			Color:             colors.Colorization(p.Color),
+			PolicyName:        p.PolicyName,
+			PolicyPackName:    p.PolicyPackName,
+			PolicyPackVersion: p.PolicyPackVersion,
+			EnforcementLevel:  apitype.EnforcementLevel(p.EnforcementLevel),
+			Waiver:            waiver,
+		}), nil
+
+	case apiEvent.PolicyRemediationEvent != nil:
+		p := apiEvent.PolicyRemediationEvent
+		decrypter := secretMaskingDecrypter{}
+		before, err := stack.DeserializeProperties(p.Before, decrypter, config.BlindingCrypter)
+		if err != nil {
+			return engine.Event{}, err
+		}
+		after, err := stack.DeserializeProperties(p.After, decrypter, config.BlindingCrypter)
+		if err != nil {
+			return engine.Event{}, err
+		}
+		return engine.NewEvent(engine.PolicyRemediationEvent, engine.PolicyRemediationEventPayload{
+			ResourceURN:       resource.URN(p.ResourceURN),
+			Color:             colors.Colorization(p.Color),
+			PolicyName:        p.PolicyName,
+			PolicyPackName:    p.PolicyPackName,
+			PolicyPackVersion: p.PolicyPackVersion,
+			Before:            before,
+			After:             after,
+		}), nil
+
+	case apiEvent.ProviderCallEvent != nil:
+		p := apiEvent.ProviderCallEvent
+		return engine.NewEvent(engine.ProviderCallEvent, engine.ProviderCallEventPayload{
+			Provider:  tokens.Package(p.Provider),
+			Method:    p.Method,
+			URN:       resource.URN(p.URN),
+			Token:     tokens.ModuleMember(p.Token),
+			StartTime: time.Unix(0, p.StartTime),
+			EndTime:   time.Unix(0, p.EndTime),
+			Failed:    p.Failed,
+		}), nil
+
+	case apiEvent.PreludeEvent != nil:
+		return engine.NewEvent(engine.PreludeEvent, engine.PreludeEventPayload{
+			IsPreview: isPreview,
+			Config:    apiEvent.PreludeEvent.Config,
+		}), nil
+
+	case apiEvent.SummaryEvent != nil:
+		p := apiEvent.SummaryEvent
+		changes := make(engine.ResourceChanges)
+		for op, count := range p.ResourceChanges {
+			changes[deploy.StepOp(op)] = count
+		}
+		return engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
+			IsPreview:       isPreview,
+			MaybeCorrupt:    p.MaybeCorrupt,
+			Duration:        time.Duration(p.DurationSeconds) * time.Second,
+			ResourceChanges: changes,
+			PolicyPacks:     p.PolicyPacks,
+		}), nil
+
+	case apiEvent.ResourcePreEvent != nil:
+		p := apiEvent.ResourcePreEvent
+		md, err := convertJSONStepEventMetadata(p.Metadata)
+		if err != nil {
+			return engine.Event{}, err
+		}
+		return engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+			Metadata:  md,
+			Planning:  p.Planning,
+			StartTime: fromUnixNanos(p.StartTime, timestamp),
+		}), nil
+
+	case apiEvent.ResOutputsEvent != nil:
+		p := apiEvent.ResOutputsEvent
+		md, err := convertJSONStepEventMetadata(p.Metadata)
+		if err != nil {
+			return engine.Event{}, err
+		}
+		return engine.NewEvent(engine.ResourceOutputsEvent, engine.ResourceOutputsEventPayload{
+			Metadata:  md,
+			Planning:  p.Planning,
+			StartTime: fromUnixNanos(p.StartTime, time.Time{}),
+			EndTime:   fromUnixNanos(p.EndTime, timestamp),
+		}), nil
+
+	case apiEvent.ResOpFailedEvent != nil:
+		p := apiEvent.ResOpFailedEvent
+		md, err := convertJSONStepEventMetadata(p.Metadata)
+		if err != nil {
+			return engine.Event{}, err
+		}
+		return engine.NewEvent(engine.ResourceOperationFailed, engine.ResourceOperationFailedPayload{
+			Metadata:  md,
+			Status:    resource.Status(p.Status),
+			Steps:     p.Steps,
+			StartTime: fromUnixNanos(p.StartTime, time.Time{}),
+			EndTime:   fromUnixNanos(p.EndTime, timestamp),
+		}), nil
+
+	default:
+		return engine.Event{}, errors.New("unknown event type")
+	}
+}
+
+// unixNanos returns the given time as a Unix timestamp in nanoseconds, or zero if the time is not set.
+func unixNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// fromUnixNanos returns the time for the given Unix timestamp in nanoseconds, or the fallback if the timestamp is not
+// set. Events recorded before step timings were included in event logs have no timestamps.
+func fromUnixNanos(nanos int64, fallback time.Time) time.Time {
+	if nanos == 0 {
+		return fallback
+	}
+	return time.Unix(0, nanos)
+}
+
+func convertJSONStepEventMetadata(md apitype.StepEventMetadata) (engine.StepEventMetadata, error) {
+	keys := make([]resource.PropertyKey, len(md.Keys))
+	for i, k := range md.Keys {
+		keys[i] = resource.PropertyKey(k)
+	}
+	var diffs []resource.PropertyKey
+	for _, k := range md.Diffs {
+		diffs = append(diffs, resource.PropertyKey(k))
+	}
+	var detailedDiff map[string]plugin.PropertyDiff
+	if md.DetailedDiff != nil {
+		detailedDiff = make(map[string]plugin.PropertyDiff)
+		for k, v := range md.DetailedDiff {
+			var d plugin.DiffKind
+			switch v.Kind {
+			case apitype.DiffAdd:
+				d = plugin.DiffAdd
+			case apitype.DiffAddReplace:
+				d = plugin.DiffAddReplace
+			case apitype.DiffDelete:
+				d = plugin.DiffDelete
+			case apitype.DiffDeleteReplace:
+				d = plugin.DiffDeleteReplace
+			case apitype.DiffUpdate:
+				d = plugin.DiffUpdate
+			case apitype.DiffUpdateReplace:
+				d = plugin.DiffUpdateReplace
+			default:
+				return engine.StepEventMetadata{}, fmt.Errorf("unrecognized diff kind %q", v.Kind)
+			}
+			detailedDiff[k] = plugin.PropertyDiff{
+				Kind:      d,
+				InputDiff: v.InputDiff,
+			}
+		}
+	}
+
+	old, err := convertJSONStepEventStateMetadata(md.Old)
+	if err != nil {
+		return engine.StepEventMetadata{}, err
+	}
+	new, err := convertJSONStepEventStateMetadata(md.New)
+	if err != nil {
+		return engine.StepEventMetadata{}, err
+	}
+	res := new
+	if res == nil {
+		res = old
+	}
+
+	return engine.StepEventMetadata{
+		Op:   deploy.StepOp(md.Op),
+		URN:  resource.URN(md.URN),
+		Type: tokens.Type(md.Type),
+
+		Old: old,
+		New: new,
+		Res: res,
+
+		Keys:         keys,
+		Diffs:        diffs,
+		DetailedDiff: detailedDiff,
+		Logical:      md.Logical,
+		Provider:     md.Provider,
+
+		ReplaceReasons: convertJSONReplaceReasons(md.ReplaceReasons),
+	}, nil
+}
+
+func convertJSONReplaceReasons(reasons []apitype.ReplaceReason) []deploy.ReplaceReason {
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	result := make([]deploy.ReplaceReason, len(reasons))
+	for i, r := range reasons {
+		var props []resource.PropertyKey
+		for _, k := range r.Properties {
+			props = append(props, resource.PropertyKey(k))
+		}
+		result[i] = deploy.ReplaceReason{
+			Kind:            deploy.ReplaceReasonKind(r.Kind),
+			Properties:      props,
+			Upstream:        resource.URN(r.Upstream),
+			UpstreamReasons: convertJSONReplaceReasons(r.UpstreamReasons),
+		}
+	}
+	return result
+}
+
+// secretMaskingDecrypter decrypts every secret to "[secret]", as the values of secrets are lost when events are
+// converted to their API types.
+type secretMaskingDecrypter struct{}
+
+func (secretMaskingDecrypter) DecryptValue(ciphertext string) (string, error) {
+	// Secrets are decrypted to their JSON representation.
+	return `"[secret]"`, nil
+}
+
+// convertJSONStepEventStateMetadata converts the API type for a resource's state back into the internal
+// StepEventStateMetadata. As the API type does not contain the resource's complete state, the State of the result
+// only contains the information that the API type does.
+func convertJSONStepEventStateMetadata(md *apitype.StepEventStateMetadata) (*engine.StepEventStateMetadata, error) {
+	if md == nil {
+		return nil, nil
+	}
+
+	decrypter := secretMaskingDecrypter{}
+	inputs, err := stack.DeserializeProperties(md.Inputs, decrypter, config.BlindingCrypter)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := stack.DeserializeProperties(md.Outputs, decrypter, config.BlindingCrypter)
+	if err != nil {
+		return nil, err
+	}
+	inputs, outputs = MassageSecrets(inputs, false), MassageSecrets(outputs, false)
+
+	state := &resource.State{
+		Type:           tokens.Type(md.Type),
+		URN:            resource.URN(md.URN),
+		Custom:         md.Custom,
+		Delete:         md.Delete,
+		ID:             resource.ID(md.ID),
+		Inputs:         inputs,
+		Outputs:        outputs,
+		Parent:         resource.URN(md.Parent),
+		Protect:        md.Protect,
+		Provider:       md.Provider,
+		InitErrors:     md.InitErrors,
+		RetainOnDelete: md.RetainOnDelete,
 	}
+	return &engine.StepEventStateMetadata{
+		State:          state,
+		Type:           state.Type,
+		URN:            state.URN,
+		Custom:         state.Custom,
+		Delete:         state.Delete,
+		ID:             state.ID,
+		Parent:         state.Parent,
+		Protect:        state.Protect,
+		RetainOnDelete: state.RetainOnDelete,
+		Inputs:         inputs,
+		Outputs:        outputs,
+		Provider:       md.Provider,
+		InitErrors:     md.InitErrors,
+	}, nil
 }
@@ -0,0 +1,188 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+)
+
+func TestConvertJSONEvent(t *testing.T) {
+	t.Parallel()
+
+	urn := resource.URN("urn:pulumi:dev::proj::pkgA:m:typA::res")
+	state := func(props resource.PropertyMap) *engine.StepEventStateMetadata {
+		return &engine.StepEventStateMetadata{
+			URN:     urn,
+			Type:    urn.Type(),
+			Custom:  true,
+			ID:      "id",
+			Parent:  "urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev",
+			Inputs:  props,
+			Outputs: props,
+		}
+	}
+	old := state(resource.PropertyMap{"size": resource.NewNumberProperty(10)})
+	new := state(resource.PropertyMap{
+		"size":     resource.NewNumberProperty(20),
+		"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+	})
+	metadata := engine.StepEventMetadata{
+		Op:           deploy.OpReplace,
+		URN:          urn,
+		Type:         urn.Type(),
+		Old:          old,
+		New:          new,
+		Res:          new,
+		Keys:         []resource.PropertyKey{"size"},
+		Diffs:        []resource.PropertyKey{"size"},
+		DetailedDiff: map[string]plugin.PropertyDiff{"size": {Kind: plugin.DiffUpdateReplace}},
+		Logical:      true,
+		Provider:     "urn:pulumi:dev::proj::pulumi:providers:pkgA::default::id",
+		ReplaceReasons: []deploy.ReplaceReason{
+			{Kind: deploy.ReplaceReasonProviderDiff, Properties: []resource.PropertyKey{"size"}},
+		},
+	}
+
+	stepStart := time.Unix(1600000000, 123456789)
+	stepEnd := stepStart.Add(1500 * time.Millisecond)
+
+	events := []engine.Event{
+		engine.NewEvent(engine.PreludeEvent, engine.PreludeEventPayload{
+			IsPreview: true,
+			Config:    map[string]string{"pkgA:region": "west"},
+		}),
+		engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{Metadata: metadata, Planning: true}),
+		engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+			URN:      urn,
+			Message:  "careful",
+			Color:    colors.Raw,
+			Severity: diag.Warning,
+		}),
+		engine.NewEvent(engine.PolicyViolationEvent, engine.PolicyViolationEventPayload{
+			ResourceURN:       urn,
+			Message:           "not allowed",
+			PolicyName:        "policy",
+			PolicyPackName:    "pack",
+			PolicyPackVersion: "1",
+			EnforcementLevel:  apitype.Advisory,
+		}),
+		engine.NewEvent(engine.PolicyViolationEvent, engine.PolicyViolationEventPayload{
+			ResourceURN:       urn,
+			Message:           "not allowed",
+			PolicyName:        "waived",
+			PolicyPackName:    "pack",
+			PolicyPackVersion: "1",
+			EnforcementLevel:  apitype.Advisory,
+			Waiver: &engine.PolicyViolationWaiver{
+				Reason:  "because",
+				Expires: time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+		}),
+		engine.NewEvent(engine.PolicyRemediationEvent, engine.PolicyRemediationEventPayload{
+			ResourceURN:       urn,
+			PolicyName:        "remediation",
+			PolicyPackName:    "pack",
+			PolicyPackVersion: "1",
+			Before:            old.Inputs,
+			After:             new.Inputs,
+		}),
+		engine.NewEvent(engine.ResourceOutputsEvent, engine.ResourceOutputsEventPayload{
+			Metadata:  metadata,
+			Planning:  true,
+			StartTime: stepStart,
+			EndTime:   stepEnd,
+		}),
+		engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
+			IsPreview:       true,
+			ResourceChanges: engine.ResourceChanges{deploy.OpReplace: 1},
+		}),
+		engine.NewEvent(engine.CancelEvent, nil),
+	}
+
+	for i, e := range events {
+		// Round-trip each event through JSON, as an event log does.
+		apiEvent, err := ConvertEngineEvent(e)
+		require.NoError(t, err)
+		apiEvent.Sequence, apiEvent.Timestamp = i, 1600000000+i
+		bytes, err := json.Marshal(apiEvent)
+		require.NoError(t, err)
+		var logged apitype.EngineEvent
+		require.NoError(t, json.Unmarshal(bytes, &logged))
+
+		converted, err := ConvertJSONEvent(logged, true)
+		require.NoError(t, err)
+		assert.Equal(t, e.Type, converted.Type)
+
+		switch e.Type {
+		case engine.ResourcePreEvent:
+			p := converted.Payload().(engine.ResourcePreEventPayload)
+			assert.True(t, p.Planning)
+			assert.Equal(t, time.Unix(int64(1600000000+i), 0), p.StartTime)
+
+			m := p.Metadata
+			assert.Equal(t, metadata.Op, m.Op)
+			assert.Equal(t, metadata.URN, m.URN)
+			assert.Equal(t, metadata.Keys, m.Keys)
+			assert.Equal(t, metadata.Diffs, m.Diffs)
+			assert.Equal(t, metadata.DetailedDiff, m.DetailedDiff)
+			assert.Equal(t, metadata.Logical, m.Logical)
+			assert.Equal(t, metadata.Provider, m.Provider)
+			assert.Equal(t, metadata.ReplaceReasons, m.ReplaceReasons)
+			assert.Equal(t, old.Inputs, m.Old.Inputs)
+			assert.Equal(t, old.Parent, m.Old.Parent)
+			assert.Equal(t, old.ID, m.Old.State.ID)
+			assert.Equal(t, m.New, m.Res)
+
+			// Secrets are lost when events are converted, and are shown masked.
+			assert.Equal(t, resource.NewStringProperty("[secret]"), m.New.Inputs["password"])
+			assert.Equal(t, new.Inputs["size"], m.New.Inputs["size"])
+		case engine.ResourceOutputsEvent:
+			// Step timings are preserved so that replayed event logs can be profiled.
+			p := converted.Payload().(engine.ResourceOutputsEventPayload)
+			assert.True(t, stepStart.Equal(p.StartTime))
+			assert.True(t, stepEnd.Equal(p.EndTime))
+		case engine.DiagEvent:
+			assert.Equal(t, e.Payload(), converted.Payload())
+		case engine.PolicyViolationEvent:
+			assert.Equal(t, e.Payload(), converted.Payload())
+		case engine.PolicyRemediationEvent:
+			p := converted.Payload().(engine.PolicyRemediationEventPayload)
+			assert.Equal(t, "remediation", p.PolicyName)
+			assert.Equal(t, old.Inputs, p.Before)
+			assert.True(t, p.After["password"].IsSecret())
+			assert.Equal(t, new.Inputs["size"], p.After["size"])
+		case engine.PreludeEvent:
+			assert.Equal(t, e.Payload(), converted.Payload())
+		case engine.SummaryEvent:
+			assert.Equal(t, e.Payload(), converted.Payload())
+		}
+	}
+
+	_, err := ConvertJSONEvent(apitype.EngineEvent{}, false)
+	assert.Error(t, err)
+}
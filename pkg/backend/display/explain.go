@@ -0,0 +1,97 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// replaceExplanation accumulates the steps planned for a single resource and the reasons for its replacement.
+type replaceExplanation struct {
+	urn     resource.URN
+	ops     []deploy.StepOp
+	reasons []deploy.ReplaceReason
+}
+
+// handleEvent records the step described by the given event, if it applies to the explained resource.
+func (x *replaceExplanation) handleEvent(e engine.Event) {
+	if e.Type != engine.ResourcePreEvent {
+		return
+	}
+	md := e.Payload().(engine.ResourcePreEventPayload).Metadata
+	if md.URN != x.urn {
+		return
+	}
+
+	x.ops = append(x.ops, md.Op)
+	if len(x.reasons) == 0 {
+		x.reasons = md.ReplaceReasons
+	}
+}
+
+// write renders the explanation to the given writer.
+func (x *replaceExplanation) write(w io.Writer, color colors.Colorization) {
+	fmt.Fprint(w, color.Colorize(fmt.Sprintf("%s%s:%s\n", colors.SpecHeadline, x.urn, colors.Reset)))
+
+	if len(x.ops) == 0 {
+		fmt.Fprintln(w, "    this resource is not part of the preview")
+		return
+	}
+
+	ops := make([]string, len(x.ops))
+	for i, op := range x.ops {
+		ops[i] = string(op)
+	}
+	if len(x.reasons) == 0 {
+		fmt.Fprintf(w, "    this resource will not be replaced (%s)\n", strings.Join(ops, ", "))
+		return
+	}
+
+	fmt.Fprintf(w, "    this resource will be replaced (%s) because:\n", strings.Join(ops, ", "))
+	writeReplaceReasons(w, x.reasons, 1)
+}
+
+func writeReplaceReasons(w io.Writer, reasons []deploy.ReplaceReason, depth int) {
+	indent := strings.Repeat("    ", depth)
+	for _, r := range reasons {
+		fmt.Fprintf(w, "%s  - %s\n", indent, r)
+		if len(r.UpstreamReasons) > 0 {
+			fmt.Fprintf(w, "%s    which is being replaced because:\n", indent)
+			writeReplaceReasons(w, r.UpstreamReasons, depth+1)
+		}
+	}
+}
+
+// startExplainer wraps the given event channels so that, once the display has finished, an explanation of whether
+// and why the resource named by opts.ExplainURN will be replaced is written to stdout.
+func startExplainer(events <-chan engine.Event, done chan<- bool, opts Options) (<-chan engine.Event, chan<- bool) {
+	explanation := &replaceExplanation{urn: opts.ExplainURN}
+	return passThroughEvents(events, done, explanation.handleEvent, func() {
+		stdout := opts.Stdout
+		if stdout == nil {
+			stdout = os.Stdout
+		}
+		fmt.Fprintln(stdout)
+		explanation.write(stdout, opts.Color)
+	})
+}
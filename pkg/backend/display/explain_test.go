@@ -0,0 +1,78 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func TestReplaceExplanation(t *testing.T) {
+	t.Parallel()
+
+	urnA := resource.URN("urn:pulumi:stack::proj::pkgA:m:typA::resA")
+	urnB := resource.URN("urn:pulumi:stack::proj::pkgA:m:typA::resB")
+
+	pre := func(urn resource.URN, op deploy.StepOp, reasons ...deploy.ReplaceReason) engine.Event {
+		return engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+			Metadata: engine.StepEventMetadata{Op: op, URN: urn, ReplaceReasons: reasons},
+		})
+	}
+	explain := func(urn resource.URN, events ...engine.Event) string {
+		x := &replaceExplanation{urn: urn}
+		for _, e := range events {
+			x.handleEvent(e)
+		}
+		var buf bytes.Buffer
+		x.write(&buf, colors.Never)
+		return buf.String()
+	}
+
+	reasonA := deploy.ReplaceReason{Kind: deploy.ReplaceReasonProviderDiff, Properties: []resource.PropertyKey{"b", "a"}}
+	reasonB := deploy.ReplaceReason{
+		Kind:            deploy.ReplaceReasonDependency,
+		Properties:      []resource.PropertyKey{"a"},
+		Upstream:        urnA,
+		UpstreamReasons: []deploy.ReplaceReason{reasonA},
+	}
+	events := []engine.Event{
+		pre(urnB, deploy.OpDeleteReplaced, reasonB),
+		pre(urnA, deploy.OpReplace, reasonA),
+		pre(urnB, deploy.OpReplace, reasonB),
+	}
+
+	assert.Equal(t, string(urnB)+":\n"+
+		"    this resource will be replaced (delete-replaced, replace) because:\n"+
+		"      - dependency '"+string(urnA)+"' is being deleted before it is replaced, which requires replacing "+
+		"this resource because of its properties [a]\n"+
+		"        which is being replaced because:\n"+
+		"          - the provider reported that changes to [a, b] require replacement\n",
+		explain(urnB, events...))
+
+	assert.Equal(t, string(urnA)+":\n"+
+		"    this resource will not be replaced (update)\n",
+		explain(urnA, pre(urnA, deploy.OpUpdate)))
+
+	assert.Equal(t, string(urnA)+":\n"+
+		"    this resource is not part of the preview\n",
+		explain(urnA))
+}
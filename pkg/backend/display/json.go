@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"time"
+	"unicode"
 
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
@@ -28,6 +29,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 )
@@ -87,7 +89,7 @@ func stateForJSONOutput(s *resource.State, opts Options) *resource.State {
 	return resource.NewState(s.Type, s.URN, s.Custom, s.Delete, s.ID, inputs,
 		outputs, s.Parent, s.Protect, s.External, s.Dependencies, s.InitErrors, s.Provider,
 		s.PropertyDependencies, s.PendingReplacement, s.AdditionalSecretOutputs, s.Aliases, &s.CustomTimeouts,
-		s.ImportID)
+		s.ImportID, s.RetainOnDelete, s.DeletedWith)
 }
 
 // ShowJSONEvents renders incremental engine events to stdout.
@@ -156,24 +158,13 @@ func ShowPreviewDigest(events <-chan engine.Event, done chan<- bool, opts Option
 			// Create the detailed metadata for this step and the initial state of its resource. Later,
 			// if new outputs arrive, we'll search for and swap in those new values.
 			if m := e.Payload().(engine.ResourcePreEventPayload).Metadata; shouldShow(m, opts) || isRootStack(m) {
-				var detailedDiff map[string]propertyDiff
-				if m.DetailedDiff != nil {
-					detailedDiff = make(map[string]propertyDiff)
-					for k, v := range m.DetailedDiff {
-						detailedDiff[k] = propertyDiff{
-							Kind:      v.Kind.String(),
-							InputDiff: v.InputDiff,
-						}
-					}
-				}
-
 				step := &previewStep{
 					Op:             m.Op,
 					URN:            m.URN,
 					Provider:       m.Provider,
 					DiffReasons:    m.Diffs,
 					ReplaceReasons: m.Keys,
-					DetailedDiff:   detailedDiff,
+					DetailedDiff:   previewDetailedDiff(m),
 				}
 
 				if m.Old != nil {
@@ -202,9 +193,12 @@ func ShowPreviewDigest(events <-chan engine.Event, done chan<- bool, opts Option
 		// resolving or operations failing.
 
 		// Events occurring late:
-		case engine.PolicyViolationEvent:
+		case engine.PolicyViolationEvent, engine.PolicyRemediationEvent:
 			// At this point in time, we don't handle policy events in JSON serialization
 			continue
+		case engine.ProviderCallEvent:
+			// Provider call timings are only used for profiling.
+			continue
 		case engine.SummaryEvent:
 			// At the end of the preview, a summary event indicates the final conclusions.
 			p := e.Payload().(engine.SummaryEventPayload)
@@ -246,6 +240,44 @@ type propertyDiff struct {
 	Kind string `json:"kind"`
 	// InputDiff is true if this is a difference between old and new inputs instead of old state and new inputs.
 	InputDiff bool `json:"inputDiff"`
+	// EncodedDiff lists the differences between the old and new values of a string property that holds a JSON or YAML
+	// document, if any. It is empty if the documents only differ in formatting.
+	EncodedDiff *[]encodedValueChange `json:"encodedDiff,omitempty"`
+}
+
+// previewDetailedDiff returns the JSON form of the given step's detailed diff, if any.
+func previewDetailedDiff(m engine.StepEventMetadata) map[string]propertyDiff {
+	if m.DetailedDiff == nil {
+		return nil
+	}
+
+	detailedDiff := make(map[string]propertyDiff)
+	for k, v := range m.DetailedDiff {
+		pdiff := propertyDiff{
+			Kind:      v.Kind.String(),
+			InputDiff: v.InputDiff,
+		}
+		if changes, ok := encodedValueChanges(m, k, v.InputDiff); ok {
+			if changes == nil {
+				changes = []encodedValueChange{}
+			}
+			pdiff.EncodedDiff = &changes
+		}
+		detailedDiff[k] = pdiff
+	}
+	return detailedDiff
+}
+
+// encodedValueChange is a single difference between two JSON or YAML documents.
+type encodedValueChange struct {
+	// Kind is the kind of difference.
+	Kind string `json:"kind"`
+	// Path is the path to the value that differs within the document.
+	Path string `json:"path"`
+	// Old is the old value, if any.
+	Old interface{} `json:"old,omitempty"`
+	// New is the new value, if any.
+	New interface{} `json:"new,omitempty"`
 }
 
 // previewStep is a detailed overview of a step the engine intends to take.
@@ -275,3 +307,101 @@ type previewDiagnostic struct {
 	Message  string        `json:"message,omitempty"`
 	Severity diag.Severity `json:"severity,omitempty"`
 }
+
+// encodedValueChanges returns the differences between the old and new values at the given path of a step's
+// properties if both values are strings that hold JSON or YAML documents. If either value does not hold such a
+// document, it returns false.
+func encodedValueChanges(m engine.StepEventMetadata, path string, inputDiff bool) ([]encodedValueChange, bool) {
+	if m.Old == nil || m.New == nil {
+		return nil, false
+	}
+
+	elements, err := resource.ParsePropertyPath(path)
+	if err != nil {
+		elements = resource.PropertyPath{path}
+	}
+	olds := m.Old.Outputs
+	if inputDiff {
+		olds = m.Old.Inputs
+	}
+	old, _ := elements.Get(resource.NewObjectProperty(olds))
+	new, _ := elements.Get(resource.NewObjectProperty(m.New.Inputs))
+	if !old.IsString() || !new.IsString() {
+		return nil, false
+	}
+
+	oldValue, _, ok := engine.DecodeStructuredString(old.StringValue())
+	if !ok {
+		return nil, false
+	}
+	newValue, _, ok := engine.DecodeStructuredString(new.StringValue())
+	if !ok {
+		return nil, false
+	}
+
+	changes := []encodedValueChange{}
+	if diff := oldValue.Diff(newValue); diff != nil {
+		changes = appendValueChanges(changes, "", *diff)
+	}
+	return changes, true
+}
+
+// appendValueChanges flattens the given diff of the value at the given path into a list of changes.
+func appendValueChanges(changes []encodedValueChange, path string, diff resource.ValueDiff) []encodedValueChange {
+	switch {
+	case diff.Object != nil:
+		for _, k := range diff.Object.Keys() {
+			keyPath := appendObjectPath(path, string(k))
+			if v, ok := diff.Object.Adds[k]; ok {
+				changes = append(changes,
+					encodedValueChange{Kind: plugin.DiffAdd.String(), Path: keyPath, New: v.Mappable()})
+			} else if v, ok := diff.Object.Deletes[k]; ok {
+				changes = append(changes,
+					encodedValueChange{Kind: plugin.DiffDelete.String(), Path: keyPath, Old: v.Mappable()})
+			} else if d, ok := diff.Object.Updates[k]; ok {
+				changes = appendValueChanges(changes, keyPath, d)
+			}
+		}
+	case diff.Array != nil:
+		for i := 0; i < diff.Array.Len(); i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			if v, ok := diff.Array.Adds[i]; ok {
+				changes = append(changes,
+					encodedValueChange{Kind: plugin.DiffAdd.String(), Path: elemPath, New: v.Mappable()})
+			} else if v, ok := diff.Array.Deletes[i]; ok {
+				changes = append(changes,
+					encodedValueChange{Kind: plugin.DiffDelete.String(), Path: elemPath, Old: v.Mappable()})
+			} else if d, ok := diff.Array.Updates[i]; ok {
+				changes = appendValueChanges(changes, elemPath, d)
+			}
+		}
+	default:
+		changes = append(changes, encodedValueChange{
+			Kind: plugin.DiffUpdate.String(),
+			Path: path,
+			Old:  diff.Old.Mappable(),
+			New:  diff.New.Mappable(),
+		})
+	}
+	return changes
+}
+
+// appendObjectPath appends the given object key to a path within a document, quoting the key if it is not a simple
+// identifier.
+func appendObjectPath(path, key string) string {
+	simple := key != ""
+	for _, c := range key {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '-' {
+			simple = false
+			break
+		}
+	}
+	switch {
+	case !simple:
+		return fmt.Sprintf("%s[%q]", path, key)
+	case path == "":
+		return key
+	default:
+		return path + "." + key
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"io"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 )
 
 // Type of output to display.
@@ -36,19 +37,29 @@ const (
 
 // Options controls how the output of events are rendered
 type Options struct {
-	Color                colors.Colorization // colorization to apply to events.
-	ShowConfig           bool                // true if we should show configuration information.
-	ShowReplacementSteps bool                // true to show the replacement steps in the plan.
-	ShowSameResources    bool                // true to show the resources that aren't updated in addition to updates.
-	ShowReads            bool                // true to show resources that are being read in
-	SuppressOutputs      bool                // true to suppress output summarization, e.g. if contains sensitive info.
-	SuppressPermalink    bool                // true to suppress state permalink
-	SummaryDiff          bool                // true if diff display should be summarized.
-	IsInteractive        bool                // true if we should display things interactively.
-	Type                 Type                // type of display (rich diff, progress, or query).
-	JSONDisplay          bool                // true if we should emit the entire diff as JSON.
-	EventLogPath         string              // the path to the file to use for logging events, if any.
-	Debug                bool                // true to enable debug output.
-	Stdout               io.Writer           // the writer to use for stdout. Defaults to os.Stdout if unset.
-	Stderr               io.Writer           // the writer to use for stderr. Defaults to os.Stderr if unset.
+	Color                colors.Colorization          // colorization to apply to events.
+	ShowConfig           bool                         // true if we should show configuration information.
+	ShowReplacementSteps bool                         // true to show the replacement steps in the plan.
+	ShowSameResources    bool                         // true to show unchanged resources in addition to updates.
+	ShowReads            bool                         // true to show resources that are being read in
+	SuppressOutputs      bool                         // true to suppress output summarization, e.g. of sensitive info.
+	SuppressPermalink    bool                         // true to suppress state permalink
+	SummaryDiff          bool                         // true if diff display should be summarized.
+	IsInteractive        bool                         // true if we should display things interactively.
+	Type                 Type                         // type of display (rich diff, progress, or query).
+	JSONDisplay          bool                         // true if we should emit the entire diff as JSON.
+	EventLogPath         string                       // the path to the file to use for logging events, if any.
+	EventSink            string                       // the unix:// or http(s):// URL to stream events to, if any.
+	ProfilePath          string                       // the path to which to write a profile of the update, if any.
+	PlannedSteps         map[resource.URN]PlannedStep // the steps planned by a preceding preview, if any.
+	ExplainURN           resource.URN                 // the resource whose replacement to explain after a preview, if any.
+	Browse               bool                         // true to browse the results of a preview in a full-screen view.
+	ReportPath           string                       // the path to which to write a report of a preview, if any.
+	ReportFormat         ReportFormat                 // the format of the preview report, if any.
+	PolicyReportPath     string                       // the path to which to write a report of policy violations, if any.
+	PolicyReportFormat   PolicyReportFormat           // the format of the policy violation report, if any.
+	ReportErrors         *ReportErrors                // collects errors writing reports after the events are displayed.
+	Debug                bool                         // true to enable debug output.
+	Stdout               io.Writer                    // the writer to use for stdout. Defaults to os.Stdout if unset.
+	Stderr               io.Writer                    // the writer to use for stderr. Defaults to os.Stderr if unset.
 }
@@ -0,0 +1,299 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// PolicyReportFormat is the format of a policy violation report.
+type PolicyReportFormat string
+
+const (
+	// PolicyReportSARIF writes policy violations as a SARIF 2.1.0 log, as consumed by code scanning tools.
+	PolicyReportSARIF PolicyReportFormat = "sarif"
+	// PolicyReportJSON writes policy violations as a JSON object.
+	PolicyReportJSON PolicyReportFormat = "json"
+)
+
+// policyReportViolation is a policy violation in a JSON policy report.
+type policyReportViolation struct {
+	PolicyPackName    string                     `json:"policyPackName"`
+	PolicyPackVersion string                     `json:"policyPackVersion"`
+	PolicyName        string                     `json:"policyName"`
+	EnforcementLevel  apitype.EnforcementLevel   `json:"enforcementLevel"`
+	URN               string                     `json:"urn,omitempty"`
+	Message           string                     `json:"message"`
+	Waiver            *apitype.PolicyWaiverEvent `json:"waiver,omitempty"`
+}
+
+// The following types are the subset of the SARIF 2.1.0 object model that is used by policy reports. See
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html for the full specification.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID       string                 `json:"ruleId"`
+	RuleIndex    int                    `json:"ruleIndex"`
+	Level        string                 `json:"level"`
+	Message      sarifMessage           `json:"message"`
+	Locations    []sarifLocation        `json:"locations,omitempty"`
+	Suppressions []sarifSuppression     `json:"suppressions,omitempty"`
+	Properties   map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Justification string `json:"justification"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// policyReport accumulates the policy violations of an update from its engine events.
+type policyReport struct {
+	violations []engine.PolicyViolationEventPayload
+}
+
+func (r *policyReport) handleEvent(e engine.Event) {
+	if e.Type == engine.PolicyViolationEvent {
+		r.violations = append(r.violations, e.Payload().(engine.PolicyViolationEventPayload))
+	}
+}
+
+// policyViolationMessage returns the plain text message of a policy violation.
+func policyViolationMessage(v engine.PolicyViolationEventPayload) string {
+	return strings.TrimSpace(colors.Never.Colorize(v.Message))
+}
+
+// policyViolationWaiver returns the API representation of the waiver that matched a policy violation, if any.
+func policyViolationWaiver(v engine.PolicyViolationEventPayload) *apitype.PolicyWaiverEvent {
+	if v.Waiver == nil {
+		return nil
+	}
+	return &apitype.PolicyWaiverEvent{
+		Reason:  v.Waiver.Reason,
+		Expires: v.Waiver.Expires.Format(time.RFC3339),
+		Expired: v.Waiver.Expired,
+	}
+}
+
+func (r *policyReport) writeJSON(w io.Writer) error {
+	violations := make([]policyReportViolation, len(r.violations))
+	for i, v := range r.violations {
+		violations[i] = policyReportViolation{
+			PolicyPackName:    v.PolicyPackName,
+			PolicyPackVersion: v.PolicyPackVersion,
+			PolicyName:        v.PolicyName,
+			EnforcementLevel:  v.EnforcementLevel,
+			URN:               string(v.ResourceURN),
+			Message:           policyViolationMessage(v),
+			Waiver:            policyViolationWaiver(v),
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Violations []policyReportViolation `json:"violations"`
+	}{violations})
+}
+
+// sarifLevel returns the SARIF level of a violation with the given enforcement level.
+func sarifLevel(level apitype.EnforcementLevel) string {
+	switch level {
+	case apitype.Mandatory:
+		return "error"
+	case apitype.Advisory:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarif returns the policy report as a SARIF log. Each policy pack is a run, whose tool is the pack and whose rules
+// are the pack's violated policies. Resources are identified by logical locations, whose fully qualified names are
+// their URNs, as the engine does not record where in a program a resource is registered. Violations that were waived
+// by the stack's policy waivers are suppressed.
+func (r *policyReport) sarif() sarifLog {
+	type packKey struct{ name, version string }
+
+	var runs []sarifRun
+	runIndex := make(map[packKey]int)
+	ruleIndex := make(map[packKey]map[string]int)
+	for _, v := range r.violations {
+		key := packKey{v.PolicyPackName, v.PolicyPackVersion}
+		i, ok := runIndex[key]
+		if !ok {
+			i = len(runs)
+			runIndex[key], ruleIndex[key] = i, make(map[string]int)
+			runs = append(runs, sarifRun{
+				Tool: sarifTool{Driver: sarifDriver{
+					Name:    v.PolicyPackName,
+					Version: v.PolicyPackVersion,
+					Rules:   []sarifRule{},
+				}},
+				Results: []sarifResult{},
+			})
+		}
+		run := &runs[i]
+
+		ri, ok := ruleIndex[key][v.PolicyName]
+		if !ok {
+			ri = len(run.Tool.Driver.Rules)
+			ruleIndex[key][v.PolicyName] = ri
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: v.PolicyName, Name: v.PolicyName})
+		}
+
+		result := sarifResult{
+			RuleID:    v.PolicyName,
+			RuleIndex: ri,
+			Level:     sarifLevel(v.EnforcementLevel),
+			Message:   sarifMessage{Text: policyViolationMessage(v)},
+			Properties: map[string]interface{}{
+				"policyPackName":    v.PolicyPackName,
+				"policyPackVersion": v.PolicyPackVersion,
+				"enforcementLevel":  string(v.EnforcementLevel),
+			},
+		}
+		if v.ResourceURN != "" {
+			result.Locations = []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{
+				Name:               string(v.ResourceURN.Name()),
+				FullyQualifiedName: string(v.ResourceURN),
+				Kind:               "resource",
+			}}}}
+		}
+		if w := v.Waiver; w != nil {
+			result.Properties["waiverReason"] = w.Reason
+			result.Properties["waiverExpires"] = w.Expires.Format(time.RFC3339)
+			result.Properties["waiverExpired"] = w.Expired
+			if !w.Expired {
+				result.Suppressions = []sarifSuppression{{
+					Kind:          "external",
+					Status:        "accepted",
+					Justification: w.Reason,
+				}}
+			}
+		}
+		run.Results = append(run.Results, result)
+	}
+	if runs == nil {
+		// A log with no runs would not say which tool produced it, so report an empty run for the engine itself.
+		runs = []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "pulumi", Rules: []sarifRule{}}},
+			Results: []sarifResult{},
+		}}
+	}
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    runs,
+	}
+}
+
+func (r *policyReport) writeSARIF(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.sarif())
+}
+
+func (r *policyReport) write(w io.Writer, format PolicyReportFormat) error {
+	switch format {
+	case PolicyReportSARIF:
+		return r.writeSARIF(w)
+	case PolicyReportJSON:
+		return r.writeJSON(w)
+	default:
+		contract.Failf("unknown policy report format %q", format)
+		return nil
+	}
+}
+
+// startPolicyReporter accumulates the policy violations of an update from its events, and writes them to the policy
+// report path once all events have been displayed.
+func startPolicyReporter(events <-chan engine.Event, done chan<- bool,
+	opts Options) (<-chan engine.Event, chan<- bool) {
+
+	report := &policyReport{}
+	return passThroughEvents(events, done, report.handleEvent, func() {
+		err := func() error {
+			f, err := os.Create(opts.PolicyReportPath)
+			if err != nil {
+				return err
+			}
+			defer contract.IgnoreClose(f)
+			return report.write(f, opts.PolicyReportFormat)
+		}()
+		if err != nil {
+			reportWriteError(opts, fmt.Errorf("could not write policy report: %w", err))
+		}
+	})
+}
+
+// ValidatePolicyReportPath returns an error if a policy report cannot be written to the file at the given path.
+func ValidatePolicyReportPath(path string) error {
+	reportFile, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create policy report: %w", err)
+	}
+	return reportFile.Close()
+}
@@ -0,0 +1,179 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func newTestPolicyReport() *policyReport {
+	var report policyReport
+	var waiver *engine.PolicyViolationWaiver
+	add := func(pack, version, policy string, level apitype.EnforcementLevel, urn resource.URN, msg string) {
+		report.handleEvent(engine.NewEvent(engine.PolicyViolationEvent, engine.PolicyViolationEventPayload{
+			ResourceURN:       urn,
+			Message:           colors.SpecNote + msg + colors.Reset + "\n",
+			Color:             colors.Raw,
+			PolicyName:        policy,
+			PolicyPackName:    pack,
+			PolicyPackVersion: version,
+			EnforcementLevel:  level,
+			Waiver:            waiver,
+		}))
+	}
+	bucket := resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs")
+	add("security", "1.0.0", "no-public-buckets", apitype.Mandatory, bucket, "Buckets must not be public.")
+	add("security", "1.0.0", "encrypted", apitype.Advisory, bucket, "Buckets should be encrypted.")
+	add("security", "1.0.0", "no-public-buckets", apitype.Mandatory,
+		"urn:pulumi:dev::proj::aws:s3/bucket:Bucket::site", "Buckets must not be public.")
+	add("cost", "0.2.0", "max-instances", apitype.Advisory, "", "Too many instances.")
+	waiver = &engine.PolicyViolationWaiver{
+		Reason:  "Public site.",
+		Expires: time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC),
+	}
+	add("security", "1.0.0", "no-public-buckets", apitype.Advisory,
+		"urn:pulumi:dev::proj::aws:s3/bucket:Bucket::www", "Buckets must not be public.")
+
+	// Other events are ignored.
+	report.handleEvent(engine.NewEvent(engine.StdoutColorEvent, engine.StdoutEventPayload{Message: "hello"}))
+	return &report
+}
+
+func TestPolicyReportSARIF(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, newTestPolicyReport().write(&buf, PolicyReportSARIF))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 2)
+
+	// Each policy pack is a run, with a rule for each of its violated policies.
+	security := log.Runs[0]
+	assert.Equal(t, "security", security.Tool.Driver.Name)
+	assert.Equal(t, "1.0.0", security.Tool.Driver.Version)
+	assert.Equal(t, []sarifRule{
+		{ID: "no-public-buckets", Name: "no-public-buckets"},
+		{ID: "encrypted", Name: "encrypted"},
+	}, security.Tool.Driver.Rules)
+	require.Len(t, security.Results, 4)
+
+	first := security.Results[0]
+	assert.Equal(t, "no-public-buckets", first.RuleID)
+	assert.Equal(t, 0, first.RuleIndex)
+	assert.Equal(t, "error", first.Level)
+	assert.Equal(t, "Buckets must not be public.", first.Message.Text)
+	assert.Equal(t, "mandatory", first.Properties["enforcementLevel"])
+	assert.Equal(t, []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{
+		Name:               "logs",
+		FullyQualifiedName: "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs",
+		Kind:               "resource",
+	}}}}, first.Locations)
+
+	assert.Equal(t, 1, security.Results[1].RuleIndex)
+	assert.Equal(t, "warning", security.Results[1].Level)
+	assert.Equal(t, 0, security.Results[2].RuleIndex)
+	assert.Empty(t, first.Suppressions)
+
+	// Waived violations are suppressed.
+	waived := security.Results[3]
+	assert.Equal(t, "warning", waived.Level)
+	assert.Equal(t, []sarifSuppression{{Kind: "external", Status: "accepted", Justification: "Public site."}},
+		waived.Suppressions)
+	assert.Equal(t, "2022-12-31T00:00:00Z", waived.Properties["waiverExpires"])
+
+	// Violations that do not pertain to a resource have no location.
+	cost := log.Runs[1]
+	assert.Equal(t, "cost", cost.Tool.Driver.Name)
+	require.Len(t, cost.Results, 1)
+	assert.Empty(t, cost.Results[0].Locations)
+
+	// A report with no violations is still a valid log.
+	buf.Reset()
+	require.NoError(t, (&policyReport{}).write(&buf, PolicyReportSARIF))
+	log = sarifLog{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Len(t, log.Runs, 1)
+	assert.Equal(t, "pulumi", log.Runs[0].Tool.Driver.Name)
+	assert.Contains(t, buf.String(), `"results": []`)
+}
+
+func TestPolicyReportJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, newTestPolicyReport().write(&buf, PolicyReportJSON))
+
+	var report struct {
+		Violations []policyReportViolation `json:"violations"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.Len(t, report.Violations, 5)
+	assert.Equal(t, policyReportViolation{
+		PolicyPackName:    "security",
+		PolicyPackVersion: "1.0.0",
+		PolicyName:        "no-public-buckets",
+		EnforcementLevel:  apitype.Mandatory,
+		URN:               "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs",
+		Message:           "Buckets must not be public.",
+	}, report.Violations[0])
+	assert.Equal(t, "", report.Violations[3].URN)
+	assert.Equal(t, &apitype.PolicyWaiverEvent{Reason: "Public site.", Expires: "2022-12-31T00:00:00Z"},
+		report.Violations[4].Waiver)
+}
+
+func TestPolicyReportWriteError(t *testing.T) {
+	t.Parallel()
+
+	// The report's directory is missing, so the report cannot be written once the events have been displayed.
+	opts := Options{
+		PolicyReportPath:   filepath.Join(t.TempDir(), "missing", "report.json"),
+		PolicyReportFormat: PolicyReportJSON,
+		ReportErrors:       &ReportErrors{},
+	}
+	assert.Error(t, ValidatePolicyReportPath(opts.PolicyReportPath))
+
+	events, done := make(chan engine.Event), make(chan bool)
+	outEvents, outDone := startPolicyReporter(events, done, opts)
+	go func() {
+		for e := range outEvents {
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+		close(outDone)
+	}()
+	events <- engine.NewEvent(engine.CancelEvent, nil)
+	<-done
+
+	err := opts.ReportErrors.Err()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not write policy report")
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// The process IDs used to group the tracks of a deployment profile.
+const (
+	profileStepsPid         = 1
+	profileCriticalPathPid  = 2
+	profileProviderCallsPid = 3
+)
+
+// traceEvent is a single event in the Chrome trace event format. Timestamps and durations are in microseconds.
+type traceEvent struct {
+	Name string                 `json:"name"`
+	Cat  string                 `json:"cat,omitempty"`
+	Ph   string                 `json:"ph"`
+	Ts   int64                  `json:"ts"`
+	Dur  int64                  `json:"dur,omitempty"`
+	Pid  int                    `json:"pid"`
+	Tid  int                    `json:"tid"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// traceFile is the top-level object of a Chrome trace event file.
+type traceFile struct {
+	TraceEvents     []traceEvent `json:"traceEvents"`
+	DisplayTimeUnit string       `json:"displayTimeUnit"`
+}
+
+// profileSpan is a timed operation within a deployment: either a step or a provider call.
+type profileSpan struct {
+	name     string
+	category string
+	urn      resource.URN
+	op       deploy.StepOp
+	deps     []resource.URN
+	start    time.Time
+	end      time.Time
+	failed   bool
+	critical bool
+}
+
+type profileStepKey struct {
+	urn   resource.URN
+	start time.Time
+}
+
+// deploymentProfile accumulates the timing information carried by engine events.
+type deploymentProfile struct {
+	steps   map[profileStepKey]*profileSpan
+	ordered []*profileSpan
+	calls   []*profileSpan
+}
+
+func newDeploymentProfile() *deploymentProfile {
+	return &deploymentProfile{steps: make(map[profileStepKey]*profileSpan)}
+}
+
+// handleEvent records the timing information carried by the given event, if any.
+func (p *deploymentProfile) handleEvent(e engine.Event) {
+	switch e.Type {
+	case engine.ResourcePreEvent:
+		payload := e.Payload().(engine.ResourcePreEventPayload)
+		p.step(payload.Metadata, payload.StartTime)
+	case engine.ResourceOutputsEvent:
+		payload := e.Payload().(engine.ResourceOutputsEventPayload)
+		span := p.step(payload.Metadata, payload.StartTime)
+		if payload.EndTime.After(span.end) {
+			span.end = payload.EndTime
+		}
+	case engine.ResourceOperationFailed:
+		payload := e.Payload().(engine.ResourceOperationFailedPayload)
+		span := p.step(payload.Metadata, payload.StartTime)
+		span.end, span.failed = payload.EndTime, true
+	case engine.ProviderCallEvent:
+		payload := e.Payload().(engine.ProviderCallEventPayload)
+		name := payload.Method
+		switch {
+		case payload.URN != "":
+			name += " " + string(payload.URN.Name())
+		case payload.Token != "":
+			name += " " + string(payload.Token)
+		}
+		p.calls = append(p.calls, &profileSpan{
+			name:     name,
+			category: string(payload.Provider),
+			urn:      payload.URN,
+			start:    payload.StartTime,
+			end:      payload.EndTime,
+			failed:   payload.Failed,
+		})
+	}
+}
+
+// step returns the span for the step described by the given metadata, creating it if necessary.
+func (p *deploymentProfile) step(md engine.StepEventMetadata, start time.Time) *profileSpan {
+	key := profileStepKey{urn: md.URN, start: start}
+	if span, ok := p.steps[key]; ok {
+		return span
+	}
+
+	span := &profileSpan{
+		name:     fmt.Sprintf("%s %s", md.Op, md.URN.Name()),
+		category: string(md.Type),
+		urn:      md.URN,
+		op:       md.Op,
+		start:    start,
+		end:      start,
+	}
+	if md.Res != nil && md.Res.State != nil {
+		state := md.Res.State
+		span.deps = append(span.deps, state.Dependencies...)
+		if state.Parent != "" {
+			span.deps = append(span.deps, state.Parent)
+		}
+		if ref, err := providers.ParseReference(state.Provider); err == nil {
+			span.deps = append(span.deps, ref.URN())
+		}
+	}
+
+	p.steps[key] = span
+	p.ordered = append(p.ordered, span)
+	return span
+}
+
+// markCriticalPath marks the chain of steps that determined the end time of the deployment. Starting from the step
+// that ended last, it repeatedly moves to the predecessor that finished last before the current step started. The
+// predecessors of a step are the resources it depends on, or, for deletions, the resources that depend on it.
+func (p *deploymentProfile) markCriticalPath() []*profileSpan {
+	byURN := make(map[resource.URN][]*profileSpan)
+	dependents := make(map[resource.URN][]resource.URN)
+	var last *profileSpan
+	for _, span := range p.ordered {
+		byURN[span.urn] = append(byURN[span.urn], span)
+		for _, dep := range span.deps {
+			dependents[dep] = append(dependents[dep], span.urn)
+		}
+		if last == nil || span.end.After(last.end) {
+			last = span
+		}
+	}
+
+	var path []*profileSpan
+	for span := last; span != nil; {
+		span.critical = true
+		path = append(path, span)
+
+		preds := span.deps
+		if span.op == deploy.OpDelete || span.op == deploy.OpDeleteReplaced {
+			preds = dependents[span.urn]
+		}
+
+		var next *profileSpan
+		for _, urn := range preds {
+			for _, candidate := range byURN[urn] {
+				if candidate.critical || candidate.end.After(span.start) {
+					continue
+				}
+				if next == nil || candidate.end.After(next.end) {
+					next = candidate
+				}
+			}
+		}
+		span = next
+	}
+
+	// Return the path in execution order.
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// assignLanes assigns each span to the lowest-numbered lane that is free when the span starts, so that spans in
+// the same lane never overlap.
+func assignLanes(spans []*profileSpan) []int {
+	order := make([]int, len(spans))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return spans[order[i]].start.Before(spans[order[j]].start) })
+
+	lanes := make([]int, len(spans))
+	var laneEnds []time.Time
+	for _, i := range order {
+		span, lane := spans[i], -1
+		for l, end := range laneEnds {
+			if !end.After(span.start) {
+				lane = l
+				break
+			}
+		}
+		if lane == -1 {
+			lane = len(laneEnds)
+			laneEnds = append(laneEnds, time.Time{})
+		}
+		laneEnds[lane] = span.end
+		lanes[i] = lane
+	}
+	return lanes
+}
+
+// trace renders the profile as a Chrome trace event file. Steps and provider calls are shown as complete events on
+// separate tracks, the critical path is repeated on its own track, and the number of steps that were executing at
+// any point in time is shown as a counter.
+func (p *deploymentProfile) trace() traceFile {
+	var origin time.Time
+	for _, spans := range [][]*profileSpan{p.ordered, p.calls} {
+		for _, span := range spans {
+			if origin.IsZero() || span.start.Before(origin) {
+				origin = span.start
+			}
+		}
+	}
+	micros := func(t time.Time) int64 { return t.Sub(origin).Microseconds() }
+
+	events := []traceEvent{
+		processName(profileStepsPid, "Steps"),
+		processName(profileCriticalPathPid, "Critical path"),
+		processName(profileProviderCallsPid, "Provider calls"),
+	}
+	complete := func(span *profileSpan, pid, tid int) traceEvent {
+		args := map[string]interface{}{}
+		if span.urn != "" {
+			args["urn"] = string(span.urn)
+		}
+		if span.failed {
+			args["failed"] = true
+		}
+		if span.critical {
+			args["critical"] = true
+		}
+		return traceEvent{
+			Name: span.name,
+			Cat:  span.category,
+			Ph:   "X",
+			Ts:   micros(span.start),
+			Dur:  span.end.Sub(span.start).Microseconds(),
+			Pid:  pid,
+			Tid:  tid,
+			Args: args,
+		}
+	}
+
+	for _, span := range p.markCriticalPath() {
+		events = append(events, complete(span, profileCriticalPathPid, 0))
+	}
+	for i, lane := range assignLanes(p.ordered) {
+		events = append(events, complete(p.ordered[i], profileStepsPid, lane))
+	}
+	for i, lane := range assignLanes(p.calls) {
+		events = append(events, complete(p.calls[i], profileProviderCallsPid, lane))
+	}
+
+	// Record the level of parallelism each time a step starts or ends.
+	type change struct {
+		at    time.Time
+		delta int
+	}
+	var changes []change
+	for _, span := range p.ordered {
+		changes = append(changes, change{span.start, 1}, change{span.end, -1})
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].at.Equal(changes[j].at) {
+			return changes[i].delta < changes[j].delta
+		}
+		return changes[i].at.Before(changes[j].at)
+	})
+	running := 0
+	for _, c := range changes {
+		running += c.delta
+		events = append(events, traceEvent{
+			Name: "Parallelism",
+			Ph:   "C",
+			Ts:   micros(c.at),
+			Pid:  profileStepsPid,
+			Args: map[string]interface{}{"steps": running},
+		})
+	}
+
+	return traceFile{TraceEvents: events, DisplayTimeUnit: "ms"}
+}
+
+func processName(pid int, name string) traceEvent {
+	return traceEvent{
+		Name: "process_name",
+		Ph:   "M",
+		Pid:  pid,
+		Args: map[string]interface{}{"name": name},
+	}
+}
+
+// ValidateProfilePath returns an error if a profile cannot be written to the file at the given path.
+func ValidateProfilePath(path string) error {
+	profileFile, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create profile: %w", err)
+	}
+	return profileFile.Close()
+}
+
+func startProfiler(events <-chan engine.Event, done chan<- bool, opts Options) (<-chan engine.Event, chan<- bool) {
+	// Before moving further, attempt to open the profile file.
+	profileFile, err := os.Create(opts.ProfilePath)
+	if err != nil {
+		logging.V(7).Infof("could not create profile: %v", err)
+		return events, done
+	}
+
+	profile := newDeploymentProfile()
+	return passThroughEvents(events, done, profile.handleEvent, func() {
+		defer contract.IgnoreClose(profileFile)
+
+		encoder := json.NewEncoder(profileFile)
+		if err := encoder.Encode(profile.trace()); err != nil {
+			logging.V(7).Infof("failed to write profile: %v", err)
+		}
+	})
+}
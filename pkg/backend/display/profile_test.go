@@ -0,0 +1,92 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+func TestDeploymentProfile(t *testing.T) {
+	origin := time.Now()
+	at := func(ms int) time.Time { return origin.Add(time.Duration(ms) * time.Millisecond) }
+	urn := func(name string) resource.URN {
+		return resource.NewURN("stack", "proj", "", "pkgA:m:typA", tokens.QName(name))
+	}
+
+	profile := newDeploymentProfile()
+	step := func(name string, start, end int, deps ...resource.URN) {
+		md := engine.StepEventMetadata{
+			Op:   deploy.OpCreate,
+			URN:  urn(name),
+			Type: "pkgA:m:typA",
+			Res:  &engine.StepEventStateMetadata{State: &resource.State{URN: urn(name), Dependencies: deps}},
+		}
+		profile.handleEvent(engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+			Metadata:  md,
+			StartTime: at(start),
+		}))
+		profile.handleEvent(engine.NewEvent(engine.ResourceOutputsEvent, engine.ResourceOutputsEventPayload{
+			Metadata:  md,
+			StartTime: at(start),
+			EndTime:   at(end),
+		}))
+	}
+
+	// a and c run in parallel; b waits for a. The deployment ends when b does, so a and b form the critical path.
+	step("a", 0, 100)
+	step("c", 10, 50)
+	step("b", 100, 300, urn("a"))
+	profile.handleEvent(engine.NewEvent(engine.ProviderCallEvent, engine.ProviderCallEventPayload{
+		Provider:  "pkgA",
+		Method:    "Create",
+		URN:       urn("b"),
+		StartTime: at(110),
+		EndTime:   at(290),
+	}))
+
+	trace := profile.trace()
+
+	var critical, steps, calls []string
+	maxParallelism := 0
+	for _, e := range trace.TraceEvents {
+		switch {
+		case e.Ph == "X" && e.Pid == profileCriticalPathPid:
+			critical = append(critical, e.Name)
+		case e.Ph == "X" && e.Pid == profileStepsPid:
+			steps = append(steps, e.Name)
+		case e.Ph == "X" && e.Pid == profileProviderCallsPid:
+			calls = append(calls, e.Name)
+			assert.Equal(t, int64(110000), e.Ts)
+			assert.Equal(t, int64(180000), e.Dur)
+		case e.Ph == "C":
+			if n := e.Args["steps"].(int); n > maxParallelism {
+				maxParallelism = n
+			}
+		}
+	}
+
+	assert.Equal(t, []string{"create a", "create b"}, critical)
+	assert.ElementsMatch(t, []string{"create a", "create b", "create c"}, steps)
+	assert.Equal(t, []string{"Create b"}, calls)
+	assert.Equal(t, 2, maxParallelism)
+}
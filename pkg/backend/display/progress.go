@@ -104,6 +104,11 @@ type ProgressDisplay struct {
 	// i.e. if we're previewing we say things like "Would update" instead of "Updating".
 	isPreview bool
 
+	// Estimates the time remaining in an update from the steps planned by its preview and the durations recorded by
+	// previous updates. This is nil if we're previewing, if the update was not previewed, or if there is no history
+	// to base an estimate on.
+	estimator *progressEstimator
+
 	// The urn of the stack.
 	stackUrn resource.URN
 
@@ -352,6 +357,14 @@ func ShowProgressEvents(op string, action apitype.UpdateKind, stack tokens.QName
 
 	ticker.Stop()
 
+	// Once a preview has finished, let the user browse its results if they asked to.
+	if opts.Browse && isPreview && display.isTerminal {
+		if err := display.browse(os.Stdin, stdout); err != nil {
+			_, err = fmt.Fprintf(stderr, "error: could not browse the preview: %v\n", err)
+			contract.IgnoreError(err)
+		}
+	}
+
 	// let our caller know we're done.
 	close(done)
 }
@@ -736,6 +749,7 @@ func (display *ProgressDisplay) processEndSteps() {
 	display.writeBlankLine()
 	wroteDiagnosticHeader := display.printDiagnostics()
 	wrotePolicyViolations := display.printPolicyViolations()
+	display.printPolicyRemediations()
 	display.printOutputs()
 	// If no policies violated, print policy packs applied.
 	if !wrotePolicyViolations {
@@ -885,6 +899,32 @@ func (display *ProgressDisplay) printPolicyViolations() bool {
 	return true
 }
 
+// printPolicyRemediations prints a new "Policy Remediations:" section with the changes that remediation policies made
+// to each resource's inputs. If no resources were remediated, prints nothing.
+func (display *ProgressDisplay) printPolicyRemediations() {
+	var remediations []engine.PolicyRemediationEventPayload
+	for _, row := range display.eventUrnToResourceRow {
+		remediations = append(remediations, row.PolicyRemediationPayloads()...)
+	}
+	if len(remediations) == 0 {
+		return
+	}
+	// Sort remediations by the URN of the resource, keeping the remediations of each resource in the order they were
+	// applied.
+	sort.SliceStable(remediations, func(i, j int) bool {
+		return remediations[i].ResourceURN < remediations[j].ResourceURN
+	})
+
+	display.writeSimpleMessage(display.opts.Color.Colorize(colors.SpecHeadline + "Policy Remediations:" + colors.Reset))
+	for _, remediation := range remediations {
+		msg := renderPolicyRemediation(remediation, 1, display.opts)
+		for _, line := range splitIntoDisplayableLines(msg) {
+			display.writeSimpleMessage(strings.TrimRightFunc(line, unicode.IsSpace))
+		}
+	}
+	display.writeBlankLine()
+}
+
 // printOutputs prints the Stack's outputs for the display in a new section, if appropriate.
 func (display *ProgressDisplay) printOutputs() {
 	// Printing the stack's outputs wasn't desired.
@@ -1019,6 +1059,9 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 		// Note: we should probably make sure we don't get any prelude events
 		// once we start hearing about actual resource events.
 		payload := event.Payload().(engine.PreludeEventPayload)
+		if !payload.IsPreview {
+			display.estimator = newProgressEstimator(payload.History, display.opts.PlannedSteps)
+		}
 		preludeEventString := renderPreludeEvent(payload, display.opts)
 		if display.isTerminal {
 			display.processNormalEvent(engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
@@ -1045,6 +1088,15 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	case engine.StdoutColorEvent:
 		display.handleSystemEvent(event.Payload().(engine.StdoutEventPayload))
 		return
+	case engine.ProviderCallEvent:
+		// Provider call timings are only used for profiling.
+		return
+	case engine.PolicyRemediationEvent:
+		// Record this policy remediation so we print it at the end. It does not change how the resource's row is
+		// displayed, as the resource's step shows the remediated inputs.
+		payload := event.Payload().(engine.PolicyRemediationEventPayload)
+		display.getRowForURN(payload.ResourceURN, nil).RecordPolicyRemediationEvent(event)
+		return
 	}
 
 	// At this point, all events should relate to resources.
@@ -1097,8 +1149,12 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	}
 
 	if event.Type == engine.ResourcePreEvent {
-		step := event.Payload().(engine.ResourcePreEventPayload).Metadata
+		payload := event.Payload().(engine.ResourcePreEventPayload)
+		step := payload.Metadata
 		row.SetStep(step)
+		if display.estimator != nil {
+			display.estimator.start(step.URN, payload.StartTime)
+		}
 	} else if event.Type == engine.ResourceOutputsEvent {
 		isRefresh := display.getStepOp(row.Step()) == deploy.OpRefresh
 		step := event.Payload().(engine.ResourceOutputsEventPayload).Metadata
@@ -1110,6 +1166,9 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 
 		row.SetStep(step)
 		row.AddOutputStep(step)
+		if display.estimator != nil {
+			display.estimator.finish(step.URN)
+		}
 
 		// If we're not in a terminal, we may not want to display this row again: if we're displaying a preview or if
 		// this step is a no-op for a custom resource, refreshing this row will simply duplicate its earlier output.
@@ -1120,6 +1179,9 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 		}
 	} else if event.Type == engine.ResourceOperationFailed {
 		row.SetFailed()
+		if display.estimator != nil {
+			display.estimator.finish(eventUrn)
+		}
 	} else if event.Type == engine.DiagEvent {
 		// also record this diagnostic so we print it at the end.
 		row.RecordDiagEvent(event)
@@ -1262,12 +1324,18 @@ func (display *ProgressDisplay) getStepDoneDescription(step engine.StepEventMeta
 			case deploy.OpUpdate:
 				return "updated"
 			case deploy.OpDelete:
+				if isRetained(step) {
+					return "retained"
+				}
 				return "deleted"
 			case deploy.OpReplace:
 				return "replaced"
 			case deploy.OpCreateReplacement:
 				return "created replacement"
 			case deploy.OpDeleteReplaced:
+				if isRetained(step) {
+					return "retained original"
+				}
 				return "deleted original"
 			case deploy.OpRead:
 				// nolint: goconst
@@ -1307,12 +1375,18 @@ func (display *ProgressDisplay) getPreviewText(step engine.StepEventMetadata) st
 	case deploy.OpUpdate:
 		return "update"
 	case deploy.OpDelete:
+		if isRetained(step) {
+			return "retain"
+		}
 		return "delete"
 	case deploy.OpReplace:
 		return "replace"
 	case deploy.OpCreateReplacement:
 		return "create replacement"
 	case deploy.OpDeleteReplaced:
+		if isRetained(step) {
+			return "retain original"
+		}
 		return "delete original"
 	case deploy.OpRead:
 		// nolint: goconst
@@ -1346,6 +1420,9 @@ func (display *ProgressDisplay) getPreviewDoneText(step engine.StepEventMetadata
 	case deploy.OpUpdate:
 		return "update"
 	case deploy.OpDelete:
+		if isRetained(step) {
+			return "retain"
+		}
 		return "delete"
 	case deploy.OpReplace, deploy.OpCreateReplacement, deploy.OpDeleteReplaced, deploy.OpReadReplacement,
 		deploy.OpDiscardReplaced:
@@ -1365,6 +1442,12 @@ func (display *ProgressDisplay) getPreviewDoneText(step engine.StepEventMetadata
 	return ""
 }
 
+// isRetained returns true if the step deletes a resource that is only removed from the snapshot rather than being
+// deleted by its provider.
+func isRetained(step engine.StepEventMetadata) bool {
+	return step.Old != nil && step.Old.RetainOnDelete
+}
+
 func (display *ProgressDisplay) getStepOp(step engine.StepEventMetadata) deploy.StepOp {
 	op := step.Op
 
@@ -1416,12 +1499,18 @@ func (display *ProgressDisplay) getStepInProgressDescription(step engine.StepEve
 		case deploy.OpUpdate:
 			return "updating"
 		case deploy.OpDelete:
+			if isRetained(step) {
+				return "retaining"
+			}
 			return "deleting"
 		case deploy.OpReplace:
 			return "replacing"
 		case deploy.OpCreateReplacement:
 			return "creating replacement"
 		case deploy.OpDeleteReplaced:
+			if isRetained(step) {
+				return "retaining original"
+			}
 			return "deleting original"
 		case deploy.OpRead:
 			return "reading"
@@ -0,0 +1,429 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// ReportFormat is the format of a preview report.
+type ReportFormat string
+
+const (
+	// ReportMarkdown renders a preview report as GitHub-flavored Markdown.
+	ReportMarkdown ReportFormat = "markdown"
+	// ReportHTML renders a preview report as a standalone HTML page.
+	ReportHTML ReportFormat = "html"
+)
+
+// reportResource is a resource shown in a preview report.
+type reportResource struct {
+	step        engine.StepEventMetadata
+	diff        string
+	violations  []engine.PolicyViolationEventPayload
+	diagnostics []engine.DiagEventPayload
+}
+
+// previewReport accumulates the results of a preview from its engine events so that they can be rendered as a report
+// suitable for pasting into a code review.
+type previewReport struct {
+	stack tokens.QName
+	opts  Options
+
+	resources []*reportResource
+	byURN     map[resource.URN]*reportResource
+
+	violations  []engine.PolicyViolationEventPayload // violations that do not pertain to a shown resource.
+	diagnostics []engine.DiagEventPayload            // diagnostics that do not pertain to a shown resource.
+
+	summary *engine.SummaryEventPayload
+}
+
+func newPreviewReport(stack tokens.QName, opts Options) *previewReport {
+	// Reports are plain text, and show the same steps as the rich diff display.
+	opts.Color = colors.Never
+	opts.Type = DisplayDiff
+	opts.JSONDisplay = false
+	opts.SummaryDiff = false
+
+	return &previewReport{
+		stack: stack,
+		opts:  opts,
+		byURN: make(map[resource.URN]*reportResource),
+	}
+}
+
+func (r *previewReport) handleEvent(e engine.Event) {
+	switch e.Type {
+	case engine.ResourcePreEvent:
+		m := e.Payload().(engine.ResourcePreEventPayload).Metadata
+		if !shouldShow(m, r.opts) || r.byURN[m.URN] != nil {
+			return
+		}
+
+		// Render each resource's diff without indentation, as resources are shown individually.
+		var diff bytes.Buffer
+		renderDiff(&diff, m, true /*planning*/, false /*debug*/, map[resource.URN]engine.StepEventMetadata{}, r.opts)
+
+		res := &reportResource{step: m, diff: strings.TrimRight(diff.String(), "\n")}
+		r.resources = append(r.resources, res)
+		r.byURN[m.URN] = res
+	case engine.DiagEvent:
+		p := e.Payload().(engine.DiagEventPayload)
+		if !p.Ephemeral && (p.Severity == diag.Warning || p.Severity == diag.Error) {
+			r.diagnostics = append(r.diagnostics, p)
+		}
+	case engine.PolicyViolationEvent:
+		r.violations = append(r.violations, e.Payload().(engine.PolicyViolationEventPayload))
+	case engine.SummaryEvent:
+		p := e.Payload().(engine.SummaryEventPayload)
+		r.summary = &p
+	}
+}
+
+// attachDiagnostics moves the policy violations and diagnostics that pertain to shown resources into their resources.
+func (r *previewReport) attachDiagnostics() {
+	var violations []engine.PolicyViolationEventPayload
+	for _, v := range r.violations {
+		if res, ok := r.byURN[v.ResourceURN]; ok {
+			res.violations = append(res.violations, v)
+		} else {
+			violations = append(violations, v)
+		}
+	}
+	r.violations = violations
+
+	var diagnostics []engine.DiagEventPayload
+	for _, d := range r.diagnostics {
+		if res, ok := r.byURN[d.URN]; ok && d.URN != "" {
+			res.diagnostics = append(res.diagnostics, d)
+		} else {
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	r.diagnostics = diagnostics
+}
+
+// reportCount is a row of a report's summary table.
+type reportCount struct {
+	label string
+	count int
+}
+
+// counts returns the number of resources affected by each kind of operation, in the order used by the summary of the
+// progress display.
+func (r *previewReport) counts() []reportCount {
+	if r.summary == nil {
+		return nil
+	}
+
+	var counts []reportCount
+	for _, op := range deploy.StepOps {
+		if op == deploy.OpSame || op == deploy.OpRead || op == deploy.OpReadDiscard || op == deploy.OpReadReplacement {
+			continue
+		}
+		if c := r.summary.ResourceChanges[op]; c > 0 {
+			counts = append(counts, reportCount{label: opLabel(op), count: c})
+		}
+	}
+	if c := r.summary.ResourceChanges[deploy.OpSame]; c > 0 {
+		counts = append(counts, reportCount{label: "unchanged", count: c})
+	}
+	return counts
+}
+
+// opLabel returns the uncolored prefix and name of an operation, e.g. "+ create".
+func opLabel(op deploy.StepOp) string {
+	return colors.Never.Colorize(op.Prefix(true /*done*/)) + string(op)
+}
+
+// formatViolation returns a single-line description of a policy violation.
+func formatViolation(v engine.PolicyViolationEventPayload) string {
+	return fmt.Sprintf("[%s] %s@v%s %s: %s", v.EnforcementLevel, v.PolicyPackName, v.PolicyPackVersion, v.PolicyName,
+		strings.TrimSpace(colors.Never.Colorize(v.Message)))
+}
+
+// formatDiagnostic returns a description of a diagnostic.
+func formatDiagnostic(d engine.DiagEventPayload) string {
+	return fmt.Sprintf("%s: %s", d.Severity, strings.TrimSpace(colors.Never.Colorize(d.Message)))
+}
+
+// notes summarizes the number of policy violations and diagnostics of a resource.
+func (res *reportResource) notes() string {
+	var notes []string
+	if n := len(res.violations); n > 0 {
+		notes = append(notes, fmt.Sprintf("%d policy %s", n, english.PluralWord(n, "violation", "")))
+	}
+	if n := len(res.diagnostics); n > 0 {
+		notes = append(notes, fmt.Sprintf("%d %s", n, english.PluralWord(n, "diagnostic", "")))
+	}
+	return strings.Join(notes, ", ")
+}
+
+// writeMarkdown renders the report as GitHub-flavored Markdown. Each resource is rendered as a collapsible section.
+func (r *previewReport) writeMarkdown(w io.Writer) {
+	fprintfIgnoreError(w, "# Preview of stack `%s`\n\n", r.stack)
+
+	if r.summary == nil {
+		fprintIgnoreError(w, "**The preview did not complete.** The results below are partial.\n\n")
+	} else if counts := r.counts(); len(counts) > 0 {
+		fprintIgnoreError(w, "| Operation | Count |\n| --- | ---: |\n")
+		for _, c := range counts {
+			fprintfIgnoreError(w, "| `%s` | %d |\n", c.label, c.count)
+		}
+		fprintIgnoreError(w, "\n")
+	}
+
+	if len(r.resources) == 0 {
+		fprintIgnoreError(w, "No resources will change.\n")
+	} else {
+		fprintIgnoreError(w, "## Resources\n")
+		for _, res := range r.resources {
+			summary := fmt.Sprintf("<code>%s</code> <code>%s</code> <b>%s</b>", html.EscapeString(opLabel(res.step.Op)),
+				html.EscapeString(string(res.step.URN.Type())), html.EscapeString(string(res.step.URN.Name())))
+			if notes := res.notes(); notes != "" {
+				summary += " (" + notes + ")"
+			}
+			fprintfIgnoreError(w, "\n<details>\n<summary>%s</summary>\n\n", summary)
+			fprintfIgnoreError(w, "```diff\n%s\n```\n", res.diff)
+			writeMarkdownList(w, "Policy violations", res.violations, nil)
+			writeMarkdownList(w, "Diagnostics", nil, res.diagnostics)
+			fprintIgnoreError(w, "\n</details>\n")
+		}
+	}
+
+	if len(r.violations) > 0 || len(r.diagnostics) > 0 {
+		fprintIgnoreError(w, "\n## Other results\n")
+		writeMarkdownList(w, "Policy violations", r.violations, nil)
+		writeMarkdownList(w, "Diagnostics", nil, r.diagnostics)
+	}
+}
+
+func writeMarkdownList(w io.Writer, title string, violations []engine.PolicyViolationEventPayload,
+	diagnostics []engine.DiagEventPayload) {
+
+	var items []string
+	for _, v := range violations {
+		items = append(items, formatViolation(v))
+	}
+	for _, d := range diagnostics {
+		items = append(items, formatDiagnostic(d))
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	fprintfIgnoreError(w, "\n**%s**\n\n", title)
+	for _, item := range items {
+		// Indent continuation lines so that multi-line messages remain part of their list item.
+		fprintfIgnoreError(w, "- %s\n", strings.ReplaceAll(item, "\n", "\n  "))
+	}
+}
+
+const reportHTMLStyle = `body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.25em 0.75em; text-align: left; }
+details { margin: 0.5em 0; }
+summary { cursor: pointer; }
+pre { background: #f6f8fa; padding: 0.75em; overflow-x: auto; }
+.create { color: #22863a; } .delete { color: #b31d28; } .update { color: #b08800; }`
+
+// writeHTML renders the report as a standalone HTML page. Each resource is rendered as a collapsible section.
+func (r *previewReport) writeHTML(w io.Writer) {
+	title := html.EscapeString(fmt.Sprintf("Preview of stack %s", r.stack))
+	fprintfIgnoreError(w, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", title)
+	fprintfIgnoreError(w, "<style>\n%s\n</style>\n</head>\n<body>\n<h1>%s</h1>\n", reportHTMLStyle, title)
+
+	if r.summary == nil {
+		fprintIgnoreError(w, "<p><b>The preview did not complete.</b> The results below are partial.</p>\n")
+	} else if counts := r.counts(); len(counts) > 0 {
+		fprintIgnoreError(w, "<table>\n<tr><th>Operation</th><th>Count</th></tr>\n")
+		for _, c := range counts {
+			fprintfIgnoreError(w, "<tr><td><code>%s</code></td><td>%d</td></tr>\n", html.EscapeString(c.label), c.count)
+		}
+		fprintIgnoreError(w, "</table>\n")
+	}
+
+	if len(r.resources) == 0 {
+		fprintIgnoreError(w, "<p>No resources will change.</p>\n")
+	} else {
+		fprintIgnoreError(w, "<h2>Resources</h2>\n")
+		for _, res := range r.resources {
+			fprintfIgnoreError(w, "<details>\n<summary><code>%s</code> <code>%s</code> <b>%s</b>",
+				html.EscapeString(opLabel(res.step.Op)), html.EscapeString(string(res.step.URN.Type())),
+				html.EscapeString(string(res.step.URN.Name())))
+			if notes := res.notes(); notes != "" {
+				fprintfIgnoreError(w, " (%s)", html.EscapeString(notes))
+			}
+			fprintIgnoreError(w, "</summary>\n<pre>")
+			writeHTMLDiff(w, res.diff)
+			fprintIgnoreError(w, "</pre>\n")
+			writeHTMLList(w, "Policy violations", res.violations, nil)
+			writeHTMLList(w, "Diagnostics", nil, res.diagnostics)
+			fprintIgnoreError(w, "</details>\n")
+		}
+	}
+
+	if len(r.violations) > 0 || len(r.diagnostics) > 0 {
+		fprintIgnoreError(w, "<h2>Other results</h2>\n")
+		writeHTMLList(w, "Policy violations", r.violations, nil)
+		writeHTMLList(w, "Diagnostics", nil, r.diagnostics)
+	}
+
+	fprintIgnoreError(w, "</body>\n</html>\n")
+}
+
+// writeHTMLDiff writes a rendered diff, highlighting each line according to its operation. The properties of created
+// and deleted resources are highlighted like the resource itself.
+func writeHTMLDiff(w io.Writer, diff string) {
+	var resourceClass string
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	for first := true; scanner.Scan(); first = false {
+		line := scanner.Text()
+		var class string
+		switch trimmed := strings.TrimLeft(line, " "); {
+		case strings.HasPrefix(trimmed, "+"):
+			class = "create"
+		case strings.HasPrefix(trimmed, "-"):
+			class = "delete"
+		case strings.HasPrefix(trimmed, "~"):
+			class = "update"
+		}
+		if first && class != "update" {
+			resourceClass = class
+		}
+		if class == "" {
+			class = resourceClass
+		}
+
+		if class == "" {
+			fprintfIgnoreError(w, "%s\n", html.EscapeString(line))
+		} else {
+			fprintfIgnoreError(w, "<span class=\"%s\">%s</span>\n", class, html.EscapeString(line))
+		}
+	}
+	contract.IgnoreError(scanner.Err())
+}
+
+func writeHTMLList(w io.Writer, title string, violations []engine.PolicyViolationEventPayload,
+	diagnostics []engine.DiagEventPayload) {
+
+	var items []string
+	for _, v := range violations {
+		items = append(items, formatViolation(v))
+	}
+	for _, d := range diagnostics {
+		items = append(items, formatDiagnostic(d))
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	fprintfIgnoreError(w, "<h4>%s</h4>\n<ul>\n", title)
+	for _, item := range items {
+		fprintfIgnoreError(w, "<li>%s</li>\n", strings.ReplaceAll(html.EscapeString(item), "\n", "<br>"))
+	}
+	fprintIgnoreError(w, "</ul>\n")
+}
+
+// write renders the report in the given format.
+func (r *previewReport) write(w io.Writer, format ReportFormat) {
+	r.attachDiagnostics()
+
+	switch format {
+	case ReportMarkdown:
+		r.writeMarkdown(w)
+	case ReportHTML:
+		r.writeHTML(w)
+	default:
+		contract.Failf("unknown report format %q", format)
+	}
+}
+
+// startReporter accumulates a report of a preview from its events, and writes it to the report path once all events
+// have been displayed.
+func startReporter(events <-chan engine.Event, done chan<- bool, stack tokens.QName,
+	opts Options) (<-chan engine.Event, chan<- bool) {
+
+	report := newPreviewReport(stack, opts)
+	return passThroughEvents(events, done, report.handleEvent, func() {
+		var buf bytes.Buffer
+		report.write(&buf, opts.ReportFormat)
+		if err := os.WriteFile(opts.ReportPath, buf.Bytes(), 0644); err != nil {
+			reportWriteError(opts, fmt.Errorf("could not write preview report: %w", err))
+		}
+	})
+}
+
+// ValidateReportPath returns an error if a report cannot be written to the file at the given path.
+func ValidateReportPath(path string) error {
+	reportFile, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create report: %w", err)
+	}
+	return reportFile.Close()
+}
+
+// ReportErrors collects the errors that occur when reports are written once all events have been displayed, so that
+// the command that displayed the events can fail.
+type ReportErrors struct {
+	m    sync.Mutex
+	errs *multierror.Error
+}
+
+// Err returns the errors collected so far, or nil if there are none.
+func (r *ReportErrors) Err() error {
+	if r == nil {
+		return nil
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.errs.ErrorOrNil()
+}
+
+// reportWriteError records an error that occurred while writing a report. If the errors are not being collected, it is
+// printed instead.
+func reportWriteError(opts Options, err error) {
+	if r := opts.ReportErrors; r != nil {
+		r.m.Lock()
+		defer r.m.Unlock()
+		r.errs = multierror.Append(r.errs, err)
+		return
+	}
+
+	stderr := opts.Stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+	fprintfIgnoreError(stderr, "error: %v\n", err)
+}
@@ -0,0 +1,156 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func TestPreviewReport(t *testing.T) {
+	t.Parallel()
+
+	newReport := func() *previewReport {
+		report := newPreviewReport("dev", Options{})
+
+		step := func(op deploy.StepOp, name string, olds, news resource.PropertyMap) {
+			urn := resource.URN("urn:pulumi:dev::proj::pkgA:m:typA::" + name)
+			m := engine.StepEventMetadata{
+				Op:      op,
+				URN:     urn,
+				Type:    urn.Type(),
+				New:     &engine.StepEventStateMetadata{URN: urn, Type: urn.Type(), Inputs: news},
+				Res:     &engine.StepEventStateMetadata{URN: urn, Type: urn.Type(), Inputs: news},
+				Logical: true,
+			}
+			if olds != nil {
+				m.Old = &engine.StepEventStateMetadata{URN: urn, Type: urn.Type(), Inputs: olds, Outputs: olds}
+			}
+			report.handleEvent(engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+				Metadata: m,
+				Planning: true,
+			}))
+		}
+		step(deploy.OpCreate, "bucket", nil, resource.PropertyMap{
+			"acl": resource.NewStringProperty("<private>"),
+		})
+		step(deploy.OpUpdate, "db", resource.PropertyMap{
+			"password": resource.NewStringProperty("[secret]"),
+			"size":     resource.NewNumberProperty(10),
+		}, resource.PropertyMap{
+			"password": resource.NewStringProperty("[secret]"),
+			"size":     resource.NewNumberProperty(20),
+		})
+
+		report.handleEvent(engine.NewEvent(engine.PolicyViolationEvent, engine.PolicyViolationEventPayload{
+			ResourceURN:       "urn:pulumi:dev::proj::pkgA:m:typA::bucket",
+			Message:           "Buckets must not be public.",
+			PolicyName:        "no-public-buckets",
+			PolicyPackName:    "security",
+			PolicyPackVersion: "1.0.0",
+			EnforcementLevel:  apitype.Mandatory,
+		}))
+		report.handleEvent(engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+			Message:  "the program is deprecated",
+			Severity: diag.Warning,
+		}))
+		report.handleEvent(engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+			Message:   "creating...",
+			Severity:  diag.Info,
+			Ephemeral: true,
+		}))
+		report.handleEvent(engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
+			IsPreview: true,
+			ResourceChanges: engine.ResourceChanges{
+				deploy.OpCreate: 1,
+				deploy.OpUpdate: 1,
+				deploy.OpSame:   3,
+			},
+		}))
+		return report
+	}
+
+	var md bytes.Buffer
+	newReport().write(&md, ReportMarkdown)
+	out := md.String()
+	assert.Contains(t, out, "# Preview of stack `dev`")
+	assert.Contains(t, out, "| `+ create` | 1 |\n| `~ update` | 1 |\n| `unchanged` | 3 |\n")
+	assert.Contains(t, out,
+		"<summary><code>+ create</code> <code>pkgA:m:typA</code> <b>bucket</b> (1 policy violation)</summary>")
+	assert.Contains(t, out, "<summary><code>~ update</code> <code>pkgA:m:typA</code> <b>db</b></summary>")
+	assert.Contains(t, out, "```diff\n+ pkgA:m:typA: (create)\n")
+	assert.Contains(t, out, `acl: "<private>"`)
+	assert.Contains(t, out, "~ size    : 10 => 20")
+	assert.Contains(t, out, `password: "[secret]"`)
+	assert.Contains(t, out, "- [mandatory] security@v1.0.0 no-public-buckets: Buckets must not be public.")
+	assert.Contains(t, out, "## Other results")
+	assert.Contains(t, out, "- warning: the program is deprecated")
+	assert.NotContains(t, out, "creating...")
+
+	var page bytes.Buffer
+	newReport().write(&page, ReportHTML)
+	out = page.String()
+	assert.Contains(t, out, "<title>Preview of stack dev</title>")
+	assert.Contains(t, out, "<tr><td><code>+ create</code></td><td>1</td></tr>")
+	assert.Contains(t, out, `<span class="create">    acl: &#34;&lt;private&gt;&#34;</span>`)
+	assert.Contains(t, out, "    password: &#34;[secret]&#34;\n<span class=\"update\">  ~ size    : 10 =&gt; 20</span>")
+	assert.Contains(t, out, "<li>[mandatory] security@v1.0.0 no-public-buckets: Buckets must not be public.</li>")
+	assert.Contains(t, out, "<li>warning: the program is deprecated</li>")
+
+	// A preview that did not complete is reported as such.
+	var partial bytes.Buffer
+	report := newPreviewReport("dev", Options{})
+	report.write(&partial, ReportMarkdown)
+	assert.Contains(t, partial.String(), "The preview did not complete.")
+}
+
+func TestPreviewReportWriteError(t *testing.T) {
+	t.Parallel()
+
+	// The report's directory is missing, so the report cannot be written once the events have been displayed.
+	opts := Options{
+		ReportPath:   filepath.Join(t.TempDir(), "missing", "report.md"),
+		ReportFormat: ReportMarkdown,
+		ReportErrors: &ReportErrors{},
+	}
+	assert.Error(t, ValidateReportPath(opts.ReportPath))
+
+	events, done := make(chan engine.Event), make(chan bool)
+	outEvents, outDone := startReporter(events, done, "stack", opts)
+	go func() {
+		for e := range outEvents {
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+		close(outDone)
+	}()
+	events <- engine.NewEvent(engine.CancelEvent, nil)
+	<-done
+
+	err := opts.ReportErrors.Err()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not write preview report")
+	}
+}
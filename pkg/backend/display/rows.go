@@ -20,6 +20,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize/english"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
@@ -58,9 +59,11 @@ type ResourceRow interface {
 
 	DiagInfo() *DiagInfo
 	PolicyPayloads() []engine.PolicyViolationEventPayload
+	PolicyRemediationPayloads() []engine.PolicyRemediationEventPayload
 
 	RecordDiagEvent(diagEvent engine.Event)
 	RecordPolicyViolationEvent(diagEvent engine.Event)
+	RecordPolicyRemediationEvent(event engine.Event)
 }
 
 // Implementation of a Row, used for the header of the grid.
@@ -100,6 +103,16 @@ func (data *headerRowData) ColorizedColumns() []string {
 		data.columns = []string{"", header("Type"), header("Name"), statusColumn, header("Info")}
 	}
 
+	// While an update is running, show how much longer we expect it to take.
+	if estimator := data.display.estimator; estimator != nil && !data.display.done {
+		if remaining := estimator.remaining(time.Now()); remaining > 0 {
+			columns := append([]string(nil), data.columns...)
+			columns[len(columns)-1] = columnHeader("Info") +
+				fmt.Sprintf(" (about %v remaining)", remaining.Round(time.Second))
+			return columns
+		}
+	}
+
 	return data.columns
 }
 
@@ -127,6 +140,9 @@ type resourceRowData struct {
 	diagInfo       *DiagInfo
 	policyPayloads []engine.PolicyViolationEventPayload
 
+	// A collection of the policy remediations applied to this resource.
+	policyRemediationPayloads []engine.PolicyRemediationEventPayload
+
 	// If this row should be hidden by default.  We will hide unless we have any child nodes
 	// we need to show.
 	hideRowIfUnnecessary bool
@@ -228,6 +244,17 @@ func (data *resourceRowData) RecordPolicyViolationEvent(event engine.Event) {
 	data.policyPayloads = append(data.policyPayloads, pePayload)
 }
 
+// PolicyRemediationPayloads returns the policy remediations applied to the resource.
+func (data *resourceRowData) PolicyRemediationPayloads() []engine.PolicyRemediationEventPayload {
+	return data.policyRemediationPayloads
+}
+
+// RecordPolicyRemediationEvent records a policy remediation event with the resourceRowData.
+func (data *resourceRowData) RecordPolicyRemediationEvent(event engine.Event) {
+	payload := event.Payload().(engine.PolicyRemediationEventPayload)
+	data.policyRemediationPayloads = append(data.policyRemediationPayloads, payload)
+}
+
 type column int
 
 const (
@@ -390,6 +417,14 @@ func (data *resourceRowData) getInfoColumn() string {
 				appendDiagMessage(eventMsg)
 			}
 		}
+
+		// Point out steps that are taking much longer than they did in previous updates.
+		if estimator := data.display.estimator; estimator != nil {
+			if usual, overdue := estimator.overdue(data.step.URN, time.Now()); overdue {
+				appendDiagMessage(fmt.Sprintf("%staking longer than usual (usually %v)%s",
+					colors.SpecWarning, usual.Round(time.Second), colors.Reset))
+			}
+		}
 	}
 
 	newLineIndex := strings.Index(diagMsg, "\n")
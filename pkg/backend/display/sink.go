@@ -0,0 +1,297 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+const (
+	// eventSinkQueueSize is the number of events buffered for a slow sink. Events that arrive while the buffer is
+	// full are dropped rather than blocking the engine.
+	eventSinkQueueSize = 4096
+	// eventSinkMaxBatchBytes bounds the size of the batches in which queued events are written to a sink.
+	eventSinkMaxBatchBytes = 1 << 20
+	// eventSinkMinBackoff and eventSinkMaxBackoff bound the delay between attempts to reconnect to a sink.
+	eventSinkMinBackoff = 100 * time.Millisecond
+	eventSinkMaxBackoff = 5 * time.Second
+	// eventSinkFlushTimeout is how long to keep trying to deliver queued events once the operation has finished.
+	eventSinkFlushTimeout = 10 * time.Second
+	// eventSinkWriteTimeout bounds each write to, or request against, a sink.
+	eventSinkWriteTimeout = 30 * time.Second
+)
+
+// eventSinkWriter writes batches of newline-delimited JSON events to a sink.
+type eventSinkWriter interface {
+	// write writes a batch to the sink, (re)connecting first if needed. The write is interrupted if the given context
+	// is cancelled.
+	write(ctx context.Context, batch []byte) error
+	// close releases any connection held to the sink.
+	close()
+}
+
+// unixSinkWriter streams events over a Unix domain socket, redialing it whenever a write fails.
+type unixSinkWriter struct {
+	path string
+	conn net.Conn
+}
+
+func (w *unixSinkWriter) write(ctx context.Context, batch []byte) error {
+	if w.conn == nil {
+		dialer := net.Dialer{Timeout: eventSinkWriteTimeout}
+		conn, err := dialer.DialContext(ctx, "unix", w.path)
+		if err != nil {
+			return err
+		}
+		w.conn = conn
+	}
+
+	// Moving the deadline to the present interrupts a write that is blocked on a sink that is not reading.
+	conn, written := w.conn, make(chan struct{})
+	defer close(written)
+	go func() {
+		select {
+		case <-ctx.Done():
+			contract.IgnoreError(conn.SetWriteDeadline(time.Now()))
+		case <-written:
+		}
+	}()
+
+	err := conn.SetWriteDeadline(time.Now().Add(eventSinkWriteTimeout))
+	if err == nil {
+		_, err = conn.Write(batch)
+	}
+	if err != nil {
+		w.close()
+		return err
+	}
+	return nil
+}
+
+func (w *unixSinkWriter) close() {
+	if w.conn != nil {
+		contract.IgnoreClose(w.conn)
+		w.conn = nil
+	}
+}
+
+// httpSinkWriter POSTs each batch of events to an HTTP endpoint.
+type httpSinkWriter struct {
+	url    string
+	client *http.Client
+}
+
+func (w *httpSinkWriter) write(ctx context.Context, batch []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(batch))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(resp.Body)
+	_, err = io.Copy(io.Discard, resp.Body)
+	contract.IgnoreError(err)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (w *httpSinkWriter) close() {
+	w.client.CloseIdleConnections()
+}
+
+// newEventSinkWriter returns a writer for an event sink given as a unix:// or http(s):// URL.
+func newEventSinkWriter(target string) (eventSinkWriter, error) {
+	u, err := url.Parse(target)
+	if err == nil {
+		switch u.Scheme {
+		case "unix":
+			path := u.Path
+			if path == "" {
+				path = u.Opaque
+			}
+			if path != "" {
+				return &unixSinkWriter{path: path}, nil
+			}
+		case "http", "https":
+			if u.Host != "" {
+				return &httpSinkWriter{url: target, client: &http.Client{Timeout: eventSinkWriteTimeout}}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("invalid event sink %q: expected unix:///path/to/socket or http(s)://host/path", target)
+}
+
+// ValidateEventSink returns an error if target is not an event sink that events can be streamed to.
+func ValidateEventSink(target string) error {
+	_, err := newEventSinkWriter(target)
+	return err
+}
+
+// eventSink forwards events to a sink in the background. Events are queued, and written in batches so that a slow
+// sink catches up; if the sink is unavailable, delivery is retried with backoff until the sink is closed.
+type eventSink struct {
+	target   string
+	writer   eventSinkWriter
+	queue    chan []byte
+	abandon  chan struct{} // closed when delivery should stop being retried.
+	finished chan struct{} // closed once the queue has been drained.
+
+	dropped int // the number of events dropped because the queue was full.
+	lost    int // the number of queued events that could not be delivered.
+}
+
+func newEventSink(target string) (*eventSink, error) {
+	writer, err := newEventSinkWriter(target)
+	if err != nil {
+		return nil, err
+	}
+	s := &eventSink{
+		target:   target,
+		writer:   writer,
+		queue:    make(chan []byte, eventSinkQueueSize),
+		abandon:  make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+// send queues an encoded event for delivery without blocking.
+func (s *eventSink) send(line []byte) {
+	select {
+	case s.queue <- line:
+	default:
+		s.dropped++
+	}
+}
+
+// close stops accepting events and waits up to timeout for those already queued to be delivered. It returns the
+// number of events that never reached the sink.
+func (s *eventSink) close(timeout time.Duration) int {
+	close(s.queue)
+	select {
+	case <-s.finished:
+	case <-time.After(timeout):
+		close(s.abandon)
+		<-s.finished
+	}
+	s.writer.close()
+	return s.dropped + s.lost
+}
+
+func (s *eventSink) run() {
+	defer close(s.finished)
+
+	for line := range s.queue {
+		// Batch up whatever else has been queued. This goroutine is the only receiver, so a non-empty queue
+		// cannot block.
+		batch, count := append([]byte(nil), line...), 1
+		for len(batch) < eventSinkMaxBatchBytes && len(s.queue) > 0 {
+			batch, count = append(batch, <-s.queue...), count+1
+		}
+
+		if !s.deliver(batch) {
+			s.lost += count
+		}
+	}
+}
+
+// deliver writes a batch to the sink, retrying with backoff until it succeeds or delivery is abandoned. Abandoning
+// delivery also interrupts a write that is in progress.
+func (s *eventSink) deliver(batch []byte) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.abandon:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	backoff := eventSinkMinBackoff
+	for {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		err := s.writer.write(ctx, batch)
+		if err == nil {
+			return true
+		}
+		logging.V(7).Infof("could not write to event sink %s: %v", s.target, err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > eventSinkMaxBackoff {
+			backoff = eventSinkMaxBackoff
+		}
+	}
+}
+
+func startEventSink(events <-chan engine.Event, done chan<- bool, opts Options) (<-chan engine.Event, chan<- bool) {
+	stderr := opts.Stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	sink, err := newEventSink(opts.EventSink)
+	if err != nil {
+		fprintfIgnoreError(stderr, "error: %v\n", err)
+		return events, done
+	}
+
+	sequence := 0
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	return passThroughEvents(events, done, func(e engine.Event) {
+		buf.Reset()
+		if err := logJSONEvent(encoder, e, opts, sequence); err != nil {
+			logging.V(7).Infof("failed to send event: %v", err)
+		} else {
+			sink.send(append([]byte(nil), buf.Bytes()...))
+		}
+		sequence++
+	}, func() {
+		if undelivered := sink.close(eventSinkFlushTimeout); undelivered > 0 {
+			fprintfIgnoreError(stderr, "warning: %d events could not be delivered to the event sink %s\n",
+				undelivered, opts.EventSink)
+		}
+	})
+}
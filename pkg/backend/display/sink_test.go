@@ -0,0 +1,198 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// runEventSink passes events through an event sink to a display that discards them, and waits for it to finish.
+func runEventSink(t *testing.T, target string, events []engine.Event) string {
+	var stderr bytes.Buffer
+	in, done := make(chan engine.Event), make(chan bool)
+	out, outDone := startEventSink(in, done, Options{EventSink: target, Stderr: &stderr})
+	go func() {
+		for range out {
+		}
+	}()
+	for _, e := range events {
+		in <- e
+	}
+	close(in)
+	close(outDone)
+	<-done
+	return stderr.String()
+}
+
+func sinkTestEvents() []engine.Event {
+	urn := resource.URN("urn:pulumi:dev::proj::pkgA:m:typA::res")
+	props := resource.PropertyMap{"password": resource.MakeSecret(resource.NewStringProperty("hunter2"))}
+	state := &engine.StepEventStateMetadata{URN: urn, Type: urn.Type(), Inputs: props}
+	return []engine.Event{
+		engine.NewEvent(engine.PreludeEvent, engine.PreludeEventPayload{}),
+		engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+			Metadata: engine.StepEventMetadata{Op: deploy.OpCreate, URN: urn, Type: urn.Type(), New: state, Res: state},
+		}),
+		engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{}),
+	}
+}
+
+func decodeSinkEvents(t *testing.T, r io.Reader) []apitype.EngineEvent {
+	var events []apitype.EngineEvent
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var e apitype.EngineEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestEventSinkHTTP(t *testing.T) {
+	t.Parallel()
+
+	var mutex sync.Mutex
+	var received []apitype.EngineEvent
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		// Fail the first request so that the batch is retried.
+		if requests++; requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(body), "hunter2")
+		received = append(received, decodeSinkEvents(t, bytes.NewReader(body))...)
+	}))
+	defer server.Close()
+
+	stderr := runEventSink(t, server.URL+"/events", sinkTestEvents())
+	assert.Empty(t, stderr)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Len(t, received, 3)
+	for i, e := range received {
+		assert.Equal(t, i, e.Sequence)
+	}
+	assert.NotNil(t, received[0].PreludeEvent)
+	require.NotNil(t, received[1].ResourcePreEvent)
+	password := received[1].ResourcePreEvent.Metadata.New.Inputs["password"].(map[string]interface{})
+	assert.Equal(t, "[secret]", password["ciphertext"])
+	assert.NotNil(t, received[2].SummaryEvent)
+}
+
+func TestEventSinkUnix(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan []apitype.EngineEvent)
+	go func() {
+		conn, err := listener.Accept()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		received <- decodeSinkEvents(t, conn)
+	}()
+
+	// The sink connection is closed once the events have been delivered, which ends the decoding above.
+	stderr := runEventSink(t, "unix://"+path, sinkTestEvents())
+	assert.Empty(t, stderr)
+	events := <-received
+	require.Len(t, events, 3)
+	assert.NotNil(t, events[1].ResourcePreEvent)
+}
+
+func TestEventSinkUnavailable(t *testing.T) {
+	t.Parallel()
+
+	// Events are queued, rather than blocking, while the sink is unavailable, and are reported as undelivered if
+	// the sink does not come back.
+	sink, err := newEventSink("unix://" + filepath.Join(t.TempDir(), "missing.sock"))
+	require.NoError(t, err)
+	for i := 0; i < eventSinkQueueSize+10; i++ {
+		sink.send([]byte("{}\n"))
+	}
+	assert.Equal(t, eventSinkQueueSize+10, sink.close(100*time.Millisecond))
+}
+
+func TestEventSinkStalled(t *testing.T) {
+	t.Parallel()
+
+	// A sink that accepts a connection but never reads from it blocks writes once the socket's buffer is full.
+	// Closing the sink must interrupt the blocked write rather than wait for it to time out.
+	path := filepath.Join(t.TempDir(), "stalled.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(eventSinkWriteTimeout)
+		}
+	}()
+
+	sink, err := newEventSink("unix://" + path)
+	require.NoError(t, err)
+	line := append(bytes.Repeat([]byte{' '}, 1023), '\n')
+	for i := 0; i < eventSinkQueueSize; i++ {
+		sink.send(line)
+	}
+
+	start := time.Now()
+	assert.Greater(t, sink.close(100*time.Millisecond), 0)
+	assert.True(t, time.Since(start) < eventSinkWriteTimeout/2, "closing the sink took %v", time.Since(start))
+}
+
+func TestValidateEventSink(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, ValidateEventSink("unix:///tmp/events.sock"))
+	assert.NoError(t, ValidateEventSink("http://localhost:8080/events"))
+	assert.NoError(t, ValidateEventSink("https://example.com/events"))
+	assert.Error(t, ValidateEventSink("/tmp/events.sock"))
+	assert.Error(t, ValidateEventSink("unix://"))
+	assert.Error(t, ValidateEventSink("http:///events"))
+	assert.Error(t, ValidateEventSink("tcp://localhost:8080"))
+}
@@ -50,9 +50,12 @@ func ShowWatchEvents(op string, action apitype.UpdateKind, events <-chan engine.
 		case engine.PreludeEvent, engine.SummaryEvent, engine.StdoutColorEvent:
 			// Ignore it
 			continue
-		case engine.PolicyViolationEvent:
+		case engine.PolicyViolationEvent, engine.PolicyRemediationEvent:
 			// At this point in time, we don't handle policy events as part of pulumi watch
 			continue
+		case engine.ProviderCallEvent:
+			// Provider call timings are only used for profiling.
+			continue
 		case engine.DiagEvent:
 			// Skip any ephemeral or debug messages, and elide all colorization.
 			p := e.Payload().(engine.DiagEventPayload)
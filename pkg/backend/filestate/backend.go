@@ -712,7 +712,7 @@ func (b *localBackend) GetLogs(ctx context.Context, stack backend.Stack, cfg bac
 	query operations.LogQuery) ([]operations.LogEntry, error) {
 
 	stackName := stack.Ref().Name()
-	target, err := b.getTarget(stackName, cfg.Config, cfg.Decrypter)
+	target, err := b.getTarget(stackName, cfg)
 	if err != nil {
 		return nil, err
 	}
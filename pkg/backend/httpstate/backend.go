@@ -1201,7 +1201,7 @@ func convertConfig(apiConfig map[string]apitype.ConfigValue) (config.Map, error)
 func (b *cloudBackend) GetLogs(ctx context.Context, stack backend.Stack, cfg backend.StackConfiguration,
 	logQuery operations.LogQuery) ([]operations.LogEntry, error) {
 
-	target, targetErr := b.getTarget(ctx, stack.Ref(), cfg.Config, cfg.Decrypter)
+	target, targetErr := b.getTarget(ctx, stack.Ref(), cfg)
 	if targetErr != nil {
 		return nil, targetErr
 	}
@@ -31,7 +31,6 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
@@ -249,7 +248,7 @@ func (b *cloudBackend) newUpdate(ctx context.Context, stackRef backend.StackRefe
 	}
 
 	// Construct the deployment target.
-	target, err := b.getTarget(ctx, stackRef, op.StackConfiguration.Config, op.StackConfiguration.Decrypter)
+	target, err := b.getTarget(ctx, stackRef, op.StackConfiguration)
 	if err != nil {
 		return nil, err
 	}
@@ -281,7 +280,7 @@ func (b *cloudBackend) getSnapshot(ctx context.Context, stackRef backend.StackRe
 }
 
 func (b *cloudBackend) getTarget(ctx context.Context, stackRef backend.StackReference,
-	cfg config.Map, dec config.Decrypter) (*deploy.Target, error) {
+	cfg backend.StackConfiguration) (*deploy.Target, error) {
 
 	snapshot, err := b.getSnapshot(ctx, stackRef)
 	if err != nil {
@@ -298,10 +297,11 @@ func (b *cloudBackend) getTarget(ctx context.Context, stackRef backend.StackRefe
 	}
 
 	return &deploy.Target{
-		Name:      stackRef.Name(),
-		Config:    cfg,
-		Decrypter: dec,
-		Snapshot:  snapshot,
+		Name:          stackRef.Name(),
+		Config:        cfg.Config,
+		Decrypter:     cfg.Decrypter,
+		Snapshot:      snapshot,
+		PolicyWaivers: cfg.PolicyWaivers,
 	}, nil
 }
 
@@ -406,6 +406,11 @@ func persistEngineEvents(
 				break
 			}
 
+			// Provider call timings are only used to profile deployments locally.
+			if e.Type == engine.ProviderCallEvent {
+				break
+			}
+
 			// Stop processing once we see the CancelEvent.
 			if e.Type == engine.CancelEvent {
 				sawCancelEvent = true
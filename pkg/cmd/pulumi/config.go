@@ -31,6 +31,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
@@ -918,13 +919,19 @@ func getStackConfiguration(stack backend.Stack, sm secrets.Manager) (backend.Sta
 		return backend.StackConfiguration{}, fmt.Errorf("loading stack configuration: %w", err)
 	}
 
+	waivers, err := deploy.NewPolicyWaivers(workspaceStack.PolicyWaivers)
+	if err != nil {
+		return backend.StackConfiguration{}, fmt.Errorf("loading stack configuration: %w", err)
+	}
+
 	// If there are no secrets in the configuration, we should never use the decrypter, so it is safe to return
 	// one which panics if it is used. This provides for some nice UX in the common case (since, for example, building
 	// the correct decrypter for the local backend would involve prompting for a passphrase)
 	if !workspaceStack.Config.HasSecureValue() {
 		return backend.StackConfiguration{
-			Config:    workspaceStack.Config,
-			Decrypter: config.NewPanicCrypter(),
+			Config:        workspaceStack.Config,
+			Decrypter:     config.NewPanicCrypter(),
+			PolicyWaivers: waivers,
 		}, nil
 	}
 
@@ -934,7 +941,8 @@ func getStackConfiguration(stack backend.Stack, sm secrets.Manager) (backend.Sta
 	}
 
 	return backend.StackConfiguration{
-		Config:    workspaceStack.Config,
-		Decrypter: crypter,
+		Config:        workspaceStack.Config,
+		Decrypter:     crypter,
+		PolicyWaivers: waivers,
 	}, nil
 }
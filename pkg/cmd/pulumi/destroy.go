@@ -43,6 +43,7 @@ func newDestroyCmd() *cobra.Command {
 	var jsonDisplay bool
 	var diffDisplay bool
 	var eventLogPath string
+	var eventSink string
 	var parallel int
 	var refresh string
 	var showConfig bool
@@ -94,10 +95,17 @@ func newDestroyCmd() *cobra.Command {
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
+				EventSink:            eventSink,
 				Debug:                debug,
 				JSONDisplay:          jsonDisplay,
 			}
 
+			if eventSink != "" {
+				if err := display.ValidateEventSink(eventSink); err != nil {
+					return result.FromError(err)
+				}
+			}
+
 			// we only suppress permalinks if the user passes true. the default is an empty string
 			// which we pass as 'false'
 			//nolint:goconst
@@ -271,6 +279,11 @@ func newDestroyCmd() *cobra.Command {
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the destroy after previewing it")
 
+	cmd.PersistentFlags().StringVar(
+		&eventSink, "event-sink", "",
+		"Stream engine events as newline-delimited JSON to a unix:///path/to/socket or http(s)://host/path URL"+
+			" while the operation runs")
+
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
 			&eventLogPath, "event-log", "",
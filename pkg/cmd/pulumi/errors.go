@@ -57,9 +57,10 @@ operations listed completed successfully by checking the state of the appropriat
 For example, if you are using AWS, you can confirm using the AWS Console.
 
 Once you have confirmed the status of the interrupted operations, you can repair your stack
-using 'pulumi stack export' to export your stack to a file. For each operation that succeeded,
-remove that operation from the "pending_operations" section of the file. Once this is complete,
-use 'pulumi stack import' to import the repaired stack.
+using 'pulumi state pending', which walks through each interrupted operation and offers to
+adopt, refresh, or discard it. Alternatively, use 'pulumi stack export' to export your stack to
+a file, remove each operation that succeeded from the "pending_operations" section of the file,
+and use 'pulumi stack import' to import the repaired stack.
 
 refusing to proceed`)
 	contract.IgnoreError(writer.Flush())
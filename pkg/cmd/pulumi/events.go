@@ -0,0 +1,37 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+)
+
+func newEventsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Work with event logs of updates",
+		Long: "Work with event logs of updates.\n" +
+			"\n" +
+			"Commands such as `pulumi up` and `pulumi preview` can record the engine events of an\n" +
+			"operation with the `--event-log` flag. The events family of commands works with those logs.",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newEventsReplayCmd())
+
+	return cmd
+}
@@ -0,0 +1,247 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// eventLog is an event log read back from a file written by --event-log.
+type eventLog struct {
+	events    []apitype.EngineEvent
+	stack     tokens.QName
+	project   tokens.PackageName
+	isPreview bool
+}
+
+// readEventLog reads the JSON-encoded engine events in the given event log. The stack, project and whether the
+// operation was a preview are not recorded in the log, so they are inferred from its resource events.
+func readEventLog(path string) (*eventLog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer contract.IgnoreClose(f)
+
+	log := &eventLog{}
+	decoder := json.NewDecoder(f)
+	for {
+		var e apitype.EngineEvent
+		if err := decoder.Decode(&e); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("reading event %d of %s: %w", len(log.events)+1, path, err)
+		}
+		log.events = append(log.events, e)
+
+		var metadata *apitype.StepEventMetadata
+		switch {
+		case e.ResourcePreEvent != nil:
+			metadata = &e.ResourcePreEvent.Metadata
+			log.isPreview = log.isPreview || e.ResourcePreEvent.Planning
+		case e.ResOutputsEvent != nil:
+			metadata = &e.ResOutputsEvent.Metadata
+			log.isPreview = log.isPreview || e.ResOutputsEvent.Planning
+		case e.ResOpFailedEvent != nil:
+			metadata = &e.ResOpFailedEvent.Metadata
+		}
+		if metadata != nil && log.stack == "" {
+			if urn := resource.URN(metadata.URN); urn.IsValid() {
+				log.stack, log.project = urn.Stack(), urn.Project()
+			}
+		}
+	}
+	if len(log.events) == 0 {
+		return nil, fmt.Errorf("%s does not contain any events", path)
+	}
+	return log, nil
+}
+
+// replay feeds the events of the log to the display as if they were being emitted by a live operation. Delays between
+// events are divided by the given speed; a speed of zero replays the events without delay.
+func (log *eventLog) replay(opts display.Options, speed float64) error {
+	kind := apitype.UpdateUpdate
+	if log.isPreview {
+		kind = apitype.PreviewUpdate
+	}
+
+	events, done := make(chan engine.Event), make(chan bool)
+	go display.ShowEvents(strings.ToLower(backend.ActionLabel(kind, log.isPreview)), kind, log.stack, log.project,
+		events, done, opts, log.isPreview)
+
+	last, canceled := 0, false
+	for _, apiEvent := range log.events {
+		if speed > 0 && last != 0 && apiEvent.Timestamp > last {
+			time.Sleep(time.Duration(float64(time.Duration(apiEvent.Timestamp-last)*time.Second) / speed))
+		}
+		if apiEvent.Timestamp != 0 {
+			last = apiEvent.Timestamp
+		}
+
+		e, err := display.ConvertJSONEvent(apiEvent, log.isPreview)
+		if err != nil {
+			// Stop the display before reporting the error.
+			events <- engine.NewEvent(engine.CancelEvent, nil)
+			<-done
+			return fmt.Errorf("converting event %d: %w", apiEvent.Sequence, err)
+		}
+		events <- e
+
+		if e.Type == engine.CancelEvent {
+			canceled = true
+			break
+		}
+	}
+
+	// Logs of operations that were interrupted may not end with a cancellation event, which the displays rely on to
+	// finish.
+	if !canceled {
+		events <- engine.NewEvent(engine.CancelEvent, nil)
+	}
+	<-done
+	return nil
+}
+
+func newEventsReplayCmd() *cobra.Command {
+	var speed float64
+	var diffDisplay bool
+	var jsonDisplay bool
+	var summaryDiff bool
+	var report string
+	var showConfig bool
+	var showReplacementSteps bool
+	var showSames bool
+	var showReads bool
+	var suppressOutputs bool
+
+	cmd := &cobra.Command{
+		Use:   "replay <file>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Replay an event log through the display",
+		Long: "Replay an event log through the display.\n" +
+			"\n" +
+			"This command reads an event log written by the `--event-log` flag of commands such as\n" +
+			"`pulumi up` and `pulumi preview`, and displays its events as if the operation were running,\n" +
+			"using the same progress, diff or JSON display. By default, events are replayed with the same\n" +
+			"timing as they were recorded; use `--speed` to replay them faster or slower.\n" +
+			"\n" +
+			"Event logs do not contain secret values or the complete state of resources, so replayed\n" +
+			"displays show secrets as [secret] and may omit some details.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			if speed < 0 {
+				return errors.New("--speed must not be negative")
+			}
+			if diffDisplay && jsonDisplay {
+				return errors.New("--diff and --json cannot be used together")
+			}
+
+			log, err := readEventLog(args[0])
+			if err != nil {
+				return err
+			}
+
+			displayType := display.DisplayProgress
+			if diffDisplay {
+				displayType = display.DisplayDiff
+			}
+			opts := display.Options{
+				Color:                cmdutil.GetGlobalColorization(),
+				ShowConfig:           showConfig,
+				ShowReplacementSteps: showReplacementSteps,
+				ShowSameResources:    showSames,
+				ShowReads:            showReads,
+				SuppressOutputs:      suppressOutputs,
+				SummaryDiff:          summaryDiff,
+				IsInteractive:        cmdutil.Interactive(),
+				Type:                 displayType,
+				JSONDisplay:          jsonDisplay,
+			}
+			if report != "" {
+				if !log.isPreview {
+					return errors.New("--report is only supported for event logs of previews")
+				}
+				if opts.ReportFormat, opts.ReportPath, err = parseReportFlag(report); err != nil {
+					return err
+				}
+				if err = display.ValidateReportPath(opts.ReportPath); err != nil {
+					return err
+				}
+				opts.ReportErrors = &display.ReportErrors{}
+			}
+
+			if !jsonDisplay {
+				fmt.Printf(opts.Color.Colorize(colors.SpecHeadline+"Replaying %s of stack %s:"+colors.Reset+"\n"),
+					args[0], log.stack)
+			}
+			if err = log.replay(opts, speed); err != nil {
+				return err
+			}
+			return opts.ReportErrors.Err()
+		}),
+	}
+
+	cmd.PersistentFlags().Float64Var(
+		&speed, "speed", 1,
+		"Replay events this many times faster than they were recorded; 0 replays them without delay")
+	cmd.PersistentFlags().BoolVar(
+		&diffDisplay, "diff", false,
+		"Display the operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonDisplay, "json", "j", false,
+		"Serialize the events as JSON, as `pulumi preview --json` does for previews")
+	cmd.PersistentFlags().BoolVar(
+		&summaryDiff, "summary", false,
+		"Only show the properties that changed in diffs")
+	cmd.PersistentFlags().StringVar(
+		&report, "report", "",
+		"Write a report of a replayed preview to a file. The value is of the form `format=file`, where format is"+
+			" markdown or html")
+	cmd.PersistentFlags().BoolVar(
+		&showConfig, "show-config", false,
+		"Show configuration keys and variables")
+	cmd.PersistentFlags().BoolVar(
+		&showReplacementSteps, "show-replacement-steps", false,
+		"Show detailed resource replacement creates and deletes instead of a single step")
+	cmd.PersistentFlags().BoolVar(
+		&showSames, "show-sames", false,
+		"Show resources that don't need be updated because they haven't changed, alongside those that do")
+	cmd.PersistentFlags().BoolVar(
+		&showReads, "show-reads", false,
+		"Show resources that are being read in, alongside those being managed directly in the stack")
+	cmd.PersistentFlags().BoolVar(
+		&suppressOutputs, "suppress-outputs", false,
+		"Suppress display of stack outputs (in case they contain sensitive values)")
+
+	return cmd
+}
@@ -282,6 +282,7 @@ func newImportCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var diffDisplay bool
 	var eventLogPath string
+	var eventSink string
 	var parallel int
 	var showConfig bool
 	var skipPreview bool
@@ -405,9 +406,16 @@ func newImportCmd() *cobra.Command {
 				IsInteractive:   interactive,
 				Type:            displayType,
 				EventLogPath:    eventLogPath,
+				EventSink:       eventSink,
 				Debug:           debug,
 			}
 
+			if eventSink != "" {
+				if err := display.ValidateEventSink(eventSink); err != nil {
+					return result.FromError(err)
+				}
+			}
+
 			// we only suppress permalinks if the user passes true. the default is an empty string
 			// which we pass as 'false'
 			if suppressPermalink == "true" {
@@ -586,6 +594,11 @@ func newImportCmd() *cobra.Command {
 		&protectResources, "protect", "", true,
 		"Allow resources to be imported with protection from deletion enabled")
 
+	cmd.PersistentFlags().StringVar(
+		&eventSink, "event-sink", "",
+		"Stream engine events as newline-delimited JSON to a unix:///path/to/socket or http(s)://host/path URL"+
+			" while the operation runs")
+
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
 			&eventLogPath, "event-log", "",
@@ -15,6 +15,9 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
+
 	"github.com/spf13/cobra"
 
 	"github.com/pulumi/pulumi/pkg/v3/engine"
@@ -43,8 +46,11 @@ func newPluginCmd() *cobra.Command {
 		Args: cmdutil.NoArgs,
 	}
 
+	cmd.AddCommand(newPluginBundleCmd())
 	cmd.AddCommand(newPluginInstallCmd())
+	cmd.AddCommand(newPluginLockCmd())
 	cmd.AddCommand(newPluginLsCmd())
+	cmd.AddCommand(newPluginPruneCmd())
 	cmd.AddCommand(newPluginRmCmd())
 
 	return cmd
@@ -56,7 +62,11 @@ func getProjectPlugins() ([]workspace.PluginInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	return getPluginsForProject(proj, root)
+}
 
+// getPluginsForProject fetches a list of plugins used by the given project, which is rooted at the given directory.
+func getPluginsForProject(proj *workspace.Project, root string) ([]workspace.PluginInfo, error) {
 	projinfo := &engine.Projinfo{Proj: proj, Root: root}
 	pwd, main, ctx, err := engine.ProjectInfoContext(projinfo, nil, nil, cmdutil.Diag(), cmdutil.Diag(), false, nil)
 	if err != nil {
@@ -86,3 +96,25 @@ func getProjectPlugins() ([]workspace.PluginInfo, error) {
 	}
 	return results, nil
 }
+
+// loadProjectPluginLock loads the plugin lock file of the current project, if there is one. If there is no current
+// project or it has no lock file, nil is returned.
+func loadProjectPluginLock() (*workspace.PluginLock, error) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	path, err := workspace.DetectProjectPathFrom(pwd)
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	lock, err := workspace.LoadPluginLock(workspace.PluginLockPath(filepath.Dir(path)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return lock, nil
+}
@@ -0,0 +1,307 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func newPluginBundleCmd() *cobra.Command {
+	var out string
+	var platforms []string
+	var stackName string
+
+	var cmd = &cobra.Command{
+		Use:   "bundle",
+		Args:  cmdutil.NoArgs,
+		Short: "Bundle the plugins used by the current project for offline installation",
+		Long: "Bundle the plugins used by the current project for offline installation.\n" +
+			"\n" +
+			"This command downloads every plugin required by the current project's program and\n" +
+			"by the resources in the stack's last deployment, and packs their tarballs into a\n" +
+			"single bundle.  The bundle can then be installed on machines without network access\n" +
+			"using `pulumi plugin install --from-bundle`.  Alternatively, a directory containing\n" +
+			"the extracted bundle can be used as a mirror by setting PULUMI_PLUGIN_MIRROR to it.\n" +
+			"\n" +
+			"By default, the bundle contains plugins for the current platform; pass --platform\n" +
+			"(e.g. linux-amd64) once for each platform the bundle will be installed on.  If the\n" +
+			"project has a Pulumi.lock file, each plugin is verified against it.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			if out == "" {
+				return errors.New("missing required flag: --out")
+			}
+			if len(platforms) == 0 {
+				platform, err := workspace.CurrentPluginPlatform()
+				if err != nil {
+					return err
+				}
+				platforms = []string{platform}
+			}
+
+			plugins, err := getProjectPlugins()
+			if err != nil {
+				return err
+			}
+
+			s, err := requireStack(stackName, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			snap, err := s.Snapshot(ctx)
+			if err != nil {
+				return err
+			}
+			snapshotPlugins, err := engine.GetSnapshotPlugins(snap)
+			if err != nil {
+				return err
+			}
+
+			lock, err := loadProjectPluginLock()
+			if err != nil {
+				return err
+			}
+
+			bundled, err := writePluginBundle(out, append(plugins, snapshotPlugins...), platforms, lock, opts.Color)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Bundled %d plugin tarballs in %s\n", bundled, out)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(&out,
+		"out", "o", "", "The path of the bundle to write")
+	cmd.PersistentFlags().StringSliceVar(&platforms,
+		"platform", nil, "A platform, in the form <os>-<arch>, to bundle plugins for (default: the current platform)")
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
+
+// writePluginBundle downloads the tarballs of the given plugins for each platform and writes them to a gzipped tarball
+// at the given path, returning the number of tarballs written. Language plugins are skipped, since they are installed
+// along with the CLI. If a plugin lock is given, each tarball must match the checksum it records.
+func writePluginBundle(path string, plugins []workspace.PluginInfo, platforms []string, lock *workspace.PluginLock,
+	color colors.Colorization) (int, error) {
+
+	// Sort and deduplicate the plugins so that bundles are deterministic.
+	byName := map[string]workspace.PluginInfo{}
+	var names []string
+	for _, plugin := range plugins {
+		if plugin.Kind == workspace.LanguagePlugin {
+			continue
+		}
+		if plugin.Version == nil {
+			return 0, fmt.Errorf("cannot bundle %s plugin %s: its version is unknown", plugin.Kind, plugin.Name)
+		}
+		key := fmt.Sprintf("%s-%s", plugin.Kind, plugin)
+		if _, has := byName[key]; !has {
+			byName[key] = plugin
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	fail := func(err error) (int, error) {
+		contract.IgnoreClose(f)
+		contract.IgnoreError(os.Remove(path))
+		return 0, err
+	}
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	count := 0
+	for _, key := range names {
+		plugin := byName[key]
+		for _, platform := range platforms {
+			if err := addPluginToBundle(tw, plugin, platform, lock, color); err != nil {
+				return fail(err)
+			}
+			count++
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fail(err)
+	}
+	if err := gz.Close(); err != nil {
+		return fail(err)
+	}
+	if err := f.Close(); err != nil {
+		return fail(err)
+	}
+	return count, nil
+}
+
+// addPluginToBundle downloads a plugin's tarball for the given platform and adds it to a bundle.
+func addPluginToBundle(tw *tar.Writer, plugin workspace.PluginInfo, platform string, lock *workspace.PluginLock,
+	color colors.Colorization) error {
+
+	label := fmt.Sprintf("[%s plugin %s %s]", plugin.Kind, plugin, platform)
+	name, err := plugin.TarballName(platform)
+	if err != nil {
+		return err
+	}
+
+	var checksum string
+	if lock != nil {
+		if checksum, err = lock.Checksum(plugin, platform); err != nil {
+			return err
+		}
+	}
+
+	cmdutil.Diag().Infoerrf(diag.Message("", "%s downloading"), label)
+	tarball, size, err := plugin.DownloadForPlatform(platform)
+	if err != nil {
+		return fmt.Errorf("%s downloading from %s: %w", label, plugin.PluginDownloadURL, err)
+	}
+	tarball = workspace.ReadCloserProgressBar(tarball, size, "Downloading plugin", color)
+	defer contract.IgnoreClose(tarball)
+
+	// Tar headers need the size of the file up front, so stage the download in a temporary file.
+	tmp, err := ioutil.TempFile("", "pulumi-plugin-*.tar.gz")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		contract.IgnoreClose(tmp)
+		contract.IgnoreError(os.Remove(tmp.Name()))
+	}()
+
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, hash), tarball)
+	if err != nil {
+		return fmt.Errorf("%s downloading: %w", label, err)
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); checksum != "" && !strings.EqualFold(actual, checksum) {
+		return fmt.Errorf("%s checksum mismatch: expected SHA-256 %s, got %s", label, checksum, actual)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	// Entries carry a fixed modification time so that bundling the same plugins always produces the same archive.
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     n,
+		Mode:     0644,
+		ModTime:  time.Unix(0, 0),
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, tmp)
+	return err
+}
+
+// installPluginBundle installs the plugins in a bundle written by `pulumi plugin bundle` that are for the current
+// platform. Plugins that are already installed are skipped unless reinstall is set. If a plugin lock is given, each
+// plugin must be pinned by it and is verified against its checksum.
+func installPluginBundle(path string, lock *workspace.PluginLock, reinstall bool) error {
+	platform, err := workspace.CurrentPluginPlatform()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening bundle %s: %w", path, err)
+	}
+	defer contract.IgnoreClose(f)
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("reading bundle %s: %w", path, err)
+	}
+	defer contract.IgnoreClose(gz)
+
+	found := 0
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("reading bundle %s: %w", path, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		install, tarballPlatform, ok := workspace.ParsePluginTarballName(filepath.Base(header.Name))
+		if !ok {
+			logging.V(1).Infof("skipping %s in bundle %s: not a plugin tarball", header.Name, path)
+			continue
+		}
+		if tarballPlatform != platform {
+			continue
+		}
+		found++
+
+		label := fmt.Sprintf("[%s plugin %s]", install.Kind, install)
+		if !reinstall && workspace.HasPlugin(install) {
+			logging.V(1).Infof("%s skipping install (existing == match)", label)
+			continue
+		}
+		if lock != nil {
+			if install.Checksum, err = lock.Checksum(install, platform); err != nil {
+				return err
+			}
+		}
+
+		cmdutil.Diag().Infoerrf(diag.Message("", "%s installing"), label)
+		if err := install.Install(ioutil.NopCloser(tr)); err != nil {
+			return fmt.Errorf("installing %s from %s: %w", label, path, err)
+		}
+	}
+
+	if found == 0 {
+		return fmt.Errorf("bundle %s contains no plugins for %s", path, platform)
+	}
+	return nil
+}
@@ -37,6 +37,7 @@ func newPluginInstallCmd() *cobra.Command {
 	var exact bool
 	var file string
 	var reinstall bool
+	var fromBundle string
 
 	var cmd = &cobra.Command{
 		Use:   "install [KIND NAME VERSION]",
@@ -50,12 +51,30 @@ func newPluginInstallCmd() *cobra.Command {
 			"project.  VERSION cannot be a range: it must be a specific number.\n" +
 			"\n" +
 			"If you let Pulumi compute the set to download, it is conservative and may end up\n" +
-			"downloading more plugins than is strictly necessary.",
+			"downloading more plugins than is strictly necessary.\n" +
+			"\n" +
+			"If the project has a Pulumi.lock file, each plugin is verified against the checksum\n" +
+			"it records and the install fails on a mismatch.  See `pulumi plugin lock`.\n" +
+			"\n" +
+			"To install plugins without network access, pass --from-bundle with a bundle\n" +
+			"created by `pulumi plugin bundle`, or set PULUMI_PLUGIN_MIRROR to a directory or\n" +
+			"HTTP server that holds plugin tarballs.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			displayOpts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
 			}
 
+			if fromBundle != "" {
+				if len(args) > 0 || file != "" {
+					return errors.New("--from-bundle cannot be combined with a specific plugin or --file (-f)")
+				}
+				lock, err := loadProjectPluginLock()
+				if err != nil {
+					return err
+				}
+				return installPluginBundle(fromBundle, lock, reinstall)
+			}
+
 			// Parse the kind, name, and version, if specified.
 			var installs []workspace.PluginInfo
 			if len(args) > 0 {
@@ -95,10 +114,27 @@ func newPluginInstallCmd() *cobra.Command {
 				}
 			}
 
+			// If the project pins its plugins, verify each install against the lock. Plugins that are named
+			// explicitly are only verified if they are pinned.
+			lock, err := loadProjectPluginLock()
+			if err != nil {
+				return err
+			}
+			platform, err := workspace.CurrentPluginPlatform()
+			if err != nil {
+				return err
+			}
+
 			// Now for each kind, name, version pair, download it from the release website, and install it.
 			for _, install := range installs {
 				label := fmt.Sprintf("[%s plugin %s]", install.Kind, install)
 
+				if lock != nil && (len(args) == 0 || lock.Find(install) != nil) {
+					if install.Checksum, err = lock.Checksum(install, platform); err != nil {
+						return err
+					}
+				}
+
 				// If the plugin already exists, don't download it unless --reinstall was passed.  Note that
 				// by default we accept plugins with >= constraints, unless --exact was passed which requires ==.
 				if !reinstall {
@@ -121,7 +157,6 @@ func newPluginInstallCmd() *cobra.Command {
 				// If we got here, actually try to do the download.
 				var source string
 				var tarball io.ReadCloser
-				var err error
 				if file == "" {
 					var size int64
 					if tarball, size, err = install.Download(); err != nil {
@@ -153,6 +188,8 @@ func newPluginInstallCmd() *cobra.Command {
 		"file", "f", "", "Install a plugin from a tarball file, instead of downloading it")
 	cmd.PersistentFlags().BoolVar(&reinstall,
 		"reinstall", false, "Reinstall a plugin even if it already exists")
+	cmd.PersistentFlags().StringVar(&fromBundle,
+		"from-bundle", "", "Install the plugins in a bundle from `pulumi plugin bundle` instead of downloading them")
 
 	return cmd
 }
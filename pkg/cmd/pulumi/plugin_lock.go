@@ -0,0 +1,126 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func newPluginLockCmd() *cobra.Command {
+	var platforms []string
+
+	var cmd = &cobra.Command{
+		Use:   "lock",
+		Args:  cmdutil.NoArgs,
+		Short: "Pin the plugins used by the current project",
+		Long: "Pin the plugins used by the current project.\n" +
+			"\n" +
+			"This command computes the set of plugins required by the current project and\n" +
+			"records the exact version of each, along with the SHA-256 of its tarball, in a\n" +
+			"Pulumi.lock file next to Pulumi.yaml.  Once the lock file exists, plugins that\n" +
+			"Pulumi installs for the project must be pinned by it, and are verified against\n" +
+			"the recorded checksum; any mismatch fails the install.\n" +
+			"\n" +
+			"Each plugin is downloaded to compute its checksum.  By default, checksums are\n" +
+			"recorded for the current platform only; pass --platform (e.g. linux-amd64) once\n" +
+			"for each platform the project is deployed from, such as CI machines.  Checksums\n" +
+			"already recorded for other platforms are kept if the plugin's version is unchanged.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			_, root, err := readProject()
+			if err != nil {
+				return err
+			}
+			plugins, err := getProjectPlugins()
+			if err != nil {
+				return err
+			}
+
+			if len(platforms) == 0 {
+				platform, err := workspace.CurrentPluginPlatform()
+				if err != nil {
+					return err
+				}
+				platforms = []string{platform}
+			}
+
+			path := workspace.PluginLockPath(root)
+			existing, err := workspace.LoadPluginLock(path)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+
+			lock, err := lockPlugins(plugins, platforms, existing)
+			if err != nil {
+				return err
+			}
+			if err := lock.Save(path); err != nil {
+				return fmt.Errorf("saving %s: %w", path, err)
+			}
+
+			fmt.Printf("Locked %d plugins in %s\n", len(lock.Plugins), path)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&platforms,
+		"platform", nil, "A platform, in the form <os>-<arch>, to record checksums for (default: the current platform)")
+
+	return cmd
+}
+
+// lockPlugins computes a plugin lock for the given plugins by downloading each of them for every given platform. The
+// checksums that an existing lock records for other platforms are kept for plugins whose versions are unchanged.
+// Language plugins are skipped.
+func lockPlugins(plugins []workspace.PluginInfo, platforms []string,
+	existing *workspace.PluginLock) (*workspace.PluginLock, error) {
+
+	lock := &workspace.PluginLock{}
+	for _, plugin := range plugins {
+		// Language plugins are installed along with the CLI rather than downloaded, so there is nothing to pin.
+		if plugin.Kind == workspace.LanguagePlugin {
+			continue
+		}
+		if plugin.Version == nil {
+			return nil, fmt.Errorf("cannot lock %s plugin %s: its version is unknown", plugin.Kind, plugin.Name)
+		}
+
+		if entry := existing.Find(plugin); entry != nil {
+			for platform, checksum := range entry.Checksums {
+				lock.Set(plugin, platform, checksum)
+			}
+		}
+
+		for _, platform := range platforms {
+			tarball, _, err := plugin.DownloadForPlatform(platform)
+			if err != nil {
+				return nil, fmt.Errorf("downloading %s plugin %s for %s: %w", plugin.Kind, plugin, platform, err)
+			}
+			checksum, err := workspace.PluginChecksum(tarball)
+			contract.IgnoreClose(tarball)
+			if err != nil {
+				return nil, fmt.Errorf("downloading %s plugin %s for %s: %w", plugin.Kind, plugin, platform, err)
+			}
+			lock.Set(plugin, platform, checksum)
+		}
+	}
+	return lock, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/blang/semver"
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func newPluginPruneCmd() *cobra.Command {
+	var keep int
+	var projects []string
+	var stacks []string
+	var dryRun bool
+	var yes bool
+
+	var cmd = &cobra.Command{
+		Use:   "prune",
+		Args:  cmdutil.NoArgs,
+		Short: "Remove old plugin versions from the download cache",
+		Long: "Remove old plugin versions from the download cache.\n" +
+			"\n" +
+			"Every plugin upgrade leaves the previous version in the cache.  This command keeps\n" +
+			"the newest --keep versions of each plugin, along with any version used by the\n" +
+			"projects passed with --project (including versions pinned by their Pulumi.lock)\n" +
+			"and by the resources of the stacks passed with --stack, and removes the rest.\n" +
+			"Plugins without a version are never removed.\n" +
+			"\n" +
+			"Each plugin is removed while holding its install lock, so plugins that are being\n" +
+			"installed concurrently are left alone until their installation completes.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			yes = yes || skipConfirmations()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			if keep < 0 {
+				return errors.New("--keep must not be negative")
+			}
+
+			referenced, err := getReferencedPlugins(ctx, projects, stacks, opts)
+			if err != nil {
+				return err
+			}
+
+			plugins, err := workspace.GetPluginsWithMetadata()
+			if err != nil {
+				return fmt.Errorf("loading plugins: %w", err)
+			}
+			prunes := workspace.SelectPluginsToPrune(plugins, keep, referenced)
+			if len(prunes) == 0 {
+				cmdutil.Diag().Infof(
+					diag.Message("", "no plugins found to prune"))
+				return nil
+			}
+
+			var size uint64
+			for _, plugin := range prunes {
+				size += uint64(plugin.Size)
+			}
+
+			// Confirm that the user wants to do this (unless --yes was passed), and do the prunes.
+			var suffix string
+			if len(prunes) != 1 {
+				suffix = "s"
+			}
+			verb := "This will remove"
+			if dryRun {
+				verb = "This would remove"
+			}
+			fmt.Print(
+				opts.Color.Colorize(
+					fmt.Sprintf("%s%s %d plugin%s, freeing %s:%s\n",
+						colors.SpecAttention, verb, len(prunes), suffix, humanize.Bytes(size), colors.Reset)))
+			for _, plugin := range prunes {
+				fmt.Printf("    %s %s (%s)\n", plugin.Kind, plugin.String(), humanize.Bytes(uint64(plugin.Size)))
+			}
+			if dryRun || (!yes && !confirmPrompt("", "yes", opts)) {
+				return nil
+			}
+
+			var result error
+			var freed uint64
+			var removed int
+			for _, plugin := range prunes {
+				if err := plugin.Prune(); err != nil {
+					result = multierror.Append(
+						result, fmt.Errorf("failed to prune %s plugin %s: %w", plugin.Kind, plugin, err))
+					continue
+				}
+				freed += uint64(plugin.Size)
+				removed++
+			}
+			fmt.Printf("Removed %d of %d plugins, freeing %s\n", removed, len(prunes), humanize.Bytes(freed))
+			return result
+		}),
+	}
+
+	cmd.PersistentFlags().IntVar(
+		&keep, "keep", 1,
+		"The number of versions of each plugin to keep, newest first")
+	cmd.PersistentFlags().StringSliceVar(
+		&projects, "project", nil,
+		"The directory of a project whose plugins should be kept (may be repeated)")
+	cmd.PersistentFlags().StringSliceVar(
+		&stacks, "stack", nil,
+		"The name of a stack whose plugins should be kept (may be repeated)")
+	cmd.PersistentFlags().BoolVar(
+		&dryRun, "dry-run", false,
+		"Show the plugins that would be removed, without removing them")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with removal anyway")
+
+	return cmd
+}
+
+// getReferencedPlugins returns the plugins used by the projects rooted at (or above) the given directories, including
+// those pinned by their lock files, and the plugins required by the resources in the given stacks.
+func getReferencedPlugins(ctx context.Context, projects, stacks []string,
+	opts display.Options) ([]workspace.PluginInfo, error) {
+
+	var referenced []workspace.PluginInfo
+	for _, dir := range projects {
+		path, err := workspace.DetectProjectPathFrom(dir)
+		if err != nil {
+			return nil, fmt.Errorf("finding project in %s: %w", dir, err)
+		} else if path == "" {
+			return nil, fmt.Errorf("no Pulumi.yaml project file found in %s", dir)
+		}
+		proj, err := workspace.LoadProject(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Pulumi project located at %q: %w", path, err)
+		}
+		root := filepath.Dir(path)
+
+		plugins, err := getPluginsForProject(proj, root)
+		if err != nil {
+			return nil, fmt.Errorf("loading plugins for project %s: %w", proj.Name, err)
+		}
+		referenced = append(referenced, plugins...)
+
+		lock, err := workspace.LoadPluginLock(workspace.PluginLockPath(root))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if lock != nil {
+			for _, entry := range lock.Plugins {
+				// LoadPluginLock has already validated the versions.
+				version, err := semver.ParseTolerant(entry.Version)
+				contract.AssertNoError(err)
+				referenced = append(referenced, workspace.PluginInfo{
+					Kind:    entry.Kind,
+					Name:    entry.Name,
+					Version: &version,
+				})
+			}
+		}
+	}
+
+	for _, stackName := range stacks {
+		s, err := requireStack(stackName, false, opts, false /*setCurrent*/)
+		if err != nil {
+			return nil, err
+		}
+		snap, err := s.Snapshot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		plugins, err := engine.GetSnapshotPlugins(snap)
+		if err != nil {
+			return nil, fmt.Errorf("loading plugins for stack %s: %w", stackName, err)
+		}
+		referenced = append(referenced, plugins...)
+	}
+	return referenced, nil
+}
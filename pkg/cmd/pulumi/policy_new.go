@@ -203,6 +203,10 @@ func installPolicyPackDependencies(proj *workspace.PolicyPackProject, projPath,
 		if err := proj.Save(projPath); err != nil {
 			return fmt.Errorf("saving project at %s: %w", projPath, err)
 		}
+	} else if strings.EqualFold(proj.Runtime.Name(), "go") {
+		if err := goInstallDependencies(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -215,6 +219,8 @@ func printPolicyPackNextSteps(proj *workspace.PolicyPackProject, root string, ge
 			commands = append(commands, "npm install")
 		} else if strings.EqualFold(proj.Runtime.Name(), "python") {
 			commands = append(commands, pythonCommands()...)
+		} else if strings.EqualFold(proj.Runtime.Name(), "go") {
+			commands = append(commands, "go mod tidy")
 		}
 	}
 
@@ -0,0 +1,289 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	resourceanalyzer "github.com/pulumi/pulumi/pkg/v3/resource/analyzer"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// policyViolationJSON is the JSON representation of a policy violation found by `pulumi policy test`.
+type policyViolationJSON struct {
+	PolicyPackName    string                   `json:"policyPackName"`
+	PolicyPackVersion string                   `json:"policyPackVersion"`
+	PolicyName        string                   `json:"policyName"`
+	EnforcementLevel  apitype.EnforcementLevel `json:"enforcementLevel"`
+	URN               string                   `json:"urn,omitempty"`
+	Message           string                   `json:"message"`
+	Tags              []string                 `json:"tags,omitempty"`
+}
+
+func newPolicyTestCmd() *cobra.Command {
+	var stackName string
+	var stateFile string
+	var configFile string
+	var jsonOut bool
+
+	var cmd = &cobra.Command{
+		Use:   "test <pack-dir>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Run a Policy Pack against a stack's existing state",
+		Long: "Run a Policy Pack against a stack's existing state.\n" +
+			"\n" +
+			"This command checks each resource in a stack's most recent deployment against the Policy Pack\n" +
+			"in the given directory, and then checks the stack as a whole, and reports any violations. Resource\n" +
+			"policies are given each resource's inputs, and stack policies each resource's outputs, just as\n" +
+			"they would be during a preview that made no changes. The stack's program is not run and no\n" +
+			"provider is contacted, which makes this a quick way to assess a new policy's impact on existing\n" +
+			"stacks before enforcing it.\n" +
+			"\n" +
+			"The state is read from the current stack, the stack named by --stack, or a file written by\n" +
+			"`pulumi stack export` named by --state. The command fails if any mandatory policy is violated.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			if stackName != "" && stateFile != "" {
+				return errors.New("only one of --stack or --state may be specified")
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			snap, err := loadPolicyTestSnapshot(stackName, stateFile, opts)
+			if err != nil {
+				return err
+			}
+
+			pwd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			plugctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, pwd, nil, false, nil)
+			if err != nil {
+				return err
+			}
+			defer contract.IgnoreClose(plugctx)
+
+			// Policy packs may use the project and stack names, so pass those of the stack that is being tested.
+			analyzerOpts := &plugin.PolicyAnalyzerOptions{DryRun: true}
+			stackURN := snapshotStackURN(snap)
+			if stackURN != "" {
+				analyzerOpts.Project = string(stackURN.Project())
+				analyzerOpts.Stack = string(stackURN.Stack())
+			}
+			analyzer, err := loadPolicyTestAnalyzer(plugctx, args[0], configFile, analyzerOpts)
+			if err != nil {
+				return err
+			}
+
+			diagnostics, err := resourceanalyzer.AnalyzeState([]plugin.Analyzer{analyzer}, snap.Resources)
+			if err != nil {
+				return err
+			}
+			// As in a deployment, violations of stack policies that do not name a resource are attributed to the
+			// stack itself.
+			for i := range diagnostics {
+				if diagnostics[i].URN == "" {
+					diagnostics[i].URN = stackURN
+				}
+			}
+
+			if jsonOut {
+				if err := printJSON(policyViolationsToJSON(diagnostics)); err != nil {
+					return err
+				}
+			} else {
+				printPolicyViolations(diagnostics, opts)
+			}
+
+			for _, d := range diagnostics {
+				if d.EnforcementLevel == apitype.Mandatory {
+					return errors.New("mandatory policy violations found")
+				}
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack whose state to check. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&stateFile, "state", "",
+		"The path to a file containing the state to check, as written by `pulumi stack export`")
+	cmd.PersistentFlags().StringVar(
+		&configFile, "config", "",
+		"The path to a JSON file containing the config for the Policy Pack")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit the policy violations as JSON")
+
+	return cmd
+}
+
+// loadPolicyTestSnapshot loads the state to check from the given exported state file or, if none is given, from the
+// given stack.
+func loadPolicyTestSnapshot(stackName, stateFile string, opts display.Options) (*deploy.Snapshot, error) {
+	if stateFile != "" {
+		f, err := os.Open(stateFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not open file: %w", err)
+		}
+		defer contract.IgnoreClose(f)
+
+		var deployment apitype.UntypedDeployment
+		if err = json.NewDecoder(f).Decode(&deployment); err != nil {
+			return nil, fmt.Errorf("could not read state from %q: %w", stateFile, err)
+		}
+		snap, err := stack.DeserializeUntypedDeployment(&deployment, stack.DefaultSecretsProvider)
+		if err != nil {
+			return nil, checkDeploymentVersionError(err, stateFile)
+		}
+		return snap, nil
+	}
+
+	s, err := requireStack(stackName, false, opts, false /*setCurrent*/)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := s.Snapshot(commandContext())
+	if err != nil {
+		return nil, err
+	}
+	if snap == nil {
+		return nil, fmt.Errorf("unable to find snapshot for stack %q", s.Ref())
+	}
+	return snap, nil
+}
+
+// snapshotStackURN returns the URN of the root stack resource in the given snapshot, if it has one.
+func snapshotStackURN(snap *deploy.Snapshot) resource.URN {
+	for _, r := range snap.Resources {
+		if r.Type == resource.RootStackType && r.Parent == "" && !r.Delete {
+			return r.URN
+		}
+	}
+	return ""
+}
+
+// loadPolicyTestAnalyzer loads the policy pack in the given directory and configures it from the given config file,
+// if any, in the same way as a local policy pack passed to `pulumi preview --policy-pack`.
+func loadPolicyTestAnalyzer(plugctx *plugin.Context, path, configFile string,
+	opts *plugin.PolicyAnalyzerOptions) (plugin.Analyzer, error) {
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	analyzer, err := plugctx.Host.PolicyAnalyzer(tokens.QName(abs), path, opts)
+	if err != nil {
+		return nil, err
+	} else if analyzer == nil {
+		return nil, fmt.Errorf("policy analyzer could not be loaded from path %q", path)
+	}
+
+	analyzerInfo, err := analyzer.GetAnalyzerInfo()
+	if err != nil {
+		return nil, err
+	}
+	if !analyzerInfo.SupportsConfig {
+		if configFile != "" {
+			return nil, fmt.Errorf("policy pack %q at %q does not support config", analyzerInfo.Name, path)
+		}
+		return analyzer, nil
+	}
+
+	var configFromFile map[string]plugin.AnalyzerPolicyConfig
+	if configFile != "" {
+		configFromFile, err = resourceanalyzer.LoadPolicyPackConfigFromFile(configFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	config, validationErrors, err := resourceanalyzer.ReconcilePolicyPackConfig(
+		analyzerInfo.Policies, analyzerInfo.InitialConfig, configFromFile)
+	if err != nil {
+		return nil, fmt.Errorf("reconciling policy config for %q at %q: %w", analyzerInfo.Name, path, err)
+	}
+	if len(validationErrors) > 0 {
+		return nil, fmt.Errorf("validating policy config: %s", strings.Join(validationErrors, "; "))
+	}
+	if err = analyzer.Configure(config); err != nil {
+		return nil, fmt.Errorf("configuring policy pack %q at %q: %w", analyzerInfo.Name, path, err)
+	}
+	return analyzer, nil
+}
+
+func policyViolationsToJSON(diagnostics []plugin.AnalyzeDiagnostic) []policyViolationJSON {
+	result := make([]policyViolationJSON, len(diagnostics))
+	for i, d := range diagnostics {
+		result[i] = policyViolationJSON{
+			PolicyPackName:    d.PolicyPackName,
+			PolicyPackVersion: d.PolicyPackVersion,
+			PolicyName:        d.PolicyName,
+			EnforcementLevel:  d.EnforcementLevel,
+			URN:               string(d.URN),
+			Message:           d.Message,
+			Tags:              d.Tags,
+		}
+	}
+	return result
+}
+
+// printPolicyViolations prints policy violations in the same format as the "Policy Violations:" section of a preview,
+// followed by a count of the violations.
+func printPolicyViolations(diagnostics []plugin.AnalyzeDiagnostic, opts display.Options) {
+	if len(diagnostics) == 0 {
+		fmt.Println("No policy violations found")
+		return
+	}
+
+	fmt.Println(opts.Color.Colorize(colors.SpecHeadline + "Policy Violations:" + colors.Reset))
+	mandatory := 0
+	for _, d := range diagnostics {
+		c := colors.SpecImportant
+		if d.EnforcementLevel == apitype.Mandatory {
+			c = colors.SpecError
+			mandatory++
+		}
+
+		var resourceName string
+		if d.URN != "" {
+			resourceName = fmt.Sprintf(" (%s: %s)", d.URN.Type(), d.URN.Name())
+		}
+		fmt.Println(opts.Color.Colorize(fmt.Sprintf("    %s[%s]  %s v%s %s %s%s",
+			c, d.EnforcementLevel, d.PolicyPackName, d.PolicyPackVersion, colors.Reset, d.PolicyName, resourceName)))
+
+		// The message may span multiple lines, so we massage it so it will be indented properly.
+		fmt.Printf("    %s\n", strings.ReplaceAll(d.Message, "\n", "\n    "))
+	}
+	fmt.Println()
+	fmt.Printf("%d policy violations found (%d mandatory)\n", len(diagnostics), mandatory)
+}
@@ -17,6 +17,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -45,6 +46,7 @@ func newPreviewCmd() *cobra.Command {
 	var policyPackConfigPaths []string
 	var diffDisplay bool
 	var eventLogPath string
+	var eventSink string
 	var parallel int
 	var refresh string
 	var showConfig bool
@@ -57,6 +59,12 @@ func newPreviewCmd() *cobra.Command {
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
+	var refuseReplace []string
+	var refuseDelete []string
+	var explain string
+	var browse bool
+	var report string
+	var policyReport string
 
 	var cmd = &cobra.Command{
 		Use:        "preview",
@@ -92,7 +100,46 @@ func newPreviewCmd() *cobra.Command {
 				Type:                 displayType,
 				JSONDisplay:          jsonDisplay,
 				EventLogPath:         eventLogPath,
+				EventSink:            eventSink,
+				ExplainURN:           resource.URN(explain),
+				Browse:               browse,
 				Debug:                debug,
+				ReportErrors:         &display.ReportErrors{},
+			}
+
+			if browse && (!cmdutil.Interactive() || jsonDisplay || diffDisplay) {
+				return result.FromError(errors.New(
+					"--interactive requires an interactive terminal and cannot be used with --json or --diff"))
+			}
+			if report != "" {
+				format, path, err := parseReportFlag(report)
+				if err != nil {
+					return result.FromError(err)
+				}
+				if err = display.ValidateReportPath(path); err != nil {
+					return result.FromError(err)
+				}
+				displayOpts.ReportFormat, displayOpts.ReportPath = format, path
+			}
+			if policyReport != "" {
+				format, path, err := parsePolicyReportFlag(policyReport)
+				if err != nil {
+					return result.FromError(err)
+				}
+				if err = display.ValidatePolicyReportPath(path); err != nil {
+					return result.FromError(err)
+				}
+				displayOpts.PolicyReportFormat, displayOpts.PolicyReportPath = format, path
+			}
+			if explain != "" && jsonDisplay {
+				return result.FromError(errors.New("--explain is not supported with --json; " +
+					"replacement reasons are included in the JSON output"))
+			}
+
+			if eventSink != "" {
+				if err := display.ValidateEventSink(eventSink); err != nil {
+					return result.FromError(err)
+				}
 			}
 
 			// we only suppress permalinks if the user passes true. the default is an empty string
@@ -180,6 +227,8 @@ func newPreviewCmd() *cobra.Command {
 					DisableOutputValues:       disableOutputValues(),
 					UpdateTargets:             targetURNs,
 					TargetDependents:          targetDependents,
+					RefuseReplace:             refuseReplace,
+					RefuseDelete:              refuseDelete,
 				},
 				Display: displayOpts,
 			}
@@ -199,6 +248,8 @@ func newPreviewCmd() *cobra.Command {
 				return PrintEngineResult(res)
 			case expectNop && changes != nil && changes.HasChanges():
 				return result.FromError(errors.New("error: no changes were expected but changes were proposed"))
+			case displayOpts.ReportErrors.Err() != nil:
+				return result.FromError(displayOpts.ReportErrors.Err())
 			default:
 				return nil
 			}
@@ -246,6 +297,31 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows updating of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().StringArrayVar(
+		&refuseReplace, "refuse-replace", []string{},
+		"Fail before making any changes if a resource matching this URN or type pattern would be replaced."+
+			" Multiple patterns can be specified using --refuse-replace p1 --refuse-replace p2")
+	cmd.PersistentFlags().StringArrayVar(
+		&refuseDelete, "refuse-delete", []string{},
+		"Fail before making any changes if a resource matching this URN or type pattern would be deleted or replaced."+
+			" Multiple patterns can be specified using --refuse-delete p1 --refuse-delete p2")
+	cmd.PersistentFlags().BoolVar(
+		&browse, "interactive", false,
+		"Browse the preview in a full-screen tree view once it completes. Components can be collapsed, resources"+
+			" filtered by operation and searched by name, and resources marked as targets or replacements for a"+
+			" follow-up `pulumi up`")
+	cmd.PersistentFlags().StringVar(
+		&report, "report", "",
+		"Write a report of the preview, with a summary and a collapsible diff of each resource, to a file for use in"+
+			" code review. The value is of the form `format=file`, where format is markdown or html")
+	cmd.PersistentFlags().StringVar(
+		&policyReport, "policy-report", "",
+		"Write the policy violations found by the preview to a file. The value is of the form `format=file`, where"+
+			" format is sarif, for code scanning tools, or json")
+	cmd.PersistentFlags().StringVar(
+		&explain, "explain", "",
+		"Explain whether and why the resource with this URN will be replaced, including any upstream resources"+
+			" whose replacement causes it")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().StringSliceVar(
@@ -289,6 +365,11 @@ func newPreviewCmd() *cobra.Command {
 		"Suppress display of the state permalink")
 	cmd.Flag("suppress-permalink").NoOptDefVal = "false"
 
+	cmd.PersistentFlags().StringVar(
+		&eventSink, "event-sink", "",
+		"Stream engine events as newline-delimited JSON to a unix:///path/to/socket or http(s)://host/path URL"+
+			" while the operation runs")
+
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
 			&eventLogPath, "event-log", "",
@@ -305,3 +386,35 @@ func newPreviewCmd() *cobra.Command {
 
 	return cmd
 }
+
+// parseReportFlag parses the value of the --report flag, which is of the form <format>=<file>.
+func parseReportFlag(report string) (display.ReportFormat, string, error) {
+	parts := strings.SplitN(report, "=", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid --report %q: expected <format>=<file>", report)
+	}
+	format, path := parts[0], parts[1]
+	switch f := display.ReportFormat(format); f {
+	case display.ReportMarkdown, display.ReportHTML:
+		return f, path, nil
+	default:
+		return "", "", fmt.Errorf("invalid --report format %q: expected %q or %q",
+			format, display.ReportMarkdown, display.ReportHTML)
+	}
+}
+
+// parsePolicyReportFlag parses the value of the --policy-report flag, which is of the form <format>=<file>.
+func parsePolicyReportFlag(report string) (display.PolicyReportFormat, string, error) {
+	parts := strings.SplitN(report, "=", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid --policy-report %q: expected <format>=<file>", report)
+	}
+	format, path := parts[0], parts[1]
+	switch f := display.PolicyReportFormat(format); f {
+	case display.PolicyReportSARIF, display.PolicyReportJSON:
+		return f, path, nil
+	default:
+		return "", "", fmt.Errorf("invalid --policy-report format %q: expected %q or %q",
+			format, display.PolicyReportSARIF, display.PolicyReportJSON)
+	}
+}
@@ -41,6 +41,7 @@ func newRefreshCmd() *cobra.Command {
 	var jsonDisplay bool
 	var diffDisplay bool
 	var eventLogPath string
+	var eventSink string
 	var parallel int
 	var showConfig bool
 	var showReplacementSteps bool
@@ -90,10 +91,17 @@ func newRefreshCmd() *cobra.Command {
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
+				EventSink:            eventSink,
 				Debug:                debug,
 				JSONDisplay:          jsonDisplay,
 			}
 
+			if eventSink != "" {
+				if err := display.ValidateEventSink(eventSink); err != nil {
+					return result.FromError(err)
+				}
+			}
+
 			// we only suppress permalinks if the user passes true. the default is an empty string
 			// which we pass as 'false'
 			if suppressPermalink == "true" {
@@ -227,6 +235,11 @@ func newRefreshCmd() *cobra.Command {
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the refresh after previewing it")
 
+	cmd.PersistentFlags().StringVar(
+		&eventSink, "event-sink", "",
+		"Stream engine events as newline-delimited JSON to a unix:///path/to/socket or http(s)://host/path URL"+
+			" while the operation runs")
+
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
 			&eventLogPath, "event-log", "",
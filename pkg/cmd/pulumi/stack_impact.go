@@ -0,0 +1,175 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/graph"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+)
+
+// impactEdgeJSON is the JSON representation of a direct dependency of an impacted resource.
+type impactEdgeJSON struct {
+	Kind     string `json:"kind"`
+	URN      string `json:"urn"`
+	Property string `json:"property,omitempty"`
+}
+
+// impactedResourceJSON is the JSON representation of a resource impacted by a change to another resource.
+type impactedResourceJSON struct {
+	URN               string           `json:"urn"`
+	Type              string           `json:"type"`
+	Edges             []impactEdgeJSON `json:"edges"`
+	Replace           string           `json:"replace,omitempty"`
+	ReplaceProperties []string         `json:"replaceProperties,omitempty"`
+}
+
+func newStackImpactCmd() *cobra.Command {
+	var jsonOut bool
+	var stackName string
+
+	cmd := &cobra.Command{
+		Use:   "impact <urn>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Show the resources that depend on a resource",
+		Long: "Show the resources that depend on a resource.\n" +
+			"\n" +
+			"This command lists every resource in the stack's most recent deployment that depends on the\n" +
+			"given resource, directly or indirectly, through parents, dependencies, property dependencies\n" +
+			"or provider references. It also shows which of those resources would be replaced if the given\n" +
+			"resource were deleted before being replaced: resources managed by a replaced provider are always\n" +
+			"replaced, and resources whose properties depend on a replaced resource are replaced if their\n" +
+			"provider requires it. No program is run and no provider is contacted.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+			if snap == nil {
+				return fmt.Errorf("unable to find snapshot for stack %q", stackName)
+			}
+
+			urn := resource.URN(args[0])
+			var res *resource.State
+			for _, r := range snap.Resources {
+				if r.URN == urn && !r.Delete {
+					res = r
+					break
+				}
+			}
+			if res == nil {
+				return fmt.Errorf("no resource named '%v' found in the stack", urn)
+			}
+
+			impacted := graph.NewDependencyGraph(snap.Resources).ImpactOf(res)
+			if jsonOut {
+				return printJSON(impactedResourcesToJSON(impacted))
+			}
+			printImpactedResources(urn, impacted)
+			return nil
+		}),
+	}
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit the impacted resources as JSON")
+	return cmd
+}
+
+func impactedResourcesToJSON(impacted []graph.ImpactedResource) []impactedResourceJSON {
+	result := make([]impactedResourceJSON, len(impacted))
+	for i, r := range impacted {
+		edges := make([]impactEdgeJSON, len(r.Edges))
+		for j, e := range r.Edges {
+			edges[j] = impactEdgeJSON{Kind: string(e.Kind), URN: string(e.URN), Property: string(e.Property)}
+		}
+		var props []string
+		for _, k := range r.ReplaceKeys {
+			props = append(props, string(k))
+		}
+		result[i] = impactedResourceJSON{
+			URN:               string(r.State.URN),
+			Type:              string(r.State.Type),
+			Edges:             edges,
+			Replace:           string(r.Replace),
+			ReplaceProperties: props,
+		}
+	}
+	return result
+}
+
+func printImpactedResources(urn resource.URN, impacted []graph.ImpactedResource) {
+	if len(impacted) == 0 {
+		fmt.Printf("No resources depend on %s\n", urn)
+		return
+	}
+
+	fmt.Printf("Resources that depend on %s (%d):\n", urn, len(impacted))
+	fmt.Println()
+
+	replaced, mayBeReplaced := 0, 0
+	rows := make([]cmdutil.TableRow, len(impacted))
+	for i, r := range impacted {
+		via := make([]string, len(r.Edges))
+		for j, e := range r.Edges {
+			if e.Kind == graph.EdgeProperty {
+				via[j] = fmt.Sprintf("%s %s of %s", e.Kind, e.Property, e.URN.Name())
+			} else {
+				via[j] = fmt.Sprintf("%s %s", e.Kind, e.URN.Name())
+			}
+		}
+
+		var replace string
+		switch r.Replace {
+		case graph.ImpactReplaced:
+			replace = "replace"
+			replaced++
+		case graph.ImpactMayBeReplaced:
+			keys := make([]string, len(r.ReplaceKeys))
+			for j, k := range r.ReplaceKeys {
+				keys[j] = string(k)
+			}
+			replace = fmt.Sprintf("replace if [%s] require it", strings.Join(keys, ", "))
+			mayBeReplaced++
+		}
+
+		rows[i] = cmdutil.TableRow{
+			Columns: []string{string(r.State.Type), string(r.State.URN.Name()), strings.Join(via, ", "), replace},
+		}
+	}
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"TYPE", "NAME", "DEPENDS VIA", "IF REPLACED"},
+		Rows:    rows,
+		Prefix:  "    ",
+	})
+
+	fmt.Println()
+	fmt.Printf("If %s were deleted before being replaced, %d of these resources would be replaced "+
+		"and %d more might be, depending on their providers.\n", urn.Name(), replaced, mayBeReplaced)
+}
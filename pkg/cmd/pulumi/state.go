@@ -46,6 +46,7 @@ troubleshooting a stack or when performing specific edits that otherwise would r
 	}
 
 	cmd.AddCommand(newStateDeleteCommand())
+	cmd.AddCommand(newStatePendingCommand())
 	cmd.AddCommand(newStateUnprotectCommand())
 	return cmd
 }
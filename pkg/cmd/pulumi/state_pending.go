@@ -0,0 +1,377 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+	"github.com/spf13/cobra"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
+)
+
+// pendingAction is a way of resolving a single pending operation.
+type pendingAction string
+
+const (
+	pendingAdopt   pendingAction = "adopt"
+	pendingImport  pendingAction = "import"
+	pendingRefresh pendingAction = "refresh"
+	pendingRemove  pendingAction = "remove"
+	pendingDiscard pendingAction = "discard"
+	pendingSkip    pendingAction = "skip"
+)
+
+func newStatePendingCommand() *cobra.Command {
+	var stack string
+	var importFilePath string
+
+	cmd := &cobra.Command{
+		Use:   "pending",
+		Short: "Resolve operations left pending by an interrupted update",
+		Long: `Resolve operations left pending by an interrupted update
+
+When an update is interrupted, the operations that were in flight are recorded in the stack's state as pending.
+Because their outcome is unknown, subsequent updates refuse to run until they have been resolved.
+
+This command walks through each pending operation and offers a way to resolve it:
+
+  - a pending create can be adopted by looking up the created resource by its ID, recorded in an import file
+    for 'pulumi import --file', or discarded if the resource was never created;
+  - a pending update or delete can be refreshed by reading the resource's current state from its provider,
+    or discarded if the operation never took effect. A pending delete can also be resolved by removing
+    the resource from the stack.
+
+When run non-interactively, this command lists the pending operations without changing the stack's state.`,
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			return resolvePendingOperations(stack, importFilePath)
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().StringVar(
+		&importFilePath, "import-file", "pending-imports.json",
+		"The path of the import file to which resources marked for import are added")
+
+	return cmd
+}
+
+func resolvePendingOperations(stackName, importFilePath string) result.Result {
+	var resolved int
+	res := runTotalStateEdit(stackName, false, func(opts display.Options, snap *deploy.Snapshot) error {
+		if snap == nil || len(snap.PendingOperations) == 0 {
+			return errors.New("the stack has no pending operations")
+		}
+
+		if !cmdutil.Interactive() {
+			msg := "the stack has the following pending operations:\n"
+			for _, op := range snap.PendingOperations {
+				msg += fmt.Sprintf("  * %s %s\n", op.Type, op.Resource.URN)
+			}
+			return errors.New(msg + "resolving pending operations requires an interactive terminal")
+		}
+
+		resolver, err := newPendingResolver(snap)
+		if err != nil {
+			return err
+		}
+		defer resolver.close()
+
+		// Resolving an operation removes it from the snapshot, so iterate over a copy.
+		pending := append([]resource.Operation(nil), snap.PendingOperations...)
+		for _, op := range pending {
+			ok, err := resolver.resolve(opts, op)
+			if err != nil {
+				return err
+			}
+			if ok {
+				resolved++
+			}
+		}
+
+		return resolver.writeImportFile(importFilePath)
+	})
+	if res != nil {
+		return res
+	}
+
+	fmt.Printf("Resolved %d pending operation(s)\n", resolved)
+	return nil
+}
+
+// pendingResolver resolves the pending operations in a snapshot, loading provider plugins on demand.
+type pendingResolver struct {
+	snap      *deploy.Snapshot
+	ctx       *plugin.Context
+	providers map[string]plugin.Provider
+	imports   importFile
+}
+
+func newPendingResolver(snap *deploy.Snapshot) (*pendingResolver, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	sink := cmdutil.Diag()
+	ctx, err := plugin.NewContext(sink, sink, nil, nil, cwd, nil, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pendingResolver{
+		snap:      snap,
+		ctx:       ctx,
+		providers: make(map[string]plugin.Provider),
+		imports:   importFile{NameTable: make(map[string]resource.URN)},
+	}, nil
+}
+
+func (r *pendingResolver) close() {
+	contract.IgnoreClose(r.ctx)
+}
+
+// resolve prompts for and applies a resolution for the given pending operation. It returns false if the operation
+// was left pending.
+func (r *pendingResolver) resolve(opts display.Options, op resource.Operation) (bool, error) {
+	action, err := promptPendingAction(opts, op)
+	if err != nil {
+		return false, err
+	}
+
+	switch action {
+	case pendingAdopt:
+		id, err := promptPendingID(op)
+		if err != nil {
+			return false, err
+		}
+		prov, err := r.provider(op.Resource)
+		if err != nil {
+			return false, err
+		}
+		return true, edit.AdoptPendingCreate(r.snap, op, id, prov)
+	case pendingImport:
+		id, err := promptPendingID(op)
+		if err != nil {
+			return false, err
+		}
+		r.addImport(op.Resource, id)
+		return true, edit.RemovePendingOperation(r.snap, op)
+	case pendingRefresh:
+		prov, err := r.provider(op.Resource)
+		if err != nil {
+			return false, err
+		}
+		return true, edit.RefreshPendingOperation(r.snap, op, prov)
+	case pendingRemove:
+		for _, res := range edit.LocateResource(r.snap, op.Resource.URN) {
+			if res.ID == op.Resource.ID {
+				if err := edit.DeleteResource(r.snap, res); err != nil {
+					return false, err
+				}
+				break
+			}
+		}
+		return true, edit.RemovePendingOperation(r.snap, op)
+	case pendingDiscard:
+		return true, edit.RemovePendingOperation(r.snap, op)
+	default:
+		return false, nil
+	}
+}
+
+// provider loads and configures the provider for the given resource.
+func (r *pendingResolver) provider(res *resource.State) (plugin.Provider, error) {
+	if prov, ok := r.providers[res.Provider]; ok {
+		return prov, nil
+	}
+
+	ref, err := providers.ParseReference(res.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("parsing provider reference for resource %q: %w", res.URN, err)
+	}
+
+	var provState *resource.State
+	for _, candidate := range r.snap.Resources {
+		if candidate.URN == ref.URN() && candidate.ID == ref.ID() {
+			provState = candidate
+			break
+		}
+	}
+	if provState == nil {
+		return nil, fmt.Errorf("provider %q for resource %q was not found in the stack's state", ref, res.URN)
+	}
+
+	registry, err := providers.NewRegistry(r.ctx.Host, []*resource.State{provState}, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	prov, ok := registry.GetProvider(ref)
+	contract.Assertf(ok, "provider %v was not loaded", ref)
+
+	r.providers[res.Provider] = prov
+	return prov, nil
+}
+
+// addImport records the given resource in the import file that is written once all operations have been resolved.
+func (r *pendingResolver) addImport(res *resource.State, id resource.ID) {
+	spec := importSpec{
+		Type: res.Type,
+		Name: res.URN.Name(),
+		ID:   id,
+	}
+	if res.Parent != "" && res.Parent.Type() != resource.RootStackType {
+		spec.Parent = r.importName(res.Parent)
+	}
+	if ref, err := providers.ParseReference(res.Provider); err == nil && !providers.IsDefaultProvider(ref.URN()) {
+		spec.Provider = r.importName(ref.URN())
+	}
+	r.imports.Resources = append(r.imports.Resources, spec)
+}
+
+// importName returns the name under which the given URN is recorded in the import file's name table.
+func (r *pendingResolver) importName(urn resource.URN) string {
+	name := string(urn.Name())
+	for i := 2; ; i++ {
+		existing, ok := r.imports.NameTable[name]
+		if !ok || existing == urn {
+			break
+		}
+		name = fmt.Sprintf("%s-%d", urn.Name(), i)
+	}
+	r.imports.NameTable[name] = urn
+	return name
+}
+
+// writeImportFile adds any resources marked for import to the import file at the given path.
+func (r *pendingResolver) writeImportFile(path string) error {
+	if len(r.imports.Resources) == 0 {
+		return nil
+	}
+
+	f := importFile{NameTable: make(map[string]resource.URN)}
+	if _, err := os.Stat(path); err == nil {
+		if f, err = readImportFile(path); err != nil {
+			return fmt.Errorf("could not read import file: %w", err)
+		}
+		if f.NameTable == nil {
+			f.NameTable = make(map[string]resource.URN)
+		}
+	}
+	for name, urn := range r.imports.NameTable {
+		if existing, ok := f.NameTable[name]; ok && existing != urn {
+			return fmt.Errorf("import file %v already uses the name %q for %v", path, name, existing)
+		}
+		f.NameTable[name] = urn
+	}
+	f.Resources = append(f.Resources, r.imports.Resources...)
+
+	bytes, err := json.MarshalIndent(f, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(path, bytes, 0600); err != nil {
+		return fmt.Errorf("could not write import file: %w", err)
+	}
+
+	fmt.Printf("Resources to import were written to %v; run 'pulumi import --file %v' to import them\n", path, path)
+	return nil
+}
+
+// promptPendingAction asks the user how the given pending operation should be resolved.
+func promptPendingAction(opts display.Options, op resource.Operation) (pendingAction, error) {
+	type choice struct {
+		action pendingAction
+		label  string
+	}
+
+	var choices []choice
+	switch op.Type {
+	case resource.OperationTypeCreating:
+		if op.Resource.Custom {
+			choices = append(choices,
+				choice{pendingAdopt, "look up the created resource by its ID and add it to the stack"},
+				choice{pendingImport, "record the created resource in an import file"})
+		}
+		choices = append(choices, choice{pendingDiscard, "the resource was not created"})
+	case resource.OperationTypeUpdating:
+		choices = append(choices,
+			choice{pendingRefresh, "read the resource's current state from its provider"},
+			choice{pendingDiscard, "keep the resource's last recorded state"})
+	case resource.OperationTypeDeleting:
+		choices = append(choices,
+			choice{pendingRefresh, "remove the resource from the stack only if it no longer exists"},
+			choice{pendingRemove, "the resource was deleted; remove it from the stack"},
+			choice{pendingDiscard, "the resource was not deleted"})
+	default:
+		choices = append(choices, choice{pendingDiscard, "drop the pending operation"})
+	}
+	choices = append(choices, choice{pendingSkip, "leave the operation pending"})
+
+	// Note: this is done to adhere to the same color scheme as the `pulumi new` picker, which also does this.
+	surveycore.DisableColor = true
+	surveycore.QuestionIcon = ""
+	surveycore.SelectFocusIcon = opts.Color.Colorize(colors.BrightGreen + ">" + colors.Reset)
+	prompt := fmt.Sprintf("Pending %s of %s. How should it be resolved?", op.Type, op.Resource.URN)
+	prompt = opts.Color.Colorize(colors.SpecPrompt + prompt + colors.Reset)
+
+	var options []string
+	optionMap := make(map[string]pendingAction)
+	for _, c := range choices {
+		option := fmt.Sprintf("%s: %s", c.action, c.label)
+		options = append(options, option)
+		optionMap[option] = c.action
+	}
+
+	cmdutil.EndKeypadTransmitMode()
+
+	var option string
+	if err := survey.AskOne(&survey.Select{
+		Message:  prompt,
+		Options:  options,
+		PageSize: len(options),
+	}, &option, nil); err != nil {
+		return "", errors.New("no resolution selected")
+	}
+
+	return optionMap[option], nil
+}
+
+// promptPendingID asks the user for the ID of the resource affected by the given pending operation.
+func promptPendingID(op resource.Operation) (resource.ID, error) {
+	var id string
+	if err := survey.AskOne(&survey.Input{
+		Message: fmt.Sprintf("ID of %s:", op.Resource.URN),
+		Default: string(op.Resource.ID),
+	}, &id, survey.Required); err != nil {
+		return "", errors.New("no ID provided")
+	}
+	return resource.ID(id), nil
+}
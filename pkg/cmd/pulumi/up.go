@@ -61,6 +61,8 @@ func newUpCmd() *cobra.Command {
 	var policyPackConfigPaths []string
 	var diffDisplay bool
 	var eventLogPath string
+	var eventSink string
+	var policyReport string
 	var parallel int
 	var refresh string
 	var showConfig bool
@@ -76,6 +78,11 @@ func newUpCmd() *cobra.Command {
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
+	var continueOnError bool
+	var profilePath string
+	var refuseReplace []string
+	var refuseDelete []string
+	var stepApproval bool
 
 	// up implementation used when the source of the Pulumi program is in the current working directory.
 	upWorkingDirectory := func(opts backend.UpdateOptions) result.Result {
@@ -141,6 +148,14 @@ func newUpCmd() *cobra.Command {
 			DisableOutputValues:       disableOutputValues(),
 			UpdateTargets:             targetURNs,
 			TargetDependents:          targetDependents,
+			ContinueOnError:           continueOnError,
+			ProfileProviderCalls:      profilePath != "",
+			RefuseReplace:             refuseReplace,
+			RefuseDelete:              refuseDelete,
+		}
+		if stepApproval {
+			opts.Engine.ApproveStep = backend.NewStepApprover(opts.Display)
+			opts.Engine.Parallel = 1
 		}
 
 		changes, res := s.Update(commandContext(), backend.UpdateOperation{
@@ -299,10 +314,18 @@ func newUpCmd() *cobra.Command {
 		}
 
 		opts.Engine = engine.UpdateOptions{
-			LocalPolicyPacks: engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths),
-			Parallel:         parallel,
-			Debug:            debug,
-			Refresh:          refreshOption,
+			LocalPolicyPacks:     engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths),
+			Parallel:             parallel,
+			Debug:                debug,
+			Refresh:              refreshOption,
+			ContinueOnError:      continueOnError,
+			ProfileProviderCalls: profilePath != "",
+			RefuseReplace:        refuseReplace,
+			RefuseDelete:         refuseDelete,
+		}
+		if stepApproval {
+			opts.Engine.ApproveStep = backend.NewStepApprover(opts.Display)
+			opts.Engine.Parallel = 1
 		}
 
 		// TODO for the URL case:
@@ -380,8 +403,41 @@ func newUpCmd() *cobra.Command {
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
+				EventSink:            eventSink,
+				ProfilePath:          profilePath,
 				Debug:                debug,
 				JSONDisplay:          jsonDisplay,
+				ReportErrors:         &display.ReportErrors{},
+			}
+
+			// Prompting for each step requires a terminal, and the prompts would garble a display that redraws itself.
+			if stepApproval {
+				if !interactive || jsonDisplay {
+					return result.FromError(
+						errors.New("--step requires an interactive terminal and cannot be used with --json"))
+				}
+				opts.Display.IsInteractive = false
+			}
+
+			if eventSink != "" {
+				if err := display.ValidateEventSink(eventSink); err != nil {
+					return result.FromError(err)
+				}
+			}
+			if profilePath != "" {
+				if err := display.ValidateProfilePath(profilePath); err != nil {
+					return result.FromError(err)
+				}
+			}
+			if policyReport != "" {
+				format, path, err := parsePolicyReportFlag(policyReport)
+				if err != nil {
+					return result.FromError(err)
+				}
+				if err = display.ValidatePolicyReportPath(path); err != nil {
+					return result.FromError(err)
+				}
+				opts.Display.PolicyReportFormat, opts.Display.PolicyReportPath = format, path
 			}
 
 			// we only suppress permalinks if the user passes true. the default is an empty string
@@ -403,11 +459,16 @@ func newUpCmd() *cobra.Command {
 				opts.Display.SuppressPermalink = true
 			}
 
+			var res result.Result
 			if len(args) > 0 {
-				return upTemplateNameOrURL(args[0], opts)
+				res = upTemplateNameOrURL(args[0], opts)
+			} else {
+				res = upWorkingDirectory(opts)
 			}
-
-			return upWorkingDirectory(opts)
+			if res == nil && opts.Display.ReportErrors.Err() != nil {
+				return result.FromError(opts.Display.ReportErrors.Err())
+			}
+			return res
 		}),
 	}
 
@@ -456,6 +517,24 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows updating of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().BoolVar(
+		&continueOnError, "continue-on-error", false,
+		"Continue updating resources that do not depend on a failed resource instead of stopping at the first error")
+	cmd.PersistentFlags().StringVar(
+		&profilePath, "profile", "",
+		"Write a Chrome trace event profile of the update's steps and provider calls to a file at this path")
+	cmd.PersistentFlags().StringArrayVar(
+		&refuseReplace, "refuse-replace", []string{},
+		"Fail before making any changes if a resource matching this URN or type pattern would be replaced."+
+			" Multiple patterns can be specified using --refuse-replace p1 --refuse-replace p2")
+	cmd.PersistentFlags().StringArrayVar(
+		&refuseDelete, "refuse-delete", []string{},
+		"Fail before making any changes if a resource matching this URN or type pattern would be deleted or replaced."+
+			" Multiple patterns can be specified using --refuse-delete p1 --refuse-delete p2")
+	cmd.PersistentFlags().BoolVar(
+		&stepApproval, "step", false,
+		"Show each update, replace and delete step's diff before it runs and ask whether to apply or skip it, or to"+
+			" abort the update. Steps are run one at a time")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().StringSliceVar(
@@ -505,6 +584,15 @@ func newUpCmd() *cobra.Command {
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the update after previewing it")
 
+	cmd.PersistentFlags().StringVar(
+		&eventSink, "event-sink", "",
+		"Stream engine events as newline-delimited JSON to a unix:///path/to/socket or http(s)://host/path URL"+
+			" while the operation runs")
+	cmd.PersistentFlags().StringVar(
+		&policyReport, "policy-report", "",
+		"Write the policy violations found by the update to a file. The value is of the form `format=file`, where"+
+			" format is sarif, for code scanning tools, or json")
+
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
 			&eventLogPath, "event-log", "",
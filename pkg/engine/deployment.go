@@ -128,6 +128,15 @@ type deploymentOptions struct {
 	// true if we should trust the dependency graph reported by the language host. Not all Pulumi-supported languages
 	// correctly report their dependencies, in which case this will be false.
 	trustDependencies bool
+
+	// the project's policies for retrying provider operations that fail with transient errors, if any.
+	retryPolicies *deploy.RetryPolicies
+
+	// the project's limits on concurrent operations against particular providers and resource types, if any.
+	concurrencyLimits *deploy.ConcurrencyLimits
+
+	// the resources that the deployment must not delete or replace, if any.
+	changeGuards *deploy.ChangeGuards
 }
 
 // deploymentSourceFunc is a callback that will be used to prepare for, and evaluate, the "new" state for a stack.
@@ -152,8 +161,27 @@ func newDeployment(ctx *Context, info *deploymentContext, opts deploymentOptions
 	if err != nil {
 		return nil, err
 	}
+	if opts.ProfileProviderCalls {
+		plugctx.Host = newProfilingHost(plugctx.Host, opts.Events)
+	}
 
 	opts.trustDependencies = proj.TrustResourceDependencies()
+	opts.retryPolicies, err = getRetryPolicies(proj)
+	if err != nil {
+		contract.IgnoreClose(plugctx)
+		return nil, err
+	}
+	opts.concurrencyLimits, err = getConcurrencyLimits(proj)
+	if err != nil {
+		contract.IgnoreClose(plugctx)
+		return nil, err
+	}
+	opts.changeGuards, err = getChangeGuards(proj, opts.UpdateOptions)
+	if err != nil {
+		contract.IgnoreClose(plugctx)
+		return nil, err
+	}
+
 	// Now create the state source.  This may issue an error if it can't create the source.  This entails,
 	// for example, loading any plugins which will be required to execute a program, among other things.
 	source, err := opts.SourceFunc(ctx.BackendClient, opts, proj, pwd, main, target, plugctx, dryRun)
@@ -207,6 +235,92 @@ func newDeployment(ctx *Context, info *deploymentContext, opts deploymentOptions
 	}, nil
 }
 
+// getRetryPolicies returns the retry policies configured in the given project's options, if any.
+func getRetryPolicies(proj *workspace.Project) (*deploy.RetryPolicies, error) {
+	if proj.Options == nil || proj.Options.Retry == nil {
+		return nil, nil
+	}
+
+	convert := func(p workspace.ProjectRetryPolicy) (*resource.RetryPolicy, error) {
+		policy := &resource.RetryPolicy{MaxAttempts: p.MaxAttempts, Errors: p.Errors}
+		if p.Backoff != "" {
+			backoff, err := time.ParseDuration(p.Backoff)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse retry backoff value %s", p.Backoff)
+			}
+			policy.Backoff = backoff.Seconds()
+		}
+		if err := deploy.ValidateRetryPolicy(policy); err != nil {
+			return nil, err
+		}
+		return policy, nil
+	}
+
+	retry := proj.Options.Retry
+	defaultPolicy, err := convert(retry.ProjectRetryPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project retry policy: %w", err)
+	}
+
+	policies := &deploy.RetryPolicies{Default: defaultPolicy, Types: map[tokens.Type]*resource.RetryPolicy{}}
+	for typ, p := range retry.Types {
+		policy, err := convert(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry policy for type %s: %w", typ, err)
+		}
+		policies.Types[tokens.Type(typ)] = policy
+	}
+	return policies, nil
+}
+
+// getConcurrencyLimits returns the concurrency limits configured in the given project's options, if any.
+func getConcurrencyLimits(proj *workspace.Project) (*deploy.ConcurrencyLimits, error) {
+	if proj.Options == nil || proj.Options.Concurrency == nil {
+		return nil, nil
+	}
+
+	limits := &deploy.ConcurrencyLimits{
+		Packages: map[tokens.Package]int{},
+		Types:    map[tokens.Type]int{},
+	}
+	for pkg, n := range proj.Options.Concurrency.Providers {
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid concurrency limit %d for provider %s: limits must be positive", n, pkg)
+		}
+		limits.Packages[tokens.Package(pkg)] = n
+	}
+	for typ, n := range proj.Options.Concurrency.Types {
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid concurrency limit %d for type %s: limits must be positive", n, typ)
+		}
+		limits.Types[tokens.Type(typ)] = n
+	}
+	return limits, nil
+}
+
+// getChangeGuards combines the change guards configured in the given project's options with those in the given update
+// options, if any.
+func getChangeGuards(proj *workspace.Project, opts UpdateOptions) (*deploy.ChangeGuards, error) {
+	guards := &deploy.ChangeGuards{
+		RefuseReplace: append([]string(nil), opts.RefuseReplace...),
+		RefuseDelete:  append([]string(nil), opts.RefuseDelete...),
+	}
+	if proj.Options != nil && proj.Options.Guard != nil {
+		guards.RefuseReplace = append(guards.RefuseReplace, proj.Options.Guard.RefuseReplace...)
+		guards.RefuseDelete = append(guards.RefuseDelete, proj.Options.Guard.RefuseDelete...)
+	}
+	if len(guards.RefuseReplace) == 0 && len(guards.RefuseDelete) == 0 {
+		return nil, nil
+	}
+
+	for _, pattern := range append(append([]string(nil), guards.RefuseReplace...), guards.RefuseDelete...) {
+		if err := deploy.ValidateChangeGuardPattern(pattern); err != nil {
+			return nil, fmt.Errorf("invalid change guard: %w", err)
+		}
+	}
+	return guards, nil
+}
+
 type deployment struct {
 	Ctx        *deploymentContext // deployment context information.
 	Plugctx    *plugin.Context    // the context containing plugins and their state.
@@ -241,7 +355,8 @@ func (deployment *deployment) run(cancelCtx *Context, actions runActions, policy
 	}
 
 	// Emit an appropriate prelude event.
-	deployment.Options.Events.preludeEvent(preview, deployment.Ctx.Update.GetTarget().Config)
+	target := deployment.Ctx.Update.GetTarget()
+	deployment.Options.Events.preludeEvent(preview, target.Config, target.Snapshot)
 
 	// Execute the deployment.
 	start := time.Now()
@@ -263,6 +378,10 @@ func (deployment *deployment) run(cancelCtx *Context, actions runActions, policy
 			UseLegacyDiff:             deployment.Options.UseLegacyDiff,
 			DisableResourceReferences: deployment.Options.DisableResourceReferences,
 			DisableOutputValues:       deployment.Options.DisableOutputValues,
+			ContinueOnError:           deployment.Options.ContinueOnError,
+			RetryPolicies:             deployment.Options.retryPolicies,
+			ConcurrencyLimits:         deployment.Options.concurrencyLimits,
+			ChangeGuards:              deployment.Options.changeGuards,
 		}
 		walkResult = deployment.Deployment.Execute(ctx, opts, preview)
 		close(done)
@@ -16,6 +16,7 @@ package engine
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"reflect"
@@ -24,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/yaml.v3"
 
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
@@ -145,6 +147,9 @@ func GetResourcePropertiesSummary(step StepEventMetadata, indent int) string {
 	if urn != "" {
 		writeWithIndentNoPrefix(&b, indent+1, simplePropOp, "[urn=%s]\n", urn)
 	}
+	if old != nil && old.RetainOnDelete && (op == deploy.OpDelete || op == deploy.OpDeleteReplaced) {
+		writeWithIndentNoPrefix(&b, indent+1, simplePropOp, "[retainOnDelete]\n")
+	}
 
 	if step.Provider != "" {
 		new := step.New
@@ -678,6 +683,13 @@ func printPropertyValueDiff(
 				return
 			}
 
+			if diff.Old.IsString() && diff.New.IsString() {
+				if printEncodedValueDiff(b, titleFunc, diff.Old.StringValue(), diff.New.StringValue(),
+					planning, indent, summary, debug) {
+					return
+				}
+			}
+
 			if isPrimitive(diff.Old) && isPrimitive(diff.New) {
 				titleFunc(deploy.OpUpdate, true /*indent*/)
 				printPrimitivePropertyValue(b, diff.Old, planning, deploy.OpDelete)
@@ -699,6 +711,89 @@ func printPropertyValueDiff(
 	}
 }
 
+// printEncodedValueDiff prints the difference between two strings that both hold JSON or YAML documents as a diff of
+// the decoded documents, and returns true. If either string does not hold such a document, nothing is printed and
+// false is returned. Documents that only differ in formatting are printed as unchanged.
+func printEncodedValueDiff(b *bytes.Buffer, titleFunc func(deploy.StepOp, bool), old, new string,
+	planning bool, indent int, summary bool, debug bool) bool {
+
+	oldValue, _, ok := DecodeStructuredString(old)
+	if !ok {
+		return false
+	}
+	newValue, kind, ok := DecodeStructuredString(new)
+	if !ok {
+		return false
+	}
+
+	diff := oldValue.Diff(newValue)
+	if diff == nil {
+		if !summary {
+			titleFunc(deploy.OpSame, false)
+			writeVerbatim(b, deploy.OpSame, "("+kind+") ")
+			printPropertyValue(b, newValue, planning, indent, deploy.OpSame, false, debug)
+		}
+		return true
+	}
+
+	encodedTitleFunc := func(op deploy.StepOp, prefix bool) {
+		titleFunc(op, prefix)
+		writeVerbatim(b, op, "("+kind+") ")
+	}
+	printPropertyValueDiff(b, encodedTitleFunc, *diff, planning, indent, summary, debug)
+	return true
+}
+
+// DecodeStructuredString decodes a string that holds a JSON or YAML document into a property value, and returns the
+// value along with the kind of document ("json" or "yaml"). Only documents whose top-level value is an object or an
+// array are decoded. YAML documents must also span multiple lines, as most single-line strings are valid YAML. A YAML
+// string that holds several documents is decoded as an array of documents.
+func DecodeStructuredString(s string) (resource.PropertyValue, string, bool) {
+	isStructured := func(v resource.PropertyValue) bool {
+		return v.IsObject() || v.IsArray()
+	}
+
+	var jsonValue interface{}
+	if err := json.Unmarshal([]byte(s), &jsonValue); err == nil {
+		v := resource.NewPropertyValue(jsonValue)
+		return v, "json", isStructured(v)
+	}
+
+	if !strings.Contains(strings.TrimSpace(s), "\n") {
+		return resource.PropertyValue{}, "", false
+	}
+	var docs []interface{}
+	decoder := yaml.NewDecoder(strings.NewReader(s))
+	for {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return resource.PropertyValue{}, "", false
+		}
+		docs = append(docs, doc)
+	}
+
+	var yamlValue interface{} = docs
+	if len(docs) == 1 {
+		yamlValue = docs[0]
+	}
+
+	// YAML allows keys of any type, so round-trip the document through JSON to normalize it into values that can be
+	// represented as properties.
+	data, err := json.Marshal(yamlValue)
+	if err != nil {
+		return resource.PropertyValue{}, "", false
+	}
+	if err = json.Unmarshal(data, &yamlValue); err != nil {
+		return resource.PropertyValue{}, "", false
+	}
+	v := resource.NewPropertyValue(yamlValue)
+	return v, "yaml", isStructured(v)
+}
+
 func isPrimitive(value resource.PropertyValue) bool {
 	return value.IsNull() || value.IsString() || value.IsNumber() ||
 		value.IsBool() || value.IsComputed() || value.IsOutput()
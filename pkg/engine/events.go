@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
@@ -60,6 +61,10 @@ func NewEvent(typ EventType, payload interface{}) Event {
 		_, ok = payload.(ResourceOperationFailedPayload)
 	case PolicyViolationEvent:
 		_, ok = payload.(PolicyViolationEventPayload)
+	case PolicyRemediationEvent:
+		_, ok = payload.(PolicyRemediationEventPayload)
+	case ProviderCallEvent:
+		_, ok = payload.(ProviderCallEventPayload)
 	default:
 		contract.Failf("unknown event type %v", typ)
 	}
@@ -83,6 +88,8 @@ const (
 	ResourceOutputsEvent    EventType = "resource-outputs"
 	ResourceOperationFailed EventType = "resource-operationfailed"
 	PolicyViolationEvent    EventType = "policy-violation"
+	PolicyRemediationEvent  EventType = "policy-remediation"
+	ProviderCallEvent       EventType = "provider-call"
 )
 
 func (e Event) Payload() interface{} {
@@ -114,6 +121,34 @@ type PolicyViolationEventPayload struct {
 	PolicyPackVersion string
 	EnforcementLevel  apitype.EnforcementLevel
 	Prefix            string
+	Waiver            *PolicyViolationWaiver // the policy waiver that matched the violation, if any.
+}
+
+// PolicyViolationWaiver describes the policy waiver that matched a policy violation.
+type PolicyViolationWaiver struct {
+	Reason  string
+	Expires time.Time
+	Expired bool // true if the waiver had expired, in which case the violation was not waived.
+}
+
+// FormatPolicyWaiverExpiry formats the expiry of a policy waiver as a date if it expires at midnight UTC, as it does
+// if its expiry was written as a date, and as an RFC 3339 time otherwise.
+func FormatPolicyWaiverExpiry(expires time.Time) string {
+	if utc := expires.UTC(); utc.Equal(utc.Truncate(24 * time.Hour)) {
+		return utc.Format("2006-01-02")
+	}
+	return expires.Format(time.RFC3339)
+}
+
+// PolicyRemediationEventPayload is the payload for an event with type `policy-remediation`.
+type PolicyRemediationEventPayload struct {
+	ResourceURN       resource.URN
+	Color             colors.Colorization
+	PolicyName        string
+	PolicyPackName    string
+	PolicyPackVersion string
+	Before            resource.PropertyMap // the resource's inputs before the remediation.
+	After             resource.PropertyMap // the resource's inputs after the remediation.
 }
 
 type StdoutEventPayload struct {
@@ -124,6 +159,15 @@ type StdoutEventPayload struct {
 type PreludeEventPayload struct {
 	IsPreview bool              // true if this prelude is for a plan operation
 	Config    map[string]string // the keys and values for config. For encrypted config, the values may be blinded
+
+	// History records what the stack's previous state says about each resource, for estimating progress.
+	History map[resource.URN]ResourceHistory
+}
+
+// ResourceHistory summarizes what a stack's previous state records about one of its resources.
+type ResourceHistory struct {
+	StepDuration time.Duration  // the time taken by the last step that created or updated the resource
+	Dependencies []resource.URN // the resources that had to be processed before this resource
 }
 
 type SummaryEventPayload struct {
@@ -135,21 +179,38 @@ type SummaryEventPayload struct {
 }
 
 type ResourceOperationFailedPayload struct {
-	Metadata StepEventMetadata
-	Status   resource.Status
-	Steps    int
+	Metadata  StepEventMetadata
+	Status    resource.Status
+	Steps     int
+	StartTime time.Time // the time at which the step started
+	EndTime   time.Time // the time at which the step failed
 }
 
 type ResourceOutputsEventPayload struct {
-	Metadata StepEventMetadata
-	Planning bool
-	Debug    bool
+	Metadata  StepEventMetadata
+	Planning  bool
+	Debug     bool
+	StartTime time.Time // the time at which the step started
+	EndTime   time.Time // the time at which the step completed or its outputs were registered
 }
 
 type ResourcePreEventPayload struct {
-	Metadata StepEventMetadata
-	Planning bool
-	Debug    bool
+	Metadata  StepEventMetadata
+	Planning  bool
+	Debug     bool
+	StartTime time.Time // the time at which the step started
+}
+
+// ProviderCallEventPayload is the payload for an event with type `provider-call`. These events are only emitted if
+// provider call profiling is enabled.
+type ProviderCallEventPayload struct {
+	Provider  tokens.Package      // the package of the provider that was called
+	Method    string              // the provider method that was called, e.g. "Create" or "Invoke"
+	URN       resource.URN        // the URN of the resource the call pertains to, if any
+	Token     tokens.ModuleMember // the token of the function that was invoked or called, if any
+	StartTime time.Time           // the time at which the call started
+	EndTime   time.Time           // the time at which the call returned
+	Failed    bool                // true if the call returned an error
 }
 
 // StepEventMetadata contains the metadata associated with a step the engine is performing.
@@ -165,6 +226,8 @@ type StepEventMetadata struct {
 	DetailedDiff map[string]plugin.PropertyDiff // the rich, structured diff
 	Logical      bool                           // true if this step represents a logical operation in the program.
 	Provider     string                         // the provider that performed this step.
+
+	ReplaceReasons []deploy.ReplaceReason // why the resource is being replaced (only for replacement steps).
 }
 
 // StepEventStateMetadata contains detailed metadata about a resource's state pertaining to a given step.
@@ -185,6 +248,8 @@ type StepEventStateMetadata struct {
 	Parent resource.URN
 	// true to "protect" this resource (protected resources cannot be deleted).
 	Protect bool
+	// true if this resource should be removed from state rather than deleted by its provider.
+	RetainOnDelete bool
 	// the resource's input properties (as specified by the program). Note: because this will cross
 	// over rpc boundaries it will be slightly different than the Inputs found in resource_state.
 	// Specifically, secrets will have been filtered out, and large values (like assets) will be
@@ -308,6 +373,18 @@ func makeStepEventMetadata(op deploy.StepOp, step deploy.Step, debug bool) StepE
 		detailedDiff = detailedDiffer.DetailedDiff()
 	}
 
+	var replaceReasons []deploy.ReplaceReason
+	if reasoner, hasReasons := step.(interface{ ReplaceReasons() []deploy.ReplaceReason }); hasReasons {
+		replaceReasons = reasoner.ReplaceReasons()
+	}
+
+	// A delete step may retain a resource according to the program's current options rather than the options
+	// recorded in the resource's old state.
+	oldMetadata := makeStepEventStateMetadata(step.Old(), debug)
+	if retainer, hasRetain := step.(interface{ RetainOnDelete() bool }); hasRetain && oldMetadata != nil {
+		oldMetadata.RetainOnDelete = retainer.RetainOnDelete()
+	}
+
 	return StepEventMetadata{
 		Op:           op,
 		URN:          step.URN(),
@@ -315,11 +392,13 @@ func makeStepEventMetadata(op deploy.StepOp, step deploy.Step, debug bool) StepE
 		Keys:         keys,
 		Diffs:        diffs,
 		DetailedDiff: detailedDiff,
-		Old:          makeStepEventStateMetadata(step.Old(), debug),
+		Old:          oldMetadata,
 		New:          makeStepEventStateMetadata(step.New(), debug),
 		Res:          makeStepEventStateMetadata(step.Res(), debug),
 		Logical:      step.Logical(),
 		Provider:     step.Provider(),
+
+		ReplaceReasons: replaceReasons,
 	}
 }
 
@@ -329,18 +408,19 @@ func makeStepEventStateMetadata(state *resource.State, debug bool) *StepEventSta
 	}
 
 	return &StepEventStateMetadata{
-		State:      state,
-		Type:       state.Type,
-		URN:        state.URN,
-		Custom:     state.Custom,
-		Delete:     state.Delete,
-		ID:         state.ID,
-		Parent:     state.Parent,
-		Protect:    state.Protect,
-		Inputs:     filterPropertyMap(state.Inputs, debug),
-		Outputs:    filterPropertyMap(state.Outputs, debug),
-		Provider:   state.Provider,
-		InitErrors: state.InitErrors,
+		State:          state,
+		Type:           state.Type,
+		URN:            state.URN,
+		Custom:         state.Custom,
+		Delete:         state.Delete,
+		ID:             state.ID,
+		Parent:         state.Parent,
+		Protect:        state.Protect,
+		RetainOnDelete: state.RetainOnDelete,
+		Inputs:         filterPropertyMap(state.Inputs, debug),
+		Outputs:        filterPropertyMap(state.Outputs, debug),
+		Provider:       state.Provider,
+		InitErrors:     state.InitErrors,
 	}
 }
 
@@ -467,40 +547,63 @@ func (e *eventEmitter) Close() {
 }
 
 func (e *eventEmitter) resourceOperationFailedEvent(
-	step deploy.Step, status resource.Status, steps int, debug bool) {
+	step deploy.Step, status resource.Status, steps int, debug bool, start time.Time) {
 
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- NewEvent(ResourceOperationFailed, ResourceOperationFailedPayload{
-		Metadata: makeStepEventMetadata(step.Op(), step, debug),
-		Status:   status,
-		Steps:    steps,
+		Metadata:  makeStepEventMetadata(step.Op(), step, debug),
+		Status:    status,
+		Steps:     steps,
+		StartTime: start,
+		EndTime:   time.Now(),
 	})
 }
 
-func (e *eventEmitter) resourceOutputsEvent(op deploy.StepOp, step deploy.Step, planning bool, debug bool) {
+func (e *eventEmitter) resourceOutputsEvent(op deploy.StepOp, step deploy.Step, planning bool, debug bool,
+	start time.Time) {
+
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- NewEvent(ResourceOutputsEvent, ResourceOutputsEventPayload{
-		Metadata: makeStepEventMetadata(op, step, debug),
-		Planning: planning,
-		Debug:    debug,
+		Metadata:  makeStepEventMetadata(op, step, debug),
+		Planning:  planning,
+		Debug:     debug,
+		StartTime: start,
+		EndTime:   time.Now(),
 	})
 }
 
 func (e *eventEmitter) resourcePreEvent(
-	step deploy.Step, planning bool, debug bool) {
+	step deploy.Step, planning bool, debug bool, start time.Time) {
 
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- NewEvent(ResourcePreEvent, ResourcePreEventPayload{
-		Metadata: makeStepEventMetadata(step.Op(), step, debug),
-		Planning: planning,
-		Debug:    debug,
+		Metadata:  makeStepEventMetadata(step.Op(), step, debug),
+		Planning:  planning,
+		Debug:     debug,
+		StartTime: start,
 	})
 }
 
-func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
+func (e *eventEmitter) providerCallEvent(pkg tokens.Package, method string, urn resource.URN,
+	tok tokens.ModuleMember, start time.Time, err error) {
+
+	contract.Requiref(e != nil, "e", "!= nil")
+
+	e.ch <- NewEvent(ProviderCallEvent, ProviderCallEventPayload{
+		Provider:  pkg,
+		Method:    method,
+		URN:       urn,
+		Token:     tok,
+		StartTime: start,
+		EndTime:   time.Now(),
+		Failed:    err != nil,
+	})
+}
+
+func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map, snap *deploy.Snapshot) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	configStringMap := make(map[string]string, len(cfg))
@@ -514,9 +617,34 @@ func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
 	e.ch <- NewEvent(PreludeEvent, PreludeEventPayload{
 		IsPreview: isPreview,
 		Config:    configStringMap,
+		History:   makeResourceHistory(snap),
 	})
 }
 
+// makeResourceHistory summarizes the step durations and dependencies of the resources in the given snapshot.
+func makeResourceHistory(snap *deploy.Snapshot) map[resource.URN]ResourceHistory {
+	if snap == nil || len(snap.Resources) == 0 {
+		return nil
+	}
+
+	history := make(map[resource.URN]ResourceHistory, len(snap.Resources))
+	for _, res := range snap.Resources {
+		if res.Delete {
+			continue
+		}
+
+		deps := append([]resource.URN(nil), res.Dependencies...)
+		if res.Parent != "" {
+			deps = append(deps, res.Parent)
+		}
+		if ref, err := providers.ParseReference(res.Provider); err == nil {
+			deps = append(deps, ref.URN())
+		}
+		history[res.URN] = ResourceHistory{StepDuration: res.StepDuration, Dependencies: deps}
+	}
+	return history
+}
+
 func (e *eventEmitter) summaryEvent(preview, maybeCorrupt bool, duration time.Duration, resourceChanges ResourceChanges,
 	policyPacks map[string]string) {
 
@@ -531,7 +659,8 @@ func (e *eventEmitter) summaryEvent(preview, maybeCorrupt bool, duration time.Du
 	})
 }
 
-func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDiagnostic) {
+func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDiagnostic,
+	waiver *deploy.PolicyWaiverMatch) {
 
 	contract.Requiref(e != nil, "e", "!= nil")
 
@@ -540,13 +669,16 @@ func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDi
 	switch d.EnforcementLevel {
 	case apitype.Mandatory:
 		prefix.WriteString(colors.SpecError)
-	case apitype.Advisory:
+	case apitype.Advisory, apitype.Remediate:
 		prefix.WriteString(colors.SpecWarning)
 	default:
 		contract.Failf("Unrecognized diagnostic severity: %v", d)
 	}
 
 	prefix.WriteString(string(d.EnforcementLevel))
+	if waiver != nil && !waiver.Expired {
+		prefix.WriteString(" (waived)")
+	}
 	prefix.WriteString(": ")
 	prefix.WriteString(colors.Reset)
 
@@ -556,6 +688,22 @@ func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDi
 
 	buffer.WriteString(d.Message)
 
+	// Followed by the waiver that matched the violation, if any.
+	var violationWaiver *PolicyViolationWaiver
+	if waiver != nil {
+		violationWaiver = &PolicyViolationWaiver{
+			Reason:  waiver.Waiver.Reason,
+			Expires: waiver.Waiver.Expires,
+			Expired: waiver.Expired,
+		}
+		expires := FormatPolicyWaiverExpiry(waiver.Waiver.Expires)
+		if waiver.Expired {
+			buffer.WriteString("\nWaiver expired on " + expires + ": " + waiver.Waiver.Reason)
+		} else {
+			buffer.WriteString("\nWaived until " + expires + ": " + waiver.Waiver.Reason)
+		}
+	}
+
 	buffer.WriteString(colors.Reset)
 	buffer.WriteRune('\n')
 
@@ -568,6 +716,23 @@ func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDi
 		PolicyPackVersion: d.PolicyPackVersion,
 		EnforcementLevel:  d.EnforcementLevel,
 		Prefix:            logging.FilterString(prefix.String()),
+		Waiver:            violationWaiver,
+	})
+}
+
+func (e *eventEmitter) policyRemediationEvent(urn resource.URN, t plugin.Remediation,
+	before resource.PropertyMap, after resource.PropertyMap, debug bool) {
+
+	contract.Requiref(e != nil, "e", "!= nil")
+
+	e.ch <- NewEvent(PolicyRemediationEvent, PolicyRemediationEventPayload{
+		ResourceURN:       urn,
+		Color:             colors.Raw,
+		PolicyName:        t.PolicyName,
+		PolicyPackName:    t.PolicyPackName,
+		PolicyPackVersion: t.PolicyPackVersion,
+		Before:            filterPropertyMap(before, debug),
+		After:             filterPropertyMap(after, debug),
 	})
 }
 
@@ -0,0 +1,291 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycletest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/policy"
+)
+
+// analyzerHost is a plugin host that runs the given analyzers as if they had been loaded from policy packs.
+type analyzerHost struct {
+	plugin.Host
+
+	analyzers []plugin.Analyzer
+}
+
+func (host *analyzerHost) ListAnalyzers() []plugin.Analyzer {
+	return host.analyzers
+}
+
+func TestPolicyRemediation(t *testing.T) {
+	var checked []resource.PropertyMap
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CheckF: func(urn resource.URN,
+					olds, news resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+					checked = append(checked, news)
+					return news, nil, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: resource.PropertyMap{"foo": resource.NewStringProperty("bar")},
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+
+	analyzer, err := policy.NewAnalyzer(policy.PolicyPack{
+		Name:    "pack",
+		Version: "1.0.0",
+		Policies: []policy.Policy{
+			policy.ResourceRemediationPolicy{
+				Name: "tag",
+				RemediateResource: func(args policy.ResourceValidationArgs) (resource.PropertyMap, error) {
+					if args.Type != "pkgA:m:typA" {
+						return nil, nil
+					}
+					args.Properties["tag"] = resource.NewStringProperty("owner")
+					return args.Properties, nil
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	host := &analyzerHost{
+		Host:      deploytest.NewPluginHost(nil, nil, program, loaders...),
+		analyzers: []plugin.Analyzer{analyzer},
+	}
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Steps: []TestStep{{
+			Op: Update,
+			Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+				evts []Event, res result.Result) result.Result {
+
+				// The remediation is reported with the inputs before and after it was applied.
+				var remediations []PolicyRemediationEventPayload
+				for _, evt := range evts {
+					if evt.Type == PolicyRemediationEvent {
+						remediations = append(remediations, evt.Payload().(PolicyRemediationEventPayload))
+					}
+				}
+				require.Len(t, remediations, 1)
+				assert.Equal(t, "tag", remediations[0].PolicyName)
+				assert.Equal(t, "pack", remediations[0].PolicyPackName)
+				assert.Equal(t, resource.PropertyMap{"foo": resource.NewStringProperty("bar")}, remediations[0].Before)
+				assert.Equal(t, resource.PropertyMap{
+					"foo": resource.NewStringProperty("bar"),
+					"tag": resource.NewStringProperty("owner"),
+				}, remediations[0].After)
+
+				// The provider checks, and the state records, the remediated inputs.
+				require.NotEmpty(t, checked)
+				assert.Equal(t, "owner", checked[len(checked)-1]["tag"].StringValue())
+				for _, entry := range entries {
+					if entry.Step.URN().Type() == "pkgA:m:typA" {
+						assert.Equal(t, "owner", entry.Step.New().Inputs["tag"].StringValue())
+					}
+				}
+				return res
+			},
+		}},
+	}
+	p.Run(t, nil)
+}
+
+// countingAnalyzer counts the calls made to an analyzer.
+type countingAnalyzer struct {
+	plugin.Analyzer
+
+	infos, remediations int
+}
+
+func (a *countingAnalyzer) GetAnalyzerInfo() (plugin.AnalyzerInfo, error) {
+	a.infos++
+	return a.Analyzer.GetAnalyzerInfo()
+}
+
+func (a *countingAnalyzer) Remediate(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+	a.remediations++
+	return a.Analyzer.Remediate(r)
+}
+
+func TestPolicyRemediationSkipsPacksWithoutRemediations(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		for _, name := range []string{"resA", "resB"} {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", name, true)
+			assert.NoError(t, err)
+		}
+		return nil
+	})
+
+	inner, err := policy.NewAnalyzer(policy.PolicyPack{
+		Name:    "pack",
+		Version: "1.0.0",
+		Policies: []policy.Policy{
+			policy.ResourceValidationPolicy{
+				Name:             "allowed",
+				EnforcementLevel: apitype.Advisory,
+				ValidateResource: func(args policy.ResourceValidationArgs, report policy.ReportViolation) error {
+					return nil
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	analyzer := &countingAnalyzer{Analyzer: inner}
+
+	host := &analyzerHost{
+		Host:      deploytest.NewPluginHost(nil, nil, program, loaders...),
+		analyzers: []plugin.Analyzer{analyzer},
+	}
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Steps:   []TestStep{{Op: Update}},
+	}
+	p.Run(t, nil)
+
+	// The pack has no remediation policies, so it is never asked to remediate. Its policies are fetched once by each of
+	// the plan's preview and update, rather than once per resource.
+	assert.Equal(t, 2, analyzer.infos)
+	assert.Equal(t, 0, analyzer.remediations)
+}
+
+func TestPolicyWaivers(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		return nil
+	})
+
+	analyzer, err := policy.NewAnalyzer(policy.PolicyPack{
+		Name:    "pack",
+		Version: "1.0.0",
+		Policies: []policy.Policy{
+			policy.ResourceValidationPolicy{
+				Name:             "forbidden",
+				Description:      "Resources of this type are forbidden.",
+				EnforcementLevel: apitype.Mandatory,
+				ValidateResource: func(args policy.ResourceValidationArgs, report policy.ReportViolation) error {
+					if args.Type == "pkgA:m:typA" {
+						report("")
+					}
+					return nil
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	violations := func(evts []Event) []PolicyViolationEventPayload {
+		var result []PolicyViolationEventPayload
+		for _, evt := range evts {
+			if evt.Type == PolicyViolationEvent {
+				result = append(result, evt.Payload().(PolicyViolationEventPayload))
+			}
+		}
+		return result
+	}
+
+	waiver := deploy.PolicyWaiver{
+		PolicyPack: "pack",
+		Policy:     "forbidden",
+		Pattern:    "urn:pulumi:test::test::pkgA:m:typA::res*",
+		Reason:     "Needed until the migration completes.",
+		Expires:    time.Now().Add(time.Hour),
+	}
+
+	host := &analyzerHost{
+		Host:      deploytest.NewPluginHost(nil, nil, program, loaders...),
+		analyzers: []plugin.Analyzer{analyzer},
+	}
+
+	// A waiver that has not expired downgrades the violation to advisory, so the update succeeds.
+	p := &TestPlan{
+		Options:       UpdateOptions{Host: host},
+		PolicyWaivers: []deploy.PolicyWaiver{waiver},
+		Steps: []TestStep{{
+			Op: Update,
+			Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+				evts []Event, res result.Result) result.Result {
+
+				vs := violations(evts)
+				require.Len(t, vs, 1)
+				assert.Equal(t, apitype.Advisory, vs[0].EnforcementLevel)
+				require.NotNil(t, vs[0].Waiver)
+				assert.False(t, vs[0].Waiver.Expired)
+				assert.Equal(t, waiver.Reason, vs[0].Waiver.Reason)
+				assert.Contains(t, vs[0].Prefix, "(waived)")
+				assert.Contains(t, vs[0].Message, "Waived until")
+				return res
+			},
+		}},
+	}
+	p.Run(t, nil)
+
+	// Once the waiver expires, the violation fails the update again.
+	waiver.Expires = time.Now().Add(-time.Hour)
+	p.PolicyWaivers = []deploy.PolicyWaiver{waiver}
+	p.Steps = []TestStep{{
+		Op:            Update,
+		ExpectFailure: true,
+		Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+			evts []Event, res result.Result) result.Result {
+
+			vs := violations(evts)
+			require.Len(t, vs, 1)
+			assert.Equal(t, apitype.Mandatory, vs[0].EnforcementLevel)
+			require.NotNil(t, vs[0].Waiver)
+			assert.True(t, vs[0].Waiver.Expired)
+			assert.Contains(t, vs[0].Message, "Waiver expired on")
+			return res
+		},
+	}}
+	p.Run(t, nil)
+}
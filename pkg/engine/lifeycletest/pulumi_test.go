@@ -24,6 +24,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
@@ -2580,3 +2581,957 @@ func TestComponentDeleteDependencies(t *testing.T) {
 	}
 	p.Run(t, nil)
 }
+
+func TestRetainOnDelete(t *testing.T) {
+	idCounter := 0
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap, ignoreChanges []string) (plugin.DiffResult, error) {
+					if !olds["foo"].DeepEquals(news["foo"]) {
+						// If foo changes do a replace, we use this to check we don't delete on replace
+						return plugin.DiffResult{
+							Changes:     plugin.DiffSome,
+							ReplaceKeys: []resource.PropertyKey{"foo"},
+						}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					resourceID := resource.ID(fmt.Sprintf("created-id-%d", idCounter))
+					idCounter = idCounter + 1
+					return resourceID, news, resource.StatusOK, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+					assert.Fail(t, "Delete was called")
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	ins := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"foo": "bar",
+	})
+
+	createResource := true
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+
+		if createResource {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+				Inputs:         ins,
+				RetainOnDelete: true,
+			})
+			assert.NoError(t, err)
+		}
+
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+
+	project := p.GetProject()
+
+	// Run an update to create the resource
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.NotNil(t, snap)
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, "created-id-0", snap.Resources[1].ID.String())
+	assert.True(t, snap.Resources[1].RetainOnDelete)
+
+	// Run a new update which will cause a replace, we shouldn't see a provider delete but should get a new id
+	ins = resource.NewPropertyMapFromMap(map[string]interface{}{
+		"foo": "baz",
+	})
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.NotNil(t, snap)
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, "created-id-1", snap.Resources[1].ID.String())
+
+	// Run a new update which will cause a delete, we still shouldn't see a provider delete
+	createResource = false
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.NotNil(t, snap)
+	assert.Len(t, snap.Resources, 0)
+}
+
+func TestRetainOnDeleteSetDuringReplace(t *testing.T) {
+	idCounter := 0
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap, ignoreChanges []string) (plugin.DiffResult, error) {
+					if !olds["foo"].DeepEquals(news["foo"]) {
+						return plugin.DiffResult{
+							Changes:     plugin.DiffSome,
+							ReplaceKeys: []resource.PropertyKey{"foo"},
+						}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					resourceID := resource.ID(fmt.Sprintf("created-id-%d", idCounter))
+					idCounter = idCounter + 1
+					return resourceID, news, resource.StatusOK, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+					assert.Fail(t, "Delete was called")
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	ins := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"foo": "bar",
+	})
+	retainOnDelete := false
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs:         ins,
+			RetainOnDelete: retainOnDelete,
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+
+	project := p.GetProject()
+
+	// Run an update to create the resource without RetainOnDelete.
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 2)
+	assert.False(t, snap.Resources[1].RetainOnDelete)
+	old := snap.Resources[1]
+
+	// Set RetainOnDelete in the same update that replaces the resource. The old resource must not be deleted by the
+	// provider, and the old state must be left untouched.
+	ins = resource.NewPropertyMapFromMap(map[string]interface{}{
+		"foo": "baz",
+	})
+	retainOnDelete = true
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, "created-id-1", snap.Resources[1].ID.String())
+	assert.True(t, snap.Resources[1].RetainOnDelete)
+	assert.False(t, old.RetainOnDelete)
+}
+
+func TestDeletedWith(t *testing.T) {
+	idCounter := 0
+
+	deletes := map[resource.URN]bool{}
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					resourceID := resource.ID(fmt.Sprintf("created-id-%d", idCounter))
+					idCounter = idCounter + 1
+					return resourceID, news, resource.StatusOK, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+					deletes[urn] = true
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	createChild := true
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		urnA, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+
+		if createChild {
+			_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, deploytest.ResourceOptions{
+				DeletedWith: urnA,
+			})
+			assert.NoError(t, err)
+		}
+
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+
+	project := p.GetProject()
+	urnA := p.NewURN("pkgA:m:typA", "resA", "")
+	urnB := p.NewURN("pkgA:m:typA", "resB", "")
+
+	// Run an update to create the resources.
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 3)
+	assert.Equal(t, urnA, snap.Resources[2].DeletedWith)
+
+	// Destroy the stack. Only resA should be deleted by the provider.
+	destroySnap, res := TestOp(Destroy).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, destroySnap.Resources, 0)
+	assert.True(t, deletes[urnA])
+	assert.False(t, deletes[urnB])
+
+	// Removing only resB from the program must still delete it, as resA is not being deleted.
+	deletes = map[resource.URN]bool{}
+	createChild = false
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 2)
+	assert.False(t, deletes[urnA])
+	assert.True(t, deletes[urnB])
+}
+
+func TestContinueOnError(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					if urn.Name() == "resA" {
+						return "", nil, resource.StatusOK, errors.New("oh no")
+					}
+					return resource.ID("created-" + urn.Name()), news, resource.StatusOK, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+					if urn.Name() == "resC" {
+						return resource.StatusOK, errors.New("oh no")
+					}
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	register := true
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		if !register {
+			return nil
+		}
+
+		// The failure of resA must be reported to the program rather than canceling the deployment.
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.Error(t, err)
+
+		urnB, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resB", true)
+		assert.NoError(t, err)
+
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resC", true, deploytest.ResourceOptions{
+			Dependencies: []resource.URN{urnB},
+		})
+		assert.NoError(t, err)
+
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host, ContinueOnError: true},
+	}
+
+	project := p.GetProject()
+	urnB := p.NewURN("pkgA:m:typA", "resB", "")
+	urnC := p.NewURN("pkgA:m:typA", "resC", "")
+
+	// The update must fail, but resB and resC must still have been created.
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	assert.Len(t, snap.Resources, 3)
+	assert.Equal(t, urnB, snap.Resources[1].URN)
+	assert.Equal(t, resource.ID("created-resB"), snap.Resources[1].ID)
+	assert.Equal(t, urnC, snap.Resources[2].URN)
+
+	// Removing every resource from the program must fail to delete resC. Because resC depends on resB, the delete of
+	// resB must be skipped, while the default provider, which both depend on, must also be left in place.
+	register = false
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	assert.Len(t, snap.Resources, 3)
+	assert.Equal(t, urnB, snap.Resources[1].URN)
+	assert.Equal(t, urnC, snap.Resources[2].URN)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	attempts := map[string]int{}
+	failures := map[string]int{}
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					name := string(urn.Name())
+					attempts[name]++
+					if attempts[name] <= failures[name] {
+						switch name {
+						case "resC":
+							return "", nil, resource.StatusOK, errors.New("boom")
+						case "resD":
+							return "", nil, resource.StatusUnknown, errors.New("throttled")
+						default:
+							return "", nil, resource.StatusOK, errors.New("throttled")
+						}
+					}
+					return resource.ID("created-" + name), news, resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	var names []string
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		for _, name := range names {
+			var opts deploytest.ResourceOptions
+			typ := tokens.Type("pkgA:m:typA")
+			switch name {
+			case "resA":
+				opts.RetryPolicy = &resource.RetryPolicy{MaxAttempts: 3}
+			case "resB":
+				typ = "pkgA:m:typB"
+			}
+			_, _, _, err := monitor.RegisterResource(typ, name, true, opts)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+
+	project := p.GetProject()
+	project.Options = &workspace.ProjectOptions{
+		Retry: &workspace.ProjectRetryOptions{
+			ProjectRetryPolicy: workspace.ProjectRetryPolicy{MaxAttempts: 2, Errors: []string{"throttl"}},
+			Types: map[string]workspace.ProjectRetryPolicy{
+				"pkgA:m:typB": {MaxAttempts: 3, Backoff: "1ms"},
+			},
+		},
+	}
+
+	// resA is retried by its own policy and resB by the policy for its type.
+	names, failures = []string{"resA", "resB"}, map[string]int{"resA": 2, "resB": 2}
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 3)
+	assert.Equal(t, 3, attempts["resA"])
+	assert.Equal(t, 3, attempts["resB"])
+
+	// resC fails with an error that the project's default policy does not consider retryable.
+	names, failures = []string{"resC"}, map[string]int{"resC": 1}
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	assert.Equal(t, 1, attempts["resC"])
+
+	// resD fails with a retryable error, but the provider cannot tell whether the resource was created.
+	names, failures = []string{"resD"}, map[string]int{"resD": 1}
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	assert.Equal(t, 1, attempts["resD"])
+}
+
+func TestConcurrencyLimits(t *testing.T) {
+	// tracker records the maximum number of concurrent operations for each key.
+	var lock sync.Mutex
+	active, maxActive := map[string]int{}, map[string]int{}
+	track := func(key string) {
+		lock.Lock()
+		active[key]++
+		if active[key] > maxActive[key] {
+			maxActive[key] = active[key]
+		}
+		lock.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		lock.Lock()
+		active[key]--
+		lock.Unlock()
+	}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					track(string(urn.Type()))
+					return resource.ID("created-" + urn.Name()), news, resource.StatusOK, nil
+				},
+				InvokeF: func(tok tokens.ModuleMember,
+					inputs resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+					track(string(tok))
+					return resource.PropertyMap{}, nil, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	const count = 6
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		provURN, provID, _, err := monitor.RegisterResource("pulumi:providers:pkgA", "provA", true,
+			deploytest.ResourceOptions{MaxConcurrency: 2})
+		assert.NoError(t, err)
+		provRef := fmt.Sprintf("%v::%v", provURN, provID)
+
+		var wg sync.WaitGroup
+		for i := 0; i < count; i++ {
+			wg.Add(3)
+			go func(i int) {
+				defer wg.Done()
+				_, _, _, err := monitor.RegisterResource("pkgA:m:typA", fmt.Sprintf("resA%d", i), true,
+					deploytest.ResourceOptions{Provider: provRef})
+				assert.NoError(t, err)
+			}(i)
+			go func(i int) {
+				defer wg.Done()
+				_, _, _, err := monitor.RegisterResource("pkgA:m:typB", fmt.Sprintf("resB%d", i), true)
+				assert.NoError(t, err)
+			}(i)
+			go func() {
+				defer wg.Done()
+				_, _, err := monitor.Invoke("pkgA:m:invoke", resource.PropertyMap{}, "", "")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host, Parallel: 3 * count},
+	}
+
+	project := p.GetProject()
+	project.Options = &workspace.ProjectOptions{
+		Concurrency: &workspace.ProjectConcurrencyOptions{
+			Providers: map[string]int{"pkgA": 3},
+			Types:     map[string]int{"pkgA:m:typB": 1},
+		},
+	}
+
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 2+2*count)
+
+	// The explicit provider limits its resources to two concurrent operations, the type limit serializes typB, and
+	// the package limit applies to invokes against the default provider.
+	assert.LessOrEqual(t, maxActive["pkgA:m:typA"], 2)
+	assert.Equal(t, 1, maxActive["pkgA:m:typB"])
+	assert.LessOrEqual(t, maxActive["pkgA:m:invoke"], 3)
+}
+
+func TestProfileProviderCalls(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					time.Sleep(10 * time.Millisecond)
+					return resource.ID("created-" + urn.Name()), news, resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		_, _, err = monitor.Invoke("pkgA:m:invoke", resource.PropertyMap{}, "", "")
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host, ProfileProviderCalls: true},
+	}
+	project := p.GetProject()
+
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+	validate := func(project workspace.Project, target deploy.Target, entries JournalEntries,
+		events []Event, res result.Result) result.Result {
+
+		methods := map[string]bool{}
+		for _, e := range events {
+			switch e.Type {
+			case ProviderCallEvent:
+				payload := e.Payload().(ProviderCallEventPayload)
+				assert.Equal(t, tokens.Package("pkgA"), payload.Provider)
+				assert.False(t, payload.EndTime.Before(payload.StartTime))
+				methods[payload.Method] = true
+				if payload.Method == "Create" {
+					assert.Equal(t, resURN, payload.URN)
+					assert.True(t, payload.EndTime.Sub(payload.StartTime) >= 10*time.Millisecond)
+				}
+				if payload.Method == "Invoke" {
+					assert.Equal(t, tokens.ModuleMember("pkgA:m:invoke"), payload.Token)
+				}
+			case ResourcePreEvent:
+				assert.False(t, e.Payload().(ResourcePreEventPayload).StartTime.IsZero())
+			case ResourceOutputsEvent:
+				payload := e.Payload().(ResourceOutputsEventPayload)
+				assert.False(t, payload.StartTime.IsZero())
+				if payload.Metadata.URN == resURN {
+					assert.True(t, payload.EndTime.Sub(payload.StartTime) >= 10*time.Millisecond)
+				}
+			}
+		}
+		for _, method := range []string{"Configure", "Check", "Create", "Invoke"} {
+			assert.True(t, methods[method], "missing provider call event for %v", method)
+		}
+		return res
+	}
+
+	_, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, validate)
+	assert.Nil(t, res)
+}
+
+func TestStepDurationHistory(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					time.Sleep(10 * time.Millisecond)
+					return resource.ID("created-" + urn.Name()), news, resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	project := p.GetProject()
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+
+	// The first update records how long the resource took to create.
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	var created time.Duration
+	for _, r := range snap.Resources {
+		if r.URN == resURN {
+			created = r.StepDuration
+		}
+	}
+	assert.True(t, created >= 10*time.Millisecond)
+
+	// The second update reports that duration in its prelude and carries it forward across the same step.
+	validate := func(project workspace.Project, target deploy.Target, entries JournalEntries,
+		events []Event, res result.Result) result.Result {
+
+		for _, e := range events {
+			if e.Type == PreludeEvent {
+				history := e.Payload().(PreludeEventPayload).History
+				assert.Equal(t, created, history[resURN].StepDuration)
+				assert.Len(t, history[resURN].Dependencies, 1)
+			}
+		}
+		return res
+	}
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, validate)
+	assert.Nil(t, res)
+	for _, r := range snap.Resources {
+		if r.URN == resURN {
+			assert.Equal(t, created, r.StepDuration)
+		}
+	}
+}
+
+func TestChangeGuards(t *testing.T) {
+	creates, deletes := 0, 0
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap, ignoreChanges []string) (plugin.DiffResult, error) {
+
+					if !olds["A"].DeepEquals(news["A"]) {
+						return plugin.DiffResult{ReplaceKeys: []resource.PropertyKey{"A"}}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					if !preview {
+						creates++
+					}
+					return resource.ID(fmt.Sprintf("created-%s-%d", urn.Name(), creates)), news, resource.StatusOK, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+					deletes++
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	inputs := resource.PropertyMap{"A": resource.NewStringProperty("foo")}
+	registerB, refuseReplaceOption := true, false
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs:        inputs,
+			RefuseReplace: refuseReplaceOption,
+		})
+		if err != nil {
+			return err
+		}
+		if registerB {
+			_, _, _, err = monitor.RegisterResource("pkgA:m:typB", "resB", true)
+		}
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	project := p.GetProject()
+
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Equal(t, 2, creates)
+
+	// Changing resA's inputs plans a replacement, which a type pattern must refuse in both preview and update.
+	inputs = resource.PropertyMap{"A": resource.NewStringProperty("bar")}
+	guarded := p.Options
+	guarded.RefuseReplace = []string{"pkgA:m:*A"}
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), guarded, true, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), guarded, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	assert.Equal(t, 2, creates)
+	assert.Equal(t, 0, deletes)
+
+	// Deletion guards refuse replacements too, as a replacement deletes the original resource.
+	guarded = p.Options
+	guarded.RefuseDelete = []string{string(p.NewURN("pkgA:m:typA", "resA", ""))}
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), guarded, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	assert.Equal(t, 2, creates)
+
+	// So does the resource's own refuseReplace option.
+	refuseReplaceOption = true
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	assert.Equal(t, 2, creates)
+
+	// Removing resB from the program must be refused by a URN pattern for it, and allowed by one that does not match.
+	inputs, registerB, refuseReplaceOption = resource.PropertyMap{"A": resource.NewStringProperty("foo")}, false, false
+	guarded = p.Options
+	guarded.RefuseDelete = []string{"urn:*::resB"}
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), guarded, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	assert.Equal(t, 0, deletes)
+
+	guarded.RefuseDelete = []string{"urn:*::resC"}
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), guarded, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Equal(t, 1, deletes)
+	assert.Len(t, snap.Resources, 2)
+}
+
+func TestChangeGuardsDeleteBeforeReplaceDependent(t *testing.T) {
+	deletes := 0
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap, ignoreChanges []string) (plugin.DiffResult, error) {
+
+					if !olds["A"].DeepEquals(news["A"]) {
+						return plugin.DiffResult{
+							ReplaceKeys:         []resource.PropertyKey{"A"},
+							DeleteBeforeReplace: true,
+						}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+					deletes++
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	inputsA := resource.PropertyMap{"A": resource.NewStringProperty("foo")}
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		urnA, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: inputsA,
+		})
+		if err != nil {
+			return err
+		}
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, deploytest.ResourceOptions{
+			Inputs:       resource.PropertyMap{"A": resource.NewStringProperty("bar")},
+			Dependencies: []resource.URN{urnA},
+			PropertyDeps: map[resource.PropertyKey][]resource.URN{"A": {urnA}},
+		})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	project := p.GetProject()
+
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	// Replacing resA deletes resB before either resource is replaced. A deletion guard for resB must refuse this,
+	// even though resA itself is not guarded.
+	inputsA = resource.PropertyMap{"A": resource.NewStringProperty("baz")}
+	guarded := p.Options
+	guarded.RefuseDelete = []string{string(p.NewURN("pkgA:m:typA", "resB", ""))}
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), guarded, true, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), guarded, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	assert.Equal(t, 0, deletes)
+}
+
+func TestReplaceReasons(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap, ignoreChanges []string) (plugin.DiffResult, error) {
+
+					if !olds["A"].DeepEquals(news["A"]) {
+						return plugin.DiffResult{
+							ReplaceKeys:         []resource.PropertyKey{"A"},
+							DeleteBeforeReplace: true,
+						}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	inputsA := resource.PropertyMap{"A": resource.NewStringProperty("foo")}
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		urnA, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: inputsA,
+		})
+		if err != nil {
+			return err
+		}
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, deploytest.ResourceOptions{
+			Inputs:       resource.PropertyMap{"A": resource.NewStringProperty("bar")},
+			Dependencies: []resource.URN{urnA},
+			PropertyDeps: map[resource.PropertyKey][]resource.URN{"A": {urnA}},
+		})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	project := p.GetProject()
+	urnA := p.NewURN("pkgA:m:typA", "resA", "")
+	urnB := p.NewURN("pkgA:m:typA", "resB", "")
+
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	// Collect the replacement reasons attached to each resource's step events.
+	reasonsFor := func(events []Event) map[resource.URN][]deploy.ReplaceReason {
+		reasons := make(map[resource.URN][]deploy.ReplaceReason)
+		for _, e := range events {
+			if e.Type != ResourcePreEvent {
+				continue
+			}
+			md := e.Payload().(ResourcePreEventPayload).Metadata
+			if len(md.ReplaceReasons) > 0 {
+				reasons[md.URN] = md.ReplaceReasons
+			}
+		}
+		return reasons
+	}
+
+	// Changing resA's inputs replaces it because of its provider's diff, and replaces resB, which must be deleted
+	// first because it consumes resA's property.
+	inputsA = resource.PropertyMap{"A": resource.NewStringProperty("baz")}
+	validate := func(project workspace.Project, target deploy.Target, entries JournalEntries,
+		events []Event, res result.Result) result.Result {
+
+		reasons := reasonsFor(events)
+		providerDiff := []deploy.ReplaceReason{{
+			Kind:       deploy.ReplaceReasonProviderDiff,
+			Properties: []resource.PropertyKey{"A"},
+		}}
+		assert.Equal(t, providerDiff, reasons[urnA])
+		assert.Equal(t, []deploy.ReplaceReason{{
+			Kind:            deploy.ReplaceReasonDependency,
+			Properties:      []resource.PropertyKey{"A"},
+			Upstream:        urnA,
+			UpstreamReasons: providerDiff,
+		}}, reasons[urnB])
+		return res
+	}
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, true, p.BackendClient, validate)
+	assert.Nil(t, res)
+
+	// Targeting a resource for replacement records that as the reason.
+	inputsA = resource.PropertyMap{"A": resource.NewStringProperty("foo")}
+	targeted := p.Options
+	targeted.ReplaceTargets = []resource.URN{urnB}
+	validate = func(project workspace.Project, target deploy.Target, entries JournalEntries,
+		events []Event, res result.Result) result.Result {
+
+		reasons := reasonsFor(events)
+		assert.Empty(t, reasons[urnA])
+		assert.Equal(t, []deploy.ReplaceReason{{Kind: deploy.ReplaceReasonTargeted}}, reasons[urnB])
+		return res
+	}
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), targeted, true, p.BackendClient, validate)
+	assert.Nil(t, res)
+}
+
+func TestStepApproval(t *testing.T) {
+	creates, updates, deletes := 0, 0, 0
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap, ignoreChanges []string) (plugin.DiffResult, error) {
+
+					if olds["A"].DeepEquals(news["A"]) {
+						return plugin.DiffResult{}, nil
+					}
+					if urn.Type() == "pkgA:m:typB" {
+						return plugin.DiffResult{ReplaceKeys: []resource.PropertyKey{"A"}}, nil
+					}
+					return plugin.DiffResult{Changes: plugin.DiffSome}, nil
+				},
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					creates++
+					return resource.ID(fmt.Sprintf("created-%s-%d", urn.Name(), creates)), news, resource.StatusOK, nil
+				},
+				UpdateF: func(urn resource.URN, id resource.ID, olds, news resource.PropertyMap, timeout float64,
+					ignoreChanges []string, preview bool) (resource.PropertyMap, resource.Status, error) {
+					updates++
+					return news, resource.StatusOK, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+					deletes++
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	inputs, registerC := resource.PropertyMap{"A": resource.NewStringProperty("foo")}, true
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: inputs,
+		})
+		if err != nil {
+			return err
+		}
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typB", "resB", true, deploytest.ResourceOptions{
+			Inputs: inputs,
+		})
+		if err != nil {
+			return err
+		}
+		if registerC {
+			_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resC", true)
+		}
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	project := p.GetProject()
+
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	creates = 0
+
+	// Update resA, replace resB and delete resC, asking about each of them.
+	inputs, registerC = resource.PropertyMap{"A": resource.NewStringProperty("bar")}, false
+	var asked []deploy.StepOp
+	approval := StepSkip
+	opts := p.Options
+	opts.ApproveStep = func(step StepEventMetadata) (StepApproval, error) {
+		asked = append(asked, step.Op)
+		return approval, nil
+	}
+
+	// Skipping every step leaves the stack unchanged, including the old inputs of the skipped resources.
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), opts, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Equal(t, []deploy.StepOp{deploy.OpUpdate, deploy.OpCreateReplacement, deploy.OpDelete}, asked)
+	assert.Equal(t, []int{0, 0, 0}, []int{creates, updates, deletes})
+	assert.Len(t, snap.Resources, 4)
+	for _, r := range snap.Resources[1:] {
+		assert.False(t, r.Delete)
+		if r.URN.Name() != "resC" {
+			assert.Equal(t, "foo", r.Inputs["A"].StringValue(), r.URN)
+		}
+	}
+
+	// Aborting fails the update at the first step.
+	asked, approval = nil, StepAbort
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), opts, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	assert.Equal(t, []deploy.StepOp{deploy.OpUpdate}, asked)
+	assert.Equal(t, []int{0, 0, 0}, []int{creates, updates, deletes})
+
+	// Approving every step applies them, asking only once about the replacement.
+	asked, approval = nil, StepApprove
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), opts, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Equal(t, []deploy.StepOp{deploy.OpUpdate, deploy.OpCreateReplacement, deploy.OpDelete}, asked)
+	assert.Equal(t, []int{1, 1, 2}, []int{creates, updates, deletes})
+	assert.Len(t, snap.Resources, 3)
+}
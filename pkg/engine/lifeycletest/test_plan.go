@@ -137,6 +137,7 @@ type TestPlan struct {
 	Config         config.Map
 	Decrypter      config.Decrypter
 	BackendClient  deploy.BackendClient
+	PolicyWaivers  []deploy.PolicyWaiver
 	Options        UpdateOptions
 	Steps          []TestStep
 }
@@ -195,7 +196,8 @@ func (p *TestPlan) GetTarget(t *testing.T, snapshot *deploy.Snapshot) deploy.Tar
 		// note: it's really important that the preview and update operate on different snapshots.  the engine can and
 		// does mutate the snapshot in-place, even in previews, and sharing a snapshot between preview and update can
 		// cause state changes from the preview to persist even when doing an update.
-		Snapshot: CloneSnapshot(t, snapshot),
+		Snapshot:      CloneSnapshot(t, snapshot),
+		PolicyWaivers: p.PolicyWaivers,
 	}
 }
 
@@ -19,6 +19,7 @@ import (
 	"os"
 	"sort"
 
+	"github.com/hashicorp/go-multierror"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
@@ -130,15 +131,76 @@ func gatherPluginsFromSnapshot(plugctx *plugin.Context, target *deploy.Target) (
 	return set, nil
 }
 
+// GetSnapshotPlugins returns the plugins required to operate on the resources in the given snapshot (avoids having to
+// export pluginSet).
+func GetSnapshotPlugins(snap *deploy.Snapshot) ([]workspace.PluginInfo, error) {
+	set, err := gatherPluginsFromSnapshot(nil, &deploy.Target{Snapshot: snap})
+	if err != nil {
+		return nil, err
+	}
+	return set.Values(), nil
+}
+
+// loadPluginLock loads the plugin lock file for the project rooted at the given directory, if there is one. If the
+// project has no lock file, nil is returned.
+func loadPluginLock(root string) (*workspace.PluginLock, error) {
+	if root == "" {
+		return nil, nil
+	}
+	lock, err := workspace.LoadPluginLock(workspace.PluginLockPath(root))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return lock, nil
+}
+
+// pinPlugins returns the plugins in the given set at the versions that the project's plugin lock pins them to, so that
+// exactly those versions are installed and loaded. It returns an error if any plugin is not pinned. Language plugins
+// ship with the CLI, so they are not pinned. If the project has no plugin lock, the set is returned as is.
+func pinPlugins(plugins pluginSet, lock *workspace.PluginLock) (pluginSet, error) {
+	if lock == nil {
+		return plugins, nil
+	}
+
+	var result error
+	pinned := newPluginSet()
+	for _, plug := range plugins.Values() {
+		if plug.Kind != workspace.LanguagePlugin {
+			var err error
+			if plug, err = lock.Pin(plug); err != nil {
+				result = multierror.Append(result, err)
+				continue
+			}
+		}
+		pinned.Add(plug)
+	}
+	return pinned, result
+}
+
 // ensurePluginsAreInstalled inspects all plugins in the plugin set and, if any plugins are not currently installed,
 // uses the given backend client to install them. Installations are processed in parallel, though
-// ensurePluginsAreInstalled does not return until all installations are completed.
-func ensurePluginsAreInstalled(plugins pluginSet) error {
+// ensurePluginsAreInstalled does not return until all installations are completed. If the project has a plugin lock,
+// each plugin must be pinned by it, installed or not, and is verified against its checksum when installed.
+func ensurePluginsAreInstalled(plugins pluginSet, lock *workspace.PluginLock) error {
 	logging.V(preparePluginLog).Infof("ensurePluginsAreInstalled(): beginning")
 	var installTasks errgroup.Group
 	for _, plug := range plugins.Values() {
-		_, path, err := workspace.GetPluginPath(plug.Kind, plug.Name, plug.Version)
-		if err == nil && path != "" {
+		if lock != nil && plug.Kind != workspace.LanguagePlugin {
+			if lock.Find(plug) == nil {
+				return fmt.Errorf("%s plugin %s is not pinned in %s; run `pulumi plugin lock` to update it",
+					plug.Kind, plug, workspace.PluginLockFile)
+			}
+
+			// Only the pinned version of the plugin will do, rather than any compatible version that is installed.
+			if workspace.HasPlugin(plug) {
+				logging.V(preparePluginLog).Infof(
+					"ensurePluginsAreInstalled(): plugin %s %s already installed", plug.Name, plug.Version)
+				continue
+			}
+		} else if _, path, err := workspace.GetPluginPath(plug.Kind, plug.Name, plug.Version); err == nil && path != "" {
 			logging.V(preparePluginLog).Infof(
 				"ensurePluginsAreInstalled(): plugin %s %s already installed", plug.Name, plug.Version)
 			continue
@@ -149,7 +211,7 @@ func ensurePluginsAreInstalled(plugins pluginSet) error {
 		installTasks.Go(func() error {
 			logging.V(preparePluginLog).Infof(
 				"ensurePluginsAreInstalled(): plugin %s %s not installed, doing install", info.Name, info.Version)
-			return installPlugin(info)
+			return installPlugin(info, lock)
 		})
 	}
 
@@ -164,8 +226,8 @@ func ensurePluginsAreLoaded(plugctx *plugin.Context, plugins pluginSet, kinds pl
 	return plugctx.Host.EnsurePlugins(plugins.Values(), kinds)
 }
 
-// installPlugin installs a plugin from the given backend client.
-func installPlugin(plugin workspace.PluginInfo) error {
+// installPlugin installs a plugin from the given backend client, verifying it against the plugin lock if there is one.
+func installPlugin(plugin workspace.PluginInfo, lock *workspace.PluginLock) error {
 	logging.V(preparePluginLog).Infof("installPlugin(%s, %s): beginning install", plugin.Name, plugin.Version)
 	if plugin.Kind == workspace.LanguagePlugin {
 		logging.V(preparePluginLog).Infof(
@@ -173,6 +235,16 @@ func installPlugin(plugin workspace.PluginInfo) error {
 		return nil
 	}
 
+	if lock != nil {
+		platform, err := workspace.CurrentPluginPlatform()
+		if err != nil {
+			return err
+		}
+		if plugin.Checksum, err = lock.Checksum(plugin, platform); err != nil {
+			return err
+		}
+	}
+
 	logging.V(preparePluginVerboseLog).Infof(
 		"installPlugin(%s, %s): initiating download", plugin.Name, plugin.Version)
 	stream, size, err := plugin.Download()
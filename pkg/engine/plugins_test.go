@@ -131,3 +131,77 @@ func TestDefaultProvidersSnapshotOverrides(t *testing.T) {
 	assert.NotNil(t, awsVer)
 	assert.Equal(t, "0.17.0", awsVer.String())
 }
+
+func TestInstallPluginRequiresPin(t *testing.T) {
+	t.Parallel()
+
+	// A plugin that the project's lock does not pin is never downloaded.
+	lock := &workspace.PluginLock{Plugins: []workspace.PluginLockEntry{{
+		Kind:      workspace.ResourcePlugin,
+		Name:      "aws",
+		Version:   "5.0.0",
+		Checksums: map[string]string{},
+	}}}
+	err := installPlugin(workspace.PluginInfo{
+		Name:              "aws",
+		Version:           mustMakeVersion("5.1.0"),
+		Kind:              workspace.ResourcePlugin,
+		PluginDownloadURL: "http://localhost:0",
+	}, lock)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is not pinned in Pulumi.lock")
+	}
+
+	// Language plugins are never installed, so they are not checked.
+	assert.NoError(t, installPlugin(workspace.PluginInfo{Name: "nodejs", Kind: workspace.LanguagePlugin}, lock))
+
+	// Projects without a lock file have no lock.
+	lock, err = loadPluginLock(t.TempDir())
+	assert.NoError(t, err)
+	assert.Nil(t, lock)
+}
+
+func TestPinPlugins(t *testing.T) {
+	t.Parallel()
+
+	lock := &workspace.PluginLock{Plugins: []workspace.PluginLockEntry{{
+		Kind:              workspace.ResourcePlugin,
+		Name:              "aws",
+		Version:           "5.0.0",
+		PluginDownloadURL: "https://example.com/aws",
+		Checksums:         map[string]string{},
+	}}}
+
+	// Plugins without a version are pinned to the locked version, and language plugins are left alone.
+	plugins := newPluginSet()
+	plugins.Add(workspace.PluginInfo{Name: "aws", Kind: workspace.ResourcePlugin})
+	plugins.Add(workspace.PluginInfo{Name: "nodejs", Kind: workspace.LanguagePlugin})
+	pinned, err := pinPlugins(plugins, lock)
+	assert.NoError(t, err)
+	assert.Len(t, pinned, 2)
+	for _, plug := range pinned.Values() {
+		if plug.Kind == workspace.ResourcePlugin {
+			assert.Equal(t, "5.0.0", plug.Version.String())
+			assert.Equal(t, "https://example.com/aws", plug.PluginDownloadURL)
+		} else {
+			assert.Nil(t, plug.Version)
+		}
+	}
+
+	// A plugin at a version the lock does not pin is rejected, whether or not that version is installed.
+	plugins = newPluginSet()
+	plugins.Add(workspace.PluginInfo{Name: "aws", Version: mustMakeVersion("5.1.0"), Kind: workspace.ResourcePlugin})
+	_, err = pinPlugins(plugins, lock)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is not pinned in Pulumi.lock")
+	}
+	err = ensurePluginsAreInstalled(plugins, lock)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is not pinned in Pulumi.lock")
+	}
+
+	// Without a lock, plugins are used as they are.
+	pinned, err = pinPlugins(plugins, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, plugins, pinned)
+}
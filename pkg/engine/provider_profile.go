@@ -0,0 +1,183 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"time"
+
+	"github.com/blang/semver"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// profilingHost wraps a plugin host so that every provider it loads reports the timing of its calls as
+// ProviderCallEvents.
+type profilingHost struct {
+	plugin.Host
+
+	events eventEmitter
+}
+
+func newProfilingHost(host plugin.Host, events eventEmitter) plugin.Host {
+	return &profilingHost{Host: host, events: events}
+}
+
+func (host *profilingHost) Provider(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
+	provider, err := host.Host.Provider(pkg, version)
+	if err != nil || provider == nil {
+		return provider, err
+	}
+	return &profilingProvider{Provider: provider, pkg: pkg, events: host.events}, nil
+}
+
+func (host *profilingHost) CloseProvider(provider plugin.Provider) error {
+	if p, ok := provider.(*profilingProvider); ok {
+		provider = p.Provider
+	}
+	return host.Host.CloseProvider(provider)
+}
+
+// profilingProvider wraps a provider and reports the timing of each of its resource operations, invokes and calls.
+type profilingProvider struct {
+	plugin.Provider
+
+	pkg    tokens.Package
+	events eventEmitter
+}
+
+func (p *profilingProvider) report(method string, urn resource.URN, tok tokens.ModuleMember, start time.Time,
+	err error) {
+
+	p.events.providerCallEvent(p.pkg, method, urn, tok, start, err)
+}
+
+func (p *profilingProvider) CheckConfig(urn resource.URN, olds, news resource.PropertyMap,
+	allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+	start := time.Now()
+	inputs, failures, err := p.Provider.CheckConfig(urn, olds, news, allowUnknowns)
+	p.report("CheckConfig", urn, "", start, err)
+	return inputs, failures, err
+}
+
+func (p *profilingProvider) DiffConfig(urn resource.URN, olds, news resource.PropertyMap, allowUnknowns bool,
+	ignoreChanges []string) (plugin.DiffResult, error) {
+
+	start := time.Now()
+	diff, err := p.Provider.DiffConfig(urn, olds, news, allowUnknowns, ignoreChanges)
+	p.report("DiffConfig", urn, "", start, err)
+	return diff, err
+}
+
+func (p *profilingProvider) Configure(inputs resource.PropertyMap) error {
+	start := time.Now()
+	err := p.Provider.Configure(inputs)
+	p.report("Configure", "", "", start, err)
+	return err
+}
+
+func (p *profilingProvider) Check(urn resource.URN, olds, news resource.PropertyMap,
+	allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+	start := time.Now()
+	inputs, failures, err := p.Provider.Check(urn, olds, news, allowUnknowns)
+	p.report("Check", urn, "", start, err)
+	return inputs, failures, err
+}
+
+func (p *profilingProvider) Diff(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+	news resource.PropertyMap, allowUnknowns bool, ignoreChanges []string) (plugin.DiffResult, error) {
+
+	start := time.Now()
+	diff, err := p.Provider.Diff(urn, id, olds, news, allowUnknowns, ignoreChanges)
+	p.report("Diff", urn, "", start, err)
+	return diff, err
+}
+
+func (p *profilingProvider) Create(urn resource.URN, news resource.PropertyMap, timeout float64,
+	preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+	start := time.Now()
+	id, outputs, status, err := p.Provider.Create(urn, news, timeout, preview)
+	p.report("Create", urn, "", start, err)
+	return id, outputs, status, err
+}
+
+func (p *profilingProvider) Read(urn resource.URN, id resource.ID,
+	inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error) {
+
+	start := time.Now()
+	result, status, err := p.Provider.Read(urn, id, inputs, state)
+	p.report("Read", urn, "", start, err)
+	return result, status, err
+}
+
+func (p *profilingProvider) Update(urn resource.URN, id resource.ID,
+	olds resource.PropertyMap, news resource.PropertyMap, timeout float64,
+	ignoreChanges []string, preview bool) (resource.PropertyMap, resource.Status, error) {
+
+	start := time.Now()
+	outputs, status, err := p.Provider.Update(urn, id, olds, news, timeout, ignoreChanges, preview)
+	p.report("Update", urn, "", start, err)
+	return outputs, status, err
+}
+
+func (p *profilingProvider) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	timeout float64) (resource.Status, error) {
+
+	start := time.Now()
+	status, err := p.Provider.Delete(urn, id, props, timeout)
+	p.report("Delete", urn, "", start, err)
+	return status, err
+}
+
+func (p *profilingProvider) Construct(info plugin.ConstructInfo, typ tokens.Type, name tokens.QName,
+	parent resource.URN, inputs resource.PropertyMap,
+	options plugin.ConstructOptions) (plugin.ConstructResult, error) {
+
+	start := time.Now()
+	result, err := p.Provider.Construct(info, typ, name, parent, inputs, options)
+	p.report("Construct", result.URN, "", start, err)
+	return result, err
+}
+
+func (p *profilingProvider) Invoke(tok tokens.ModuleMember,
+	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+	start := time.Now()
+	result, failures, err := p.Provider.Invoke(tok, args)
+	p.report("Invoke", "", tok, start, err)
+	return result, failures, err
+}
+
+func (p *profilingProvider) StreamInvoke(tok tokens.ModuleMember, args resource.PropertyMap,
+	onNext func(resource.PropertyMap) error) ([]plugin.CheckFailure, error) {
+
+	start := time.Now()
+	failures, err := p.Provider.StreamInvoke(tok, args, onNext)
+	p.report("StreamInvoke", "", tok, start, err)
+	return failures, err
+}
+
+func (p *profilingProvider) Call(tok tokens.ModuleMember, args resource.PropertyMap, info plugin.CallInfo,
+	options plugin.CallOptions) (plugin.CallResult, error) {
+
+	start := time.Now()
+	result, err := p.Provider.Call(tok, args, info, options)
+	p.report("Call", "", tok, start, err)
+	return result, err
+}
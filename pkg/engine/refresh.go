@@ -69,7 +69,17 @@ func newRefreshSource(client deploy.BackendClient, opts deploymentOptions, proj
 	}
 
 	// Like Update, if we're missing plugins, attempt to download the missing plugins.
-	if err := ensurePluginsAreInstalled(plugins); err != nil {
+	lock, err := loadPluginLock(plugctx.Root)
+	if err != nil {
+		return nil, err
+	}
+	if plugins, err = pinPlugins(plugins, lock); err != nil {
+		return nil, err
+	}
+	if err := ensurePluginsAreInstalled(plugins, lock); err != nil {
+		if lock != nil {
+			return nil, err
+		}
 		logging.V(7).Infof("newRefreshSource(): failed to install missing plugins: %v", err)
 	}
 
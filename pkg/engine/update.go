@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	resourceanalyzer "github.com/pulumi/pulumi/pkg/v3/resource/analyzer"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
@@ -139,6 +140,21 @@ type UpdateOptions struct {
 	// true if the engine should disable output value support.
 	DisableOutputValues bool
 
+	// true if the engine should continue executing steps that do not depend on a failed step.
+	ContinueOnError bool
+
+	// true if the engine should emit events recording the timing of every provider call.
+	ProfileProviderCalls bool
+
+	// patterns for resources that the engine must refuse to replace, in addition to any set in the project.
+	RefuseReplace []string
+
+	// patterns for resources that the engine must refuse to delete, in addition to any set in the project.
+	RefuseDelete []string
+
+	// if set, called before each update, replace or delete step executes to ask whether to apply it.
+	ApproveStep StepApprover
+
 	// true if we should report events for steps that involve default providers.
 	reportDefaultProviderSteps bool
 
@@ -146,6 +162,35 @@ type UpdateOptions struct {
 	Host plugin.Host
 }
 
+// StepApproval is a decision about whether to execute a step.
+type StepApproval int
+
+const (
+	// StepApprove executes the step.
+	StepApprove StepApproval = iota
+	// StepSkip leaves the step's resource unchanged, as if it had not been targeted by the update.
+	StepSkip
+	// StepAbort fails the step and stops the update.
+	StepAbort
+)
+
+// StepApprover decides whether to execute the step described by the given metadata. It is called at most once per
+// resource, and never concurrently; the decision also applies to the other steps that replace the same resource.
+type StepApprover func(step StepEventMetadata) (StepApproval, error)
+
+// errUpdateAborted is returned when a step approver aborts the update.
+var errUpdateAborted = errors.New("the update was aborted")
+
+// requiresApproval returns true if a step with the given operation must be approved before it executes.
+func requiresApproval(op deploy.StepOp) bool {
+	switch op {
+	case deploy.OpUpdate, deploy.OpDelete, deploy.OpReplace, deploy.OpCreateReplacement, deploy.OpDeleteReplaced:
+		return true
+	default:
+		return false
+	}
+}
+
 // ResourceChanges contains the aggregate resource changes by operation type.
 type ResourceChanges map[deploy.StepOp]int
 
@@ -229,15 +274,28 @@ func installPlugins(
 		return nil, nil, err
 	}
 
+	// If the project pins its plugins with a lock file, use exactly the pinned versions, both to install and load the
+	// plugins and to select the versions of default providers.
+	lock, err := loadPluginLock(plugctx.Root)
+	if err != nil {
+		return nil, nil, err
+	}
+	if languagePlugins, err = pinPlugins(languagePlugins, lock); err != nil {
+		return nil, nil, err
+	}
+	if snapshotPlugins, err = pinPlugins(snapshotPlugins, lock); err != nil {
+		return nil, nil, err
+	}
+
 	allPlugins := languagePlugins.Union(snapshotPlugins)
 
 	// If there are any plugins that are not available, we can attempt to install them here.
 	//
 	// Note that this is purely a best-effort thing. If we can't install missing plugins, just proceed; we'll fail later
-	// with an error message indicating exactly what plugins are missing. If `returnInstallErrors` is set, then return
-	// the error.
-	if err := ensurePluginsAreInstalled(allPlugins); err != nil {
-		if returnInstallErrors {
+	// with an error message indicating exactly what plugins are missing. If `returnInstallErrors` is set, or the
+	// project pins its plugins with a lock file, then return the error.
+	if err := ensurePluginsAreInstalled(allPlugins, lock); err != nil {
+		if returnInstallErrors || lock != nil {
 			return nil, nil, err
 		}
 		logging.V(7).Infof("newUpdateSource(): failed to install missing plugins: %v", err)
@@ -481,11 +539,15 @@ type updateActions struct {
 	Steps   int
 	Ops     map[deploy.StepOp]int
 	Seen    map[resource.URN]deploy.Step
+	Starts  map[deploy.Step]time.Time
 	MapLock sync.Mutex
 	Update  UpdateInfo
 	Opts    deploymentOptions
 
 	maybeCorrupt bool
+
+	approvalLock sync.Mutex
+	approvals    map[resource.URN]StepApproval
 }
 
 func newUpdateActions(context *Context, u UpdateInfo, opts deploymentOptions) *updateActions {
@@ -493,20 +555,55 @@ func newUpdateActions(context *Context, u UpdateInfo, opts deploymentOptions) *u
 		Context: context,
 		Ops:     make(map[deploy.StepOp]int),
 		Seen:    make(map[resource.URN]deploy.Step),
+		Starts:  make(map[deploy.Step]time.Time),
 		Update:  u,
 		Opts:    opts,
+
+		approvals: make(map[resource.URN]StepApproval),
+	}
+}
+
+// approve asks the step approver, if any, whether to execute the given step. The decision for a resource is
+// remembered, so that the user is asked only once about the several steps that replace it.
+func (acts *updateActions) approve(step deploy.Step) (StepApproval, error) {
+	if acts.Opts.ApproveStep == nil || !requiresApproval(step.Op()) {
+		return StepApprove, nil
+	}
+
+	acts.approvalLock.Lock()
+	defer acts.approvalLock.Unlock()
+
+	if approval, ok := acts.approvals[step.URN()]; ok {
+		return approval, nil
+	}
+	approval, err := acts.Opts.ApproveStep(makeStepEventMetadata(step.Op(), step, acts.Opts.Debug))
+	if err != nil {
+		return StepAbort, err
 	}
+	acts.approvals[step.URN()] = approval
+	return approval, nil
 }
 
 func (acts *updateActions) OnResourceStepPre(step deploy.Step) (interface{}, error) {
+	switch approval, err := acts.approve(step); {
+	case err != nil:
+		return nil, err
+	case approval == StepSkip:
+		return nil, deploy.ErrSkipStep
+	case approval == StepAbort:
+		return nil, errUpdateAborted
+	}
+
 	// Ensure we've marked this step as observed.
+	start := time.Now()
 	acts.MapLock.Lock()
 	acts.Seen[step.URN()] = step
+	acts.Starts[step] = start
 	acts.MapLock.Unlock()
 
 	// Skip reporting if necessary.
 	if shouldReportStep(step, acts.Opts) {
-		acts.Opts.Events.resourcePreEvent(step, false /*planning*/, acts.Opts.Debug)
+		acts.Opts.Events.resourcePreEvent(step, false /*planning*/, acts.Opts.Debug, start)
 	}
 
 	// Inform the snapshot service that we are about to perform a step.
@@ -519,6 +616,7 @@ func (acts *updateActions) OnResourceStepPost(
 
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
+	start := acts.Starts[step]
 	acts.MapLock.Unlock()
 
 	// If we've already been terminated, exit without writing the checkpoint. We explicitly want to leave the
@@ -543,7 +641,7 @@ func (acts *updateActions) OnResourceStepPost(
 		// Issue a true, bonafide error.
 		acts.Opts.Diag.Errorf(diag.GetResourceOperationFailedError(errorURN), err)
 		if reportStep {
-			acts.Opts.Events.resourceOperationFailedEvent(step, status, acts.Steps, acts.Opts.Debug)
+			acts.Opts.Events.resourceOperationFailedEvent(step, status, acts.Steps, acts.Opts.Debug, start)
 		}
 	} else if reportStep {
 		op, record := step.Op(), step.Logical()
@@ -568,7 +666,7 @@ func (acts *updateActions) OnResourceStepPost(
 		// not show outputs for component resources at this point: any that exist must be from a previous execution of
 		// the Pulumi program, as component resources only report outputs via calls to RegisterResourceOutputs.
 		if step.Res().Custom || acts.Opts.Refresh && step.Op() == deploy.OpRefresh {
-			acts.Opts.Events.resourceOutputsEvent(op, step, false /*planning*/, acts.Opts.Debug)
+			acts.Opts.Events.resourceOutputsEvent(op, step, false /*planning*/, acts.Opts.Debug, start)
 		}
 	}
 
@@ -606,11 +704,12 @@ func (acts *updateActions) OnResourceStepPost(
 func (acts *updateActions) OnResourceOutputs(step deploy.Step) error {
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
+	start := acts.Starts[step]
 	acts.MapLock.Unlock()
 
 	// Skip reporting if necessary.
 	if shouldReportStep(step, acts.Opts) {
-		acts.Opts.Events.resourceOutputsEvent(step.Op(), step, false /*planning*/, acts.Opts.Debug)
+		acts.Opts.Events.resourceOutputsEvent(step.Op(), step, false /*planning*/, acts.Opts.Debug, start)
 	}
 
 	// There's a chance there are new outputs that weren't written out last time.
@@ -618,8 +717,15 @@ func (acts *updateActions) OnResourceOutputs(step deploy.Step) error {
 	return acts.Context.SnapshotManager.RegisterResourceOutputs(step)
 }
 
-func (acts *updateActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic) {
-	acts.Opts.Events.policyViolationEvent(urn, d)
+func (acts *updateActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic,
+	waiver *deploy.PolicyWaiverMatch) {
+	acts.Opts.Events.policyViolationEvent(urn, d, waiver)
+}
+
+func (acts *updateActions) OnPolicyRemediation(urn resource.URN, t plugin.Remediation,
+	before resource.PropertyMap, after resource.PropertyMap) {
+
+	acts.Opts.Events.policyRemediationEvent(urn, t, before, after, acts.Opts.Debug)
 }
 
 func (acts *updateActions) MaybeCorrupt() bool {
@@ -634,6 +740,7 @@ type previewActions struct {
 	Ops     map[deploy.StepOp]int
 	Opts    deploymentOptions
 	Seen    map[resource.URN]deploy.Step
+	Starts  map[deploy.Step]time.Time
 	MapLock sync.Mutex
 }
 
@@ -657,15 +764,18 @@ func ShouldRecordReadStep(step deploy.Step) bool {
 
 func newPreviewActions(opts deploymentOptions) *previewActions {
 	return &previewActions{
-		Ops:  make(map[deploy.StepOp]int),
-		Opts: opts,
-		Seen: make(map[resource.URN]deploy.Step),
+		Ops:    make(map[deploy.StepOp]int),
+		Opts:   opts,
+		Seen:   make(map[resource.URN]deploy.Step),
+		Starts: make(map[deploy.Step]time.Time),
 	}
 }
 
 func (acts *previewActions) OnResourceStepPre(step deploy.Step) (interface{}, error) {
+	start := time.Now()
 	acts.MapLock.Lock()
 	acts.Seen[step.URN()] = step
+	acts.Starts[step] = start
 	acts.MapLock.Unlock()
 
 	// Skip reporting if necessary.
@@ -673,7 +783,7 @@ func (acts *previewActions) OnResourceStepPre(step deploy.Step) (interface{}, er
 		return nil, nil
 	}
 
-	acts.Opts.Events.resourcePreEvent(step, true /*planning*/, acts.Opts.Debug)
+	acts.Opts.Events.resourcePreEvent(step, true /*planning*/, acts.Opts.Debug, start)
 
 	return nil, nil
 }
@@ -682,6 +792,7 @@ func (acts *previewActions) OnResourceStepPost(ctx interface{},
 	step deploy.Step, status resource.Status, err error) error {
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
+	start := acts.Starts[step]
 	acts.MapLock.Unlock()
 
 	reportStep := shouldReportStep(step, acts.Opts)
@@ -713,7 +824,7 @@ func (acts *previewActions) OnResourceStepPost(ctx interface{},
 			acts.MapLock.Unlock()
 		}
 
-		acts.Opts.Events.resourceOutputsEvent(op, step, true /*planning*/, acts.Opts.Debug)
+		acts.Opts.Events.resourceOutputsEvent(op, step, true /*planning*/, acts.Opts.Debug, start)
 	}
 
 	return nil
@@ -722,6 +833,7 @@ func (acts *previewActions) OnResourceStepPost(ctx interface{},
 func (acts *previewActions) OnResourceOutputs(step deploy.Step) error {
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
+	start := acts.Starts[step]
 	acts.MapLock.Unlock()
 
 	// Skip reporting if necessary.
@@ -730,13 +842,20 @@ func (acts *previewActions) OnResourceOutputs(step deploy.Step) error {
 	}
 
 	// Print the resource outputs separately.
-	acts.Opts.Events.resourceOutputsEvent(step.Op(), step, true /*planning*/, acts.Opts.Debug)
+	acts.Opts.Events.resourceOutputsEvent(step.Op(), step, true /*planning*/, acts.Opts.Debug, start)
 
 	return nil
 }
 
-func (acts *previewActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic) {
-	acts.Opts.Events.policyViolationEvent(urn, d)
+func (acts *previewActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic,
+	waiver *deploy.PolicyWaiverMatch) {
+	acts.Opts.Events.policyViolationEvent(urn, d, waiver)
+}
+
+func (acts *previewActions) OnPolicyRemediation(urn resource.URN, t plugin.Remediation,
+	before resource.PropertyMap, after resource.PropertyMap) {
+
+	acts.Opts.Events.policyRemediationEvent(urn, t, before, after, acts.Opts.Debug)
 }
 
 func (acts *previewActions) MaybeCorrupt() bool {
@@ -0,0 +1,119 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+)
+
+// StackResources converts the resources in a stack's state into the resources that are passed to a policy pack's
+// AnalyzeStack. Resources that are pending deletion are skipped. As in a deployment, each resource's properties are
+// its outputs, and its provider's properties are the provider's inputs.
+func StackResources(resources []*resource.State) ([]plugin.AnalyzerStackResource, error) {
+	providerStates := make(map[resource.URN]*resource.State)
+	for _, r := range resources {
+		if !r.Delete && providers.IsProviderType(r.Type) {
+			providerStates[r.URN] = r
+		}
+	}
+
+	var result []plugin.AnalyzerStackResource
+	for _, r := range resources {
+		if r.Delete {
+			continue
+		}
+
+		res := plugin.AnalyzerStackResource{
+			AnalyzerResource: plugin.AnalyzerResource{
+				URN:        r.URN,
+				Type:       r.Type,
+				Name:       r.URN.Name(),
+				Properties: r.Outputs,
+				Options: plugin.AnalyzerResourceOptions{
+					Protect:                 r.Protect,
+					AdditionalSecretOutputs: r.AdditionalSecretOutputs,
+					Aliases:                 r.Aliases,
+					CustomTimeouts:          r.CustomTimeouts,
+				},
+			},
+			Parent:               r.Parent,
+			Dependencies:         r.Dependencies,
+			PropertyDependencies: r.PropertyDependencies,
+		}
+		if r.Provider != "" {
+			ref, err := providers.ParseReference(r.Provider)
+			if err != nil {
+				return nil, fmt.Errorf("parsing provider reference of %v: %w", r.URN, err)
+			}
+			if p, ok := providerStates[ref.URN()]; ok {
+				res.Provider = &plugin.AnalyzerProviderResource{
+					URN:        p.URN,
+					Type:       p.Type,
+					Name:       p.URN.Name(),
+					Properties: p.Inputs,
+				}
+			}
+		}
+		result = append(result, res)
+	}
+	return result, nil
+}
+
+// AnalyzeState runs the given analyzers against the resources in a stack's state, without running the stack's program
+// or contacting any provider. Each resource's inputs are checked with Analyze, and then the stack's resources and
+// their outputs are checked with AnalyzeStack, just as they would be during a deployment that made no changes.
+// Diagnostics from Analyze that do not name a resource are attributed to the resource that was analyzed.
+func AnalyzeState(analyzers []plugin.Analyzer, resources []*resource.State) ([]plugin.AnalyzeDiagnostic, error) {
+	stackResources, err := StackResources(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make(map[resource.URN]resource.PropertyMap)
+	for _, r := range resources {
+		if !r.Delete {
+			inputs[r.URN] = r.Inputs
+		}
+	}
+
+	var diagnostics []plugin.AnalyzeDiagnostic
+	for _, a := range analyzers {
+		for _, r := range stackResources {
+			res := r.AnalyzerResource
+			res.Properties = inputs[r.URN]
+			diags, err := a.Analyze(res)
+			if err != nil {
+				return nil, fmt.Errorf("analyzing %v: %w", r.URN, err)
+			}
+			for _, d := range diags {
+				if d.URN == "" {
+					d.URN = r.URN
+				}
+				diagnostics = append(diagnostics, d)
+			}
+		}
+
+		diags, err := a.AnalyzeStack(stackResources)
+		if err != nil {
+			return nil, fmt.Errorf("analyzing stack: %w", err)
+		}
+		diagnostics = append(diagnostics, diags...)
+	}
+	return diagnostics, nil
+}
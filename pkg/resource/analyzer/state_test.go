@@ -0,0 +1,150 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/policy"
+)
+
+func testStateResources() []*resource.State {
+	provURN := resource.NewURN("test", "proj", "", "pulumi:providers:pkgA", "prov")
+	urnA := resource.NewURN("test", "proj", "", "pkgA:m:typA", "resA")
+	urnB := resource.NewURN("test", "proj", "", "pkgA:m:typA", "resB")
+	return []*resource.State{
+		{
+			URN:    provURN,
+			Type:   "pulumi:providers:pkgA",
+			Custom: true,
+			ID:     "provid",
+			Inputs: resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")},
+		},
+		{
+			URN:      urnA,
+			Type:     "pkgA:m:typA",
+			Custom:   true,
+			ID:       "ida",
+			Provider: string(provURN) + "::provid",
+			Inputs:   resource.PropertyMap{"size": resource.NewNumberProperty(1)},
+			Outputs: resource.PropertyMap{
+				"size": resource.NewNumberProperty(1),
+				"arn":  resource.NewStringProperty("arn:a"),
+			},
+			Protect: true,
+		},
+		{
+			URN:          urnB,
+			Type:         "pkgA:m:typA",
+			Custom:       true,
+			ID:           "idb",
+			Provider:     string(provURN) + "::provid",
+			Inputs:       resource.PropertyMap{"size": resource.NewNumberProperty(10)},
+			Outputs:      resource.PropertyMap{"size": resource.NewNumberProperty(10)},
+			Dependencies: []resource.URN{urnA},
+		},
+		{
+			// Resources pending deletion are not analyzed.
+			URN:     urnB,
+			Type:    "pkgA:m:typA",
+			Custom:  true,
+			ID:      "idb-old",
+			Inputs:  resource.PropertyMap{"size": resource.NewNumberProperty(100)},
+			Outputs: resource.PropertyMap{"size": resource.NewNumberProperty(100)},
+			Delete:  true,
+		},
+	}
+}
+
+func TestStackResources(t *testing.T) {
+	t.Parallel()
+
+	states := testStateResources()
+	resources, err := StackResources(states)
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+
+	a := resources[1]
+	assert.Equal(t, states[1].URN, a.URN)
+	assert.Equal(t, "resA", string(a.Name))
+	assert.Equal(t, states[1].Outputs, a.Properties)
+	assert.True(t, a.Options.Protect)
+	require.NotNil(t, a.Provider)
+	assert.Equal(t, states[0].URN, a.Provider.URN)
+	assert.Equal(t, states[0].Inputs, a.Provider.Properties)
+
+	assert.Equal(t, []resource.URN{states[1].URN}, resources[2].Dependencies)
+
+	_, err = StackResources([]*resource.State{{URN: states[1].URN, Type: "pkgA:m:typA", Provider: "bad"}})
+	assert.Error(t, err)
+}
+
+func TestAnalyzeState(t *testing.T) {
+	t.Parallel()
+
+	var stackResources []plugin.AnalyzerStackResource
+	analyzer, err := policy.NewAnalyzer(policy.PolicyPack{
+		Name:    "pack",
+		Version: "1.0.0",
+		Policies: []policy.Policy{
+			policy.ResourceValidationPolicy{
+				Name:             "max-size",
+				Description:      "Resources must not be too large.",
+				EnforcementLevel: apitype.Mandatory,
+				ValidateResource: func(args policy.ResourceValidationArgs, report policy.ReportViolation) error {
+					if size, ok := args.Properties["size"]; ok && size.NumberValue() > 5 {
+						report("")
+					}
+					return nil
+				},
+			},
+			policy.StackValidationPolicy{
+				Name:        "count",
+				Description: "Too many resources.",
+				ValidateStack: func(args policy.StackValidationArgs, report policy.ReportStackViolation) error {
+					stackResources = args.Resources
+					if len(args.ResourcesOfType("pkgA:m:typA")) > 1 {
+						report("", "")
+					}
+					return nil
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	states := testStateResources()
+	diags, err := AnalyzeState([]plugin.Analyzer{analyzer}, states)
+	require.NoError(t, err)
+	require.Len(t, diags, 2)
+
+	// The resource violation is attributed to the resource that was checked.
+	assert.Equal(t, "max-size", diags[0].PolicyName)
+	assert.Equal(t, apitype.Mandatory, diags[0].EnforcementLevel)
+	assert.Equal(t, states[2].URN, diags[0].URN)
+
+	assert.Equal(t, "count", diags[1].PolicyName)
+	assert.Equal(t, apitype.Advisory, diags[1].EnforcementLevel)
+	assert.Equal(t, resource.URN(""), diags[1].URN)
+
+	// AnalyzeStack sees the outputs of each resource.
+	require.Len(t, stackResources, 3)
+	assert.Equal(t, "arn:a", stackResources[1].Properties["arn"].StringValue())
+}
@@ -0,0 +1,136 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// ConcurrencyLimits holds limits on the number of provider operations that the engine runs at once, in addition to
+// the global limit set by Options.Parallel. Limits on individual provider resources are set with the provider's
+// MaxConcurrency resource option.
+type ConcurrencyLimits struct {
+	Packages map[tokens.Package]int // limits that apply to all providers for a package.
+	Types    map[tokens.Type]int    // limits that apply to operations on resources of a type.
+}
+
+// concurrencyLimiter enforces concurrency limits across the step executor and the resource monitor. The zero value
+// and the nil value both impose no limits.
+type concurrencyLimiter struct {
+	limits         ConcurrencyLimits
+	providerLimits sync.Map // provider URNs to the limits set by their MaxConcurrency option.
+
+	lock       sync.Mutex
+	semaphores map[string]chan struct{}
+}
+
+func newConcurrencyLimiter(limits *ConcurrencyLimits) *concurrencyLimiter {
+	l := &concurrencyLimiter{semaphores: map[string]chan struct{}{}}
+	if limits != nil {
+		l.limits = *limits
+	}
+	return l
+}
+
+// setProviderLimit records the limit for the provider resource with the given URN.
+func (l *concurrencyLimiter) setProviderLimit(urn resource.URN, limit int) {
+	if l == nil || limit <= 0 {
+		return
+	}
+	l.providerLimits.Store(urn, limit)
+}
+
+// semaphore returns the semaphore for the given key, creating it with the given limit if necessary.
+func (l *concurrencyLimiter) semaphore(key string, limit int) chan struct{} {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	sem, has := l.semaphores[key]
+	if !has {
+		sem = make(chan struct{}, limit)
+		l.semaphores[key] = sem
+	}
+	return sem
+}
+
+// acquire blocks until an operation against the given provider reference and resource type may run, or until the
+// given context is done. If typ is empty, only provider limits apply. On success, acquire returns a function that
+// must be called once the operation completes.
+//
+// Limits are always acquired in the same order (package, provider, type) so that concurrent callers cannot deadlock.
+func (l *concurrencyLimiter) acquire(ctx context.Context, providerRef string, typ tokens.Type) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	type limit struct {
+		key   string
+		limit int
+	}
+	var limits []limit
+	if ref, err := providers.ParseReference(providerRef); err == nil {
+		pkg := providers.GetProviderPackage(ref.URN().Type())
+		if n, has := l.limits.Packages[pkg]; has && n > 0 {
+			limits = append(limits, limit{"package:" + string(pkg), n})
+		}
+		if n, has := l.providerLimits.Load(ref.URN()); has {
+			limits = append(limits, limit{"provider:" + string(ref.URN()), n.(int)})
+		}
+	}
+	if n, has := l.limits.Types[typ]; has && n > 0 {
+		limits = append(limits, limit{"type:" + string(typ), n})
+	}
+
+	var held []chan struct{}
+	release := func() {
+		for _, sem := range held {
+			<-sem
+		}
+	}
+	for _, lim := range limits {
+		sem := l.semaphore(lim.key, lim.limit)
+		select {
+		case sem <- struct{}{}:
+			held = append(held, sem)
+		default:
+			logging.V(7).Infof("concurrencyLimiter.acquire(%s, %s): waiting on %s", providerRef, typ, lim.key)
+			select {
+			case sem <- struct{}{}:
+				held = append(held, sem)
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+	return release, nil
+}
+
+// callsProvider returns true if steps with the given operation call their resource's provider when applied.
+func callsProvider(op StepOp) bool {
+	switch op {
+	case OpCreate, OpCreateReplacement, OpUpdate, OpDelete, OpDeleteReplaced, OpRead, OpReadReplacement, OpRefresh,
+		OpImport, OpImportReplacement:
+		return true
+	default:
+		return false
+	}
+}
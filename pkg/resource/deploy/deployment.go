@@ -49,19 +49,25 @@ type BackendClient interface {
 
 // Options controls the deployment process.
 type Options struct {
-	Events                    Events         // an optional events callback interface.
-	Parallel                  int            // the degree of parallelism for resource operations (<=1 for serial).
-	Refresh                   bool           // whether or not to refresh before executing the deployment.
-	RefreshOnly               bool           // whether or not to exit after refreshing.
-	RefreshTargets            []resource.URN // The specific resources to refresh during a refresh op.
-	ReplaceTargets            []resource.URN // Specific resources to replace.
-	DestroyTargets            []resource.URN // Specific resources to destroy.
-	UpdateTargets             []resource.URN // Specific resources to update.
-	TargetDependents          bool           // true if we're allowing things to proceed, even with unspecified targets
-	TrustDependencies         bool           // whether or not to trust the resource dependency graph.
-	UseLegacyDiff             bool           // whether or not to use legacy diffing behavior.
-	DisableResourceReferences bool           // true to disable resource reference support.
-	DisableOutputValues       bool           // true to disable output value support.
+	Events                    Events             // an optional events callback interface.
+	Parallel                  int                // the degree of parallelism for resource operations (<=1 for serial).
+	Refresh                   bool               // whether or not to refresh before executing the deployment.
+	RefreshOnly               bool               // whether or not to exit after refreshing.
+	RefreshTargets            []resource.URN     // The specific resources to refresh during a refresh op.
+	ReplaceTargets            []resource.URN     // Specific resources to replace.
+	DestroyTargets            []resource.URN     // Specific resources to destroy.
+	UpdateTargets             []resource.URN     // Specific resources to update.
+	TargetDependents          bool               // true if we're allowing things to proceed, even with unspecified targets
+	TrustDependencies         bool               // whether or not to trust the resource dependency graph.
+	UseLegacyDiff             bool               // whether or not to use legacy diffing behavior.
+	DisableResourceReferences bool               // true to disable resource reference support.
+	DisableOutputValues       bool               // true to disable output value support.
+	ContinueOnError           bool               // true to keep executing steps that do not depend on a failed step.
+	RetryPolicies             *RetryPolicies     // the policies for retrying provider operations that fail.
+	ConcurrencyLimits         *ConcurrencyLimits // limits on concurrent operations against providers and types.
+	ChangeGuards              *ChangeGuards      // resources that must not be deleted or replaced.
+
+	limiter *concurrencyLimiter // enforces the concurrency limits; shared by the step executor and the resmon.
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -78,7 +84,8 @@ func (o Options) InfiniteParallelism() bool {
 	return o.Parallel == math.MaxInt32
 }
 
-// StepExecutorEvents is an interface that can be used to hook resource lifecycle events.
+// StepExecutorEvents is an interface that can be used to hook resource lifecycle events. OnResourceStepPre may return
+// ErrSkipStep to leave the step's resource unchanged, as if it had not been targeted by the deployment.
 type StepExecutorEvents interface {
 	OnResourceStepPre(step Step) (interface{}, error)
 	OnResourceStepPost(ctx interface{}, step Step, status resource.Status, err error) error
@@ -87,7 +94,10 @@ type StepExecutorEvents interface {
 
 // PolicyEvents is an interface that can be used to hook policy events.
 type PolicyEvents interface {
-	OnPolicyViolation(resource.URN, plugin.AnalyzeDiagnostic)
+	// OnPolicyViolation is called with each policy violation. If a policy waiver matched the violation, it is passed
+	// along with it; a violation that was waived has been downgraded to advisory.
+	OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic, waiver *PolicyWaiverMatch)
+	OnPolicyRemediation(urn resource.URN, t plugin.Remediation, before, after resource.PropertyMap)
 }
 
 // Events is an interface that can be used to hook interesting engine events.
@@ -407,6 +417,8 @@ func (d *Deployment) generateEventURN(event SourceEvent) resource.URN {
 
 // Execute executes a deployment to completion, using the given cancellation context and running a preview or update.
 func (d *Deployment) Execute(ctx context.Context, opts Options, preview bool) result.Result {
+	opts.limiter = newConcurrencyLimiter(opts.ConcurrencyLimits)
+
 	deploymentExec := &deploymentExecutor{deployment: d}
 	return deploymentExec.Execute(ctx, opts, preview)
 }
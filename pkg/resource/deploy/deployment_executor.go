@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
@@ -185,7 +186,7 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 	ctx, cancel := context.WithCancel(callerCtx)
 
 	// Set up a step generator and executor for this deployment.
-	ex.stepExec = newStepExecutor(ctx, cancel, ex.deployment, opts, preview, false)
+	ex.stepExec = newStepExecutor(ctx, cancel, ex.deployment, opts, preview, opts.ContinueOnError)
 
 	// We iterate the source in its own goroutine because iteration is blocking and we want the main loop to be able to
 	// respond to cancellation requests promptly.
@@ -217,6 +218,9 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 	//     should bail.
 	//  3. The stepExecCancel cancel context gets canceled. This means some error occurred in the step executor
 	//     and we need to bail. This can also happen if the user hits Ctrl-C.
+	//
+	// If we are continuing after errors, errors in the source program or in step generation do not cancel the
+	// deployment. Instead, we let any steps that are already in flight complete before we bail.
 	canceled, res := func() (bool, result.Result) {
 		logging.V(4).Infof("deploymentExecutor.Execute(...): waiting for incoming events")
 		for {
@@ -229,14 +233,18 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 					if !event.Result.IsBail() {
 						ex.reportError("", event.Result.Error())
 					}
-					cancel()
+					if opts.ContinueOnError {
+						ex.stepExec.SignalCompletion()
+					} else {
+						cancel()
+					}
 
 					// We reported any errors above.  So we can just bail now.
 					return false, result.Bail()
 				}
 
 				if event.Event == nil {
-					return false, ex.performDeletes(ctx, updateTargetsOpt, destroyTargetsOpt)
+					return false, ex.performDeletes(ctx, updateTargetsOpt, destroyTargetsOpt, opts.ContinueOnError)
 				}
 
 				if res := ex.handleSingleEvent(event.Event); res != nil {
@@ -244,6 +252,11 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 						logging.V(4).Infof("deploymentExecutor.Execute(...): error handling event: %v", resErr)
 						ex.reportError(ex.deployment.generateEventURN(event.Event), resErr)
 					}
+					if opts.ContinueOnError {
+						ex.stepGen.sawError = true
+						failEvent(event.Event)
+						continue
+					}
 					cancel()
 					return false, result.Bail()
 				}
@@ -284,6 +297,12 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 		}
 	}
 
+	// If we continued past any failures, summarize them so that they are not lost among the output of the steps
+	// that ran afterwards.
+	if opts.ContinueOnError {
+		ex.reportFailures()
+	}
+
 	// Figure out if execution failed and why. Step generation and execution errors trump cancellation.
 	if res != nil || ex.stepExec.Errored() || ex.stepGen.Errored() {
 		// TODO(cyrusn): We seem to be losing any information about the original 'res's errors.  Should
@@ -298,8 +317,40 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 	return res
 }
 
-func (ex *deploymentExecutor) performDeletes(
-	ctx context.Context, updateTargetsOpt, destroyTargetsOpt map[resource.URN]bool) result.Result {
+// reportFailures issues a single diagnostic that lists each resource whose step failed or was skipped during a
+// deployment that continued after errors.
+func (ex *deploymentExecutor) reportFailures() {
+	failures := ex.stepExec.Failures()
+	if len(failures) == 0 {
+		return
+	}
+
+	urns := make([]string, 0, len(failures))
+	for urn := range failures {
+		urns = append(urns, string(urn))
+	}
+	sort.Strings(urns)
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "%d resource(s) failed or were skipped:", len(urns))
+	for _, urn := range urns {
+		fmt.Fprintf(&message, "\n    %s: %v", urn, failures[resource.URN(urn)])
+	}
+	ex.reportError("", errors.New(message.String()))
+}
+
+// failEvent notifies the program that a source event could not be serviced because step generation failed.
+func failEvent(event SourceEvent) {
+	switch e := event.(type) {
+	case RegisterResourceEvent:
+		e.Done(&RegisterResult{Failed: true})
+	case ReadResourceEvent:
+		e.Done(&ReadResult{Failed: true})
+	}
+}
+
+func (ex *deploymentExecutor) performDeletes(ctx context.Context, updateTargetsOpt,
+	destroyTargetsOpt map[resource.URN]bool, continueOnError bool) result.Result {
 
 	defer func() {
 		// We're done here - signal completion so that the step executor knows to terminate.
@@ -339,6 +390,10 @@ func (ex *deploymentExecutor) performDeletes(
 	// deleting but we won't until the previous set of deletes fully completes. This approximation
 	// is conservative, but correct.
 	for _, antichain := range deletes {
+		if continueOnError {
+			antichain = ex.skipBlockedDeletes(antichain)
+		}
+
 		logging.V(4).Infof("deploymentExecutor.Execute(...): beginning delete antichain")
 		tok := ex.stepExec.ExecuteParallel(antichain)
 		tok.Wait(ctx)
@@ -359,6 +414,33 @@ func (ex *deploymentExecutor) performDeletes(
 	return nil
 }
 
+// skipBlockedDeletes removes any delete steps from the given antichain whose resources are depended on by a resource
+// whose step failed or was skipped. Deleting such a resource could break the resource that depends on it, so we
+// record the delete as skipped instead.
+func (ex *deploymentExecutor) skipBlockedDeletes(steps antichain) antichain {
+	var runnable antichain
+	for _, step := range steps {
+		var blockedBy resource.URN
+		for _, dep := range ex.deployment.depGraph.DependingOn(step.Old(), nil, true) {
+			if ex.stepExec.HasFailed(dep.URN) {
+				blockedBy = dep.URN
+				break
+			}
+		}
+
+		if blockedBy == "" {
+			runnable = append(runnable, step)
+			continue
+		}
+
+		logging.V(4).Infof("deploymentExecutor.Execute(...): skipping delete of %v due to failure of %v",
+			step.URN(), blockedBy)
+		ex.stepExec.failChain(synchronousWorkerID, chain{step},
+			fmt.Errorf("skipped because %s failed", blockedBy))
+	}
+	return runnable
+}
+
 // handleSingleEvent handles a single source event. For all incoming events, it produces a chain that needs
 // to be executed and schedules the chain for execution.
 func (ex *deploymentExecutor) handleSingleEvent(event SourceEvent) result.Result {
@@ -102,6 +102,11 @@ type ResourceOptions struct {
 	SupportsPartialValues *bool
 	Remote                bool
 	Providers             map[string]string
+	RetainOnDelete        bool
+	DeletedWith           resource.URN
+	RetryPolicy           *resource.RetryPolicy
+	MaxConcurrency        int
+	RefuseReplace         bool
 
 	DisableSecrets            bool
 	DisableResourceReferences bool
@@ -158,6 +163,15 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 		timeouts.Delete = prepareTestTimeout(opts.CustomTimeouts.Delete)
 	}
 
+	var retryPolicy *pulumirpc.RegisterResourceRequest_RetryPolicy
+	if opts.RetryPolicy != nil {
+		retryPolicy = &pulumirpc.RegisterResourceRequest_RetryPolicy{
+			MaxAttempts: int32(opts.RetryPolicy.MaxAttempts),
+			Backoff:     fmt.Sprintf("%vs", opts.RetryPolicy.Backoff),
+			Errors:      opts.RetryPolicy.Errors,
+		}
+	}
+
 	deleteBeforeReplace := false
 	if opts.DeleteBeforeReplace != nil {
 		deleteBeforeReplace = *opts.DeleteBeforeReplace
@@ -190,6 +204,11 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 		ReplaceOnChanges:           opts.ReplaceOnChanges,
 		Providers:                  opts.Providers,
 		PluginDownloadURL:          opts.PluginDownloadURL,
+		RetainOnDelete:             opts.RetainOnDelete,
+		DeletedWith:                string(opts.DeletedWith),
+		RetryPolicy:                retryPolicy,
+		MaxConcurrency:             int32(opts.MaxConcurrency),
+		RefuseReplace:              opts.RefuseReplace,
 	}
 
 	// submit request
@@ -0,0 +1,94 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// ChangeGuards lists the resources that a deployment must never delete or replace. Each pattern matches resource URNs
+// if it begins with "urn:" and resource types otherwise; a "*" in a pattern matches any sequence of characters.
+type ChangeGuards struct {
+	RefuseReplace []string // patterns for resources that must not be replaced.
+	RefuseDelete  []string // patterns for resources that must not be deleted, whether on their own or by replacement.
+}
+
+// ValidateChangeGuardPattern returns an error if the given pattern cannot be used in a ChangeGuards list.
+func ValidateChangeGuardPattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("resource patterns must not be empty")
+	}
+	return nil
+}
+
+// matchesGuardPattern returns true if the given pattern matches the resource with the given URN.
+func matchesGuardPattern(pattern string, urn resource.URN) bool {
+	subject := string(urn)
+	if !strings.HasPrefix(pattern, "urn:") {
+		subject = string(urn.Type())
+	}
+
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$").MatchString(subject)
+}
+
+// refusal returns a description of the guard that forbids the given step, if any. Replacements are refused by both
+// replace and delete guards, as replacing a resource deletes the original. This includes deleting the original ahead
+// of its replacement, as happens to the dependents of a resource that is deleted before it is replaced.
+func (g *ChangeGuards) refusal(step Step) (string, bool) {
+	if g == nil {
+		return "", false
+	}
+
+	var lists []string
+	var patterns [][]string
+	switch {
+	case isReplacementOp(step.Op()) || step.Op() == OpDeleteReplaced:
+		lists, patterns = []string{"refuse-replace", "refuse-delete"}, [][]string{g.RefuseReplace, g.RefuseDelete}
+	case step.Op() == OpDelete:
+		lists, patterns = []string{"refuse-delete"}, [][]string{g.RefuseDelete}
+	default:
+		return "", false
+	}
+
+	for i, list := range patterns {
+		for _, pattern := range list {
+			if matchesGuardPattern(pattern, step.URN()) {
+				return fmt.Sprintf("it matches the %s pattern '%s'", lists[i], pattern), true
+			}
+		}
+	}
+	return "", false
+}
+
+// isReplacementOp returns true if the given operation is part of replacing a resource.
+func isReplacementOp(op StepOp) bool {
+	return op == OpReplace || op == OpCreateReplacement || op == OpImportReplacement
+}
+
+// guardedOpDescription returns the verb used to describe the given step in a refusal.
+func guardedOpDescription(op StepOp) string {
+	if op == OpDelete {
+		return "delete"
+	}
+	return "replace"
+}
@@ -0,0 +1,46 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func TestMatchesGuardPattern(t *testing.T) {
+	t.Parallel()
+
+	urn := resource.NewURN("stack", "proj", "", "aws:rds/instance:Instance", "prod-db")
+
+	cases := []struct {
+		pattern string
+		matches bool
+	}{
+		{"aws:rds/instance:Instance", true},
+		{"aws:rds/*", true},
+		{"aws:rds/cluster:Cluster", false},
+		{"aws:rds/instance", false},
+		{string(urn), true},
+		{"urn:pulumi:stack::proj::*::prod-*", true},
+		{"urn:pulumi:other::proj::*", false},
+		{"*", true},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.matches, matchesGuardPattern(c.pattern, urn), "pattern %q", c.pattern)
+	}
+}
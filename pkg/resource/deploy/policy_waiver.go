@@ -0,0 +1,121 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// PolicyWaiver waives a policy for the resources that match a pattern until it expires. The pattern matches resource
+// URNs if it begins with "urn:" and resource types otherwise; a "*" matches any sequence of characters.
+type PolicyWaiver struct {
+	PolicyPack string    // the name of the policy pack that contains the policy.
+	Policy     string    // the name of the waived policy.
+	Pattern    string    // the pattern matching the resources for which the policy is waived.
+	Reason     string    // the justification for the waiver.
+	Expires    time.Time // the time from which the waiver no longer applies.
+}
+
+// PolicyWaiverMatch is a waiver that matched a policy violation.
+type PolicyWaiverMatch struct {
+	Waiver  PolicyWaiver
+	Expired bool // true if the waiver had expired, in which case the violation was not waived.
+}
+
+// NewPolicyWaivers validates the policy waivers in a stack's settings and returns them.
+func NewPolicyWaivers(waivers []workspace.ProjectStackPolicyWaiver) ([]PolicyWaiver, error) {
+	var result []PolicyWaiver
+	for i, w := range waivers {
+		waiver, err := newPolicyWaiver(w)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy waiver %d: %w", i+1, err)
+		}
+		result = append(result, waiver)
+	}
+	return result, nil
+}
+
+func newPolicyWaiver(w workspace.ProjectStackPolicyWaiver) (PolicyWaiver, error) {
+	switch {
+	case w.PolicyPack == "":
+		return PolicyWaiver{}, errors.New("a policy pack is required")
+	case w.Policy == "":
+		return PolicyWaiver{}, errors.New("a policy is required")
+	case strings.TrimSpace(w.Reason) == "":
+		return PolicyWaiver{}, errors.New("a reason is required")
+	case w.Expires == "":
+		return PolicyWaiver{}, errors.New("an expiry date is required")
+	}
+	if err := ValidateChangeGuardPattern(w.URN); err != nil {
+		return PolicyWaiver{}, err
+	}
+
+	// A waiver that expires on a date applies throughout that day, so it expires at the start of the next one.
+	expires, err := time.Parse("2006-01-02", w.Expires)
+	if err == nil {
+		expires = expires.AddDate(0, 0, 1)
+	} else if expires, err = time.Parse(time.RFC3339, w.Expires); err != nil {
+		return PolicyWaiver{}, fmt.Errorf(
+			"expiry %q is not a date (e.g. 2022-12-31) or an RFC 3339 time", w.Expires)
+	}
+
+	return PolicyWaiver{
+		PolicyPack: w.PolicyPack,
+		Policy:     w.Policy,
+		Pattern:    w.URN,
+		Reason:     w.Reason,
+		Expires:    expires,
+	}, nil
+}
+
+// Matches returns true if the waiver applies to the given violation of a policy by the resource with the given URN.
+func (w PolicyWaiver) Matches(urn resource.URN, d plugin.AnalyzeDiagnostic) bool {
+	return w.PolicyPack == d.PolicyPackName && w.Policy == d.PolicyName && matchesGuardPattern(w.Pattern, urn)
+}
+
+// waivePolicyViolation applies the first of the given waivers that matches a policy violation and has not expired,
+// downgrading the violation to advisory. If only expired waivers match, the violation is left as it is. Returns the
+// violation along with the waiver that matched, if any.
+func waivePolicyViolation(waivers []PolicyWaiver, urn resource.URN, d plugin.AnalyzeDiagnostic,
+	now time.Time) (plugin.AnalyzeDiagnostic, *PolicyWaiverMatch) {
+
+	var expired *PolicyWaiverMatch
+	for _, w := range waivers {
+		if !w.Matches(urn, d) {
+			continue
+		}
+
+		if !now.Before(w.Expires) {
+			if expired == nil {
+				expired = &PolicyWaiverMatch{Waiver: w, Expired: true}
+			}
+			continue
+		}
+
+		if d.EnforcementLevel == apitype.Mandatory {
+			d.EnforcementLevel = apitype.Advisory
+		}
+		return d, &PolicyWaiverMatch{Waiver: w}
+	}
+	return d, expired
+}
@@ -0,0 +1,140 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func TestNewPolicyWaivers(t *testing.T) {
+	t.Parallel()
+
+	valid := workspace.ProjectStackPolicyWaiver{
+		PolicyPack: "security",
+		Policy:     "no-public-buckets",
+		URN:        "urn:pulumi:prod::site::aws:s3/bucket:Bucket::assets*",
+		Reason:     "The bucket hosts the public website.",
+		Expires:    "2022-12-31",
+	}
+
+	waivers, err := NewPolicyWaivers([]workspace.ProjectStackPolicyWaiver{valid})
+	require.NoError(t, err)
+	require.Len(t, waivers, 1)
+	assert.Equal(t, PolicyWaiver{
+		PolicyPack: "security",
+		Policy:     "no-public-buckets",
+		Pattern:    valid.URN,
+		Reason:     valid.Reason,
+		Expires:    time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, waivers[0])
+
+	withTime := valid
+	withTime.Expires = "2022-12-31T12:30:00Z"
+	waivers, err = NewPolicyWaivers([]workspace.ProjectStackPolicyWaiver{withTime})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 12, 31, 12, 30, 0, 0, time.UTC), waivers[0].Expires)
+
+	invalid := []func(w *workspace.ProjectStackPolicyWaiver){
+		func(w *workspace.ProjectStackPolicyWaiver) { w.PolicyPack = "" },
+		func(w *workspace.ProjectStackPolicyWaiver) { w.Policy = "" },
+		func(w *workspace.ProjectStackPolicyWaiver) { w.URN = "" },
+		func(w *workspace.ProjectStackPolicyWaiver) { w.Reason = " " },
+		func(w *workspace.ProjectStackPolicyWaiver) { w.Expires = "" },
+		func(w *workspace.ProjectStackPolicyWaiver) { w.Expires = "next week" },
+	}
+	for i, f := range invalid {
+		w := valid
+		f(&w)
+		_, err := NewPolicyWaivers([]workspace.ProjectStackPolicyWaiver{valid, w})
+		assert.Error(t, err, "case %d", i)
+		if err != nil {
+			assert.Contains(t, err.Error(), "invalid policy waiver 2")
+		}
+	}
+}
+
+func TestWaivePolicyViolation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	waivers := []PolicyWaiver{
+		{
+			PolicyPack: "security",
+			Policy:     "no-public-buckets",
+			Pattern:    "aws:s3/bucket:Bucket",
+			Reason:     "current",
+			Expires:    now.Add(time.Hour),
+		},
+		{
+			PolicyPack: "security",
+			Policy:     "encrypted",
+			Pattern:    "urn:pulumi:prod::site::*::old-*",
+			Reason:     "expired",
+			Expires:    now,
+		},
+	}
+
+	bucket := resource.NewURN("prod", "site", "", "aws:s3/bucket:Bucket", "assets")
+	oldBucket := resource.NewURN("prod", "site", "", "aws:s3/bucket:Bucket", "old-assets")
+	violation := func(policy string) plugin.AnalyzeDiagnostic {
+		return plugin.AnalyzeDiagnostic{
+			PolicyPackName:   "security",
+			PolicyName:       policy,
+			EnforcementLevel: apitype.Mandatory,
+		}
+	}
+
+	// A matching waiver that has not expired downgrades the violation.
+	d, match := waivePolicyViolation(waivers, bucket, violation("no-public-buckets"), now)
+	require.NotNil(t, match)
+	assert.Equal(t, "current", match.Waiver.Reason)
+	assert.False(t, match.Expired)
+	assert.Equal(t, apitype.Advisory, d.EnforcementLevel)
+
+	// A matching waiver that has expired is reported, but does not downgrade the violation.
+	d, match = waivePolicyViolation(waivers, oldBucket, violation("encrypted"), now)
+	require.NotNil(t, match)
+	assert.Equal(t, "expired", match.Waiver.Reason)
+	assert.True(t, match.Expired)
+	assert.Equal(t, apitype.Mandatory, d.EnforcementLevel)
+
+	// Waivers only match their own policy and resources.
+	d, match = waivePolicyViolation(waivers, bucket, violation("encrypted"), now)
+	assert.Nil(t, match)
+	assert.Equal(t, apitype.Mandatory, d.EnforcementLevel)
+
+	// A waiver that has not expired takes precedence over an expired one.
+	renewed := waivers[1]
+	renewed.Reason, renewed.Expires = "renewed", now.Add(time.Hour)
+	d, match = waivePolicyViolation(append(waivers, renewed), oldBucket, violation("encrypted"), now)
+	require.NotNil(t, match)
+	assert.Equal(t, "renewed", match.Waiver.Reason)
+	assert.False(t, match.Expired)
+	assert.Equal(t, apitype.Advisory, d.EnforcementLevel)
+
+	other := violation("no-public-buckets")
+	other.PolicyPackName = "other"
+	_, match = waivePolicyViolation(waivers, bucket, other, now)
+	assert.Nil(t, match)
+}
@@ -0,0 +1,135 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// ReplaceReasonKind identifies one of the ways in which the step generator can decide to replace a resource.
+type ReplaceReasonKind string
+
+const (
+	// ReplaceReasonTargeted means that the resource was named by a --replace target.
+	ReplaceReasonTargeted ReplaceReasonKind = "targeted"
+	// ReplaceReasonProviderDiff means that the resource's provider reported changes that require replacement.
+	ReplaceReasonProviderDiff ReplaceReasonKind = "provider-diff"
+	// ReplaceReasonReplaceOnChanges means that properties named by the resource's replaceOnChanges option changed.
+	ReplaceReasonReplaceOnChanges ReplaceReasonKind = "replace-on-changes"
+	// ReplaceReasonInitErrors means that the resource failed to initialize and its replaceOnChanges option is "*".
+	ReplaceReasonInitErrors ReplaceReasonKind = "init-errors"
+	// ReplaceReasonProviderChanged means that the resource's provider changed or its configuration requires
+	// replacement of the resources it manages.
+	ReplaceReasonProviderChanged ReplaceReasonKind = "provider-changed"
+	// ReplaceReasonDependency means that a resource this resource depends on is being replaced with
+	// delete-before-replace, which requires this resource to be replaced as well.
+	ReplaceReasonDependency ReplaceReasonKind = "dependency-replaced"
+	// ReplaceReasonExternal means that the resource was previously read rather than managed, and must be created.
+	ReplaceReasonExternal ReplaceReasonKind = "external"
+	// ReplaceReasonImport means that the resource is being imported in place of the existing resource.
+	ReplaceReasonImport ReplaceReasonKind = "import"
+)
+
+// ReplaceReason records one cause of a resource's replacement. Reasons that blame an upstream resource carry the
+// reasons for that resource's own replacement, if any, so that a reason forms a chain back to its root cause.
+type ReplaceReason struct {
+	Kind            ReplaceReasonKind      // the kind of cause.
+	Properties      []resource.PropertyKey // the properties that caused the replacement, if any.
+	Upstream        resource.URN           // the upstream resource that caused the replacement, if any.
+	UpstreamReasons []ReplaceReason        // the reasons for the upstream resource's own replacement, if any.
+}
+
+// String returns a human-readable description of this reason, not including the reasons for any upstream replacement.
+func (r ReplaceReason) String() string {
+	props := func() string {
+		keys := make([]string, len(r.Properties))
+		for i, k := range r.Properties {
+			keys[i] = string(k)
+		}
+		sort.Strings(keys)
+		return strings.Join(keys, ", ")
+	}
+
+	switch r.Kind {
+	case ReplaceReasonTargeted:
+		return "the resource was targeted for replacement with --replace"
+	case ReplaceReasonProviderDiff:
+		return fmt.Sprintf("the provider reported that changes to [%s] require replacement", props())
+	case ReplaceReasonReplaceOnChanges:
+		return fmt.Sprintf("the replaceOnChanges resource option covers changed properties [%s]", props())
+	case ReplaceReasonInitErrors:
+		return "the resource failed to initialize and its replaceOnChanges resource option is \"*\""
+	case ReplaceReasonProviderChanged:
+		if len(r.Properties) > 0 {
+			return fmt.Sprintf("changes to [%s] in the configuration of provider '%s' require replacement",
+				props(), r.Upstream)
+		}
+		return fmt.Sprintf("the resource's provider changed to '%s'", r.Upstream)
+	case ReplaceReasonDependency:
+		if len(r.Properties) > 0 {
+			return fmt.Sprintf("dependency '%s' is being deleted before it is replaced, which requires replacing "+
+				"this resource because of its properties [%s]", r.Upstream, props())
+		}
+		return fmt.Sprintf("dependency '%s' is being deleted before it is replaced", r.Upstream)
+	case ReplaceReasonExternal:
+		return "the resource was previously read rather than managed, so it must be created"
+	case ReplaceReasonImport:
+		return "the resource is being imported in place of the existing resource"
+	default:
+		return string(r.Kind)
+	}
+}
+
+// diffReplaceReasons returns the reasons for a replacement that is required by the given resource diff. The diff's
+// replace keys are split between those reported by the provider and those added by the replaceOnChanges option.
+func diffReplaceReasons(providerKeys, replaceKeys []resource.PropertyKey) []ReplaceReason {
+	fromProvider := make(map[resource.PropertyKey]bool)
+	for _, k := range providerKeys {
+		fromProvider[k] = true
+	}
+
+	var reasons []ReplaceReason
+	if len(providerKeys) > 0 {
+		reasons = append(reasons, ReplaceReason{Kind: ReplaceReasonProviderDiff, Properties: providerKeys})
+	}
+
+	var replaceOnChanges []resource.PropertyKey
+	initErrors := false
+	for _, k := range replaceKeys {
+		switch {
+		case fromProvider[k]:
+		case k == initErrorSpecialKey:
+			initErrors = true
+		default:
+			replaceOnChanges = append(replaceOnChanges, k)
+		}
+	}
+	if len(replaceOnChanges) > 0 {
+		reasons = append(reasons, ReplaceReason{Kind: ReplaceReasonReplaceOnChanges, Properties: replaceOnChanges})
+	}
+	if initErrors {
+		reasons = append(reasons, ReplaceReason{Kind: ReplaceReasonInitErrors})
+	}
+
+	// A provider may report a replacement without naming the properties responsible.
+	if len(reasons) == 0 {
+		reasons = append(reasons, ReplaceReason{Kind: ReplaceReasonProviderDiff})
+	}
+	return reasons
+}
@@ -0,0 +1,133 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// RetryPolicies holds the policies that control how the engine retries provider operations that fail with transient
+// errors. A policy set on a resource takes precedence over the policy for its type, which in turn takes precedence over
+// the default policy.
+type RetryPolicies struct {
+	Default *resource.RetryPolicy                 // the policy for resources that have no more specific policy.
+	Types   map[tokens.Type]*resource.RetryPolicy // the policies for particular resource types.
+}
+
+// ValidateRetryPolicy returns an error if the given retry policy is malformed. If the policy is valid, its error
+// patterns are compiled so that they need not be recompiled each time a step fails.
+func ValidateRetryPolicy(policy *resource.RetryPolicy) error {
+	if policy.MaxAttempts < 0 {
+		return errors.New("retry policy maxAttempts must not be negative")
+	}
+	if policy.Backoff < 0 {
+		return errors.New("retry policy backoff must not be negative")
+	}
+	patterns := make([]*regexp.Regexp, len(policy.Errors))
+	for i, pattern := range policy.Errors {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid retryable error pattern %q: %w", pattern, err)
+		}
+		patterns[i] = re
+	}
+	policy.ErrorPatterns = patterns
+	return nil
+}
+
+// isRetryableOp returns true if steps with the given operation call a provider in a way that may be retried.
+func isRetryableOp(op StepOp) bool {
+	switch op {
+	case OpCreate, OpCreateReplacement, OpUpdate, OpDelete, OpDeleteReplaced, OpRead, OpReadReplacement, OpRefresh:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryPolicy returns the retry policy that applies to the given step, or nil if the step must not be retried.
+func (se *stepExecutor) retryPolicy(step Step) *resource.RetryPolicy {
+	if !isRetryableOp(step.Op()) {
+		return nil
+	}
+
+	// Deletes are not driven by a goal, so they can only be governed by the type or default policies.
+	if goal, has := se.deployment.goals.get(step.URN()); has && goal.RetryPolicy != nil {
+		return goal.RetryPolicy
+	}
+	if policies := se.opts.RetryPolicies; policies != nil {
+		if policy, has := policies.Types[step.Type()]; has {
+			return policy
+		}
+		return policies.Default
+	}
+	return nil
+}
+
+// shouldRetry returns true if a step with the given operation that failed with the given status and error on the given
+// attempt may be tried again under the given policy.
+func shouldRetry(policy *resource.RetryPolicy, attempt int, op StepOp, status resource.Status, err error) bool {
+	if policy == nil || attempt >= policy.MaxAttempts {
+		return false
+	}
+
+	// A partial failure means that the provider made changes that have already been recorded in the step's new state,
+	// so the step must not be applied again. A create that failed with an unknown status may have created the resource,
+	// so trying again could leak it; the other operations are safe to repeat.
+	switch {
+	case status == resource.StatusPartialFailure:
+		return false
+	case status == resource.StatusUnknown && (op == OpCreate || op == OpCreateReplacement):
+		return false
+	}
+
+	if len(policy.Errors) == 0 {
+		return true
+	}
+	for _, pattern := range errorPatterns(policy) {
+		if pattern.MatchString(err.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorPatterns returns the compiled error patterns of the given policy. A policy that has not been validated has no
+// compiled patterns, so its patterns are compiled here instead; any that are malformed never match.
+func errorPatterns(policy *resource.RetryPolicy) []*regexp.Regexp {
+	if len(policy.ErrorPatterns) == len(policy.Errors) {
+		return policy.ErrorPatterns
+	}
+	var patterns []*regexp.Regexp
+	for _, pattern := range policy.Errors {
+		if re, err := regexp.Compile(pattern); err == nil {
+			patterns = append(patterns, re)
+		}
+	}
+	return patterns
+}
+
+// retryDelay returns how long to wait before the given retry attempt. The delay doubles with each attempt.
+func retryDelay(policy *resource.RetryPolicy, attempt int) time.Duration {
+	seconds := policy.Backoff * math.Pow(2, float64(attempt-1))
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,39 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func TestShouldRetryUnvalidatedPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := &resource.RetryPolicy{MaxAttempts: 3, Errors: []string{"throttl"}}
+	throttled, denied := errors.New("request throttled"), errors.New("access denied")
+
+	assert.True(t, shouldRetry(policy, 1, OpUpdate, resource.StatusOK, throttled))
+	assert.False(t, shouldRetry(policy, 1, OpUpdate, resource.StatusOK, denied))
+	assert.False(t, shouldRetry(policy, 3, OpUpdate, resource.StatusOK, throttled))
+
+	assert.NoError(t, ValidateRetryPolicy(policy))
+	assert.True(t, shouldRetry(policy, 1, OpUpdate, resource.StatusOK, throttled))
+	assert.False(t, shouldRetry(policy, 1, OpUpdate, resource.StatusOK, denied))
+}
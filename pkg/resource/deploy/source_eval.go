@@ -249,7 +249,10 @@ type defaultProviders struct {
 	// A map of ProviderRequest strings to provider references, used to keep track of the set of default providers that
 	// have already been loaded.
 	providers map[string]providers.Reference
-	config    plugin.ConfigSource
+	// A map of ProviderRequest strings to errors, used to keep track of the set of default providers that failed to
+	// register. This is only populated if the deployment continues after errors.
+	failures map[string]error
+	config   plugin.ConfigSource
 
 	requests        chan defaultProviderRequest
 	providerRegChan chan<- *registerResourceEvent
@@ -325,7 +328,7 @@ func (d *defaultProviders) newRegisterDefaultProviderEvent(
 	event := &registerResourceEvent{
 		goal: resource.NewGoal(
 			providers.MakeProviderType(req.Package()),
-			req.Name(), true, inputs, "", false, nil, "", nil, nil, nil, nil, nil, nil, "", nil, nil),
+			req.Name(), true, inputs, "", false, nil, "", nil, nil, nil, nil, nil, nil, "", nil, nil, false, "", nil, 0, false),
 		done: done,
 	}
 	return event, done, nil
@@ -351,6 +354,9 @@ func (d *defaultProviders) handleRequest(req providers.ProviderRequest) (provide
 	if ok {
 		return ref, nil
 	}
+	if err, failed := d.failures[req.String()]; failed {
+		return providers.Reference{}, err
+	}
 
 	event, done, err := d.newRegisterDefaultProviderEvent(req)
 	if err != nil {
@@ -372,6 +378,12 @@ func (d *defaultProviders) handleRequest(req providers.ProviderRequest) (provide
 		return providers.Reference{}, context.Canceled
 	}
 
+	if result.Failed {
+		err = fmt.Errorf("default provider for package %s was not registered because its deployment step failed", req)
+		d.failures[req.String()] = err
+		return providers.Reference{}, err
+	}
+
 	logging.V(5).Infof("registered default provider for package %s: %s", req, result.State.URN)
 
 	id := result.State.ID
@@ -426,6 +438,7 @@ type resmon struct {
 	done                      chan error                         // a channel that resolves when the server completes.
 	disableResourceReferences bool                               // true if resource references are disabled.
 	disableOutputValues       bool                               // true if output values are disabled.
+	limiter                   *concurrencyLimiter                // the limiter for concurrent provider operations.
 }
 
 var _ SourceResourceMonitor = (*resmon)(nil)
@@ -442,6 +455,7 @@ func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *reg
 	d := &defaultProviders{
 		defaultProviderInfo: src.defaultProviderInfo,
 		providers:           make(map[string]providers.Reference),
+		failures:            make(map[string]error),
 		config:              src.runinfo.Target,
 		requests:            make(chan defaultProviderRequest),
 		providerRegChan:     regChan,
@@ -458,6 +472,7 @@ func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *reg
 		cancel:                    cancel,
 		disableResourceReferences: opts.DisableResourceReferences,
 		disableOutputValues:       opts.DisableOutputValues,
+		limiter:                   opts.limiter,
 	}
 
 	// Fire up a gRPC server and start listening for incomings.
@@ -582,10 +597,14 @@ func (rm *resmon) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pu
 	if err != nil {
 		return nil, err
 	}
-	prov, err := getProviderFromSource(rm.providers, rm.defaultProviders, providerReq, req.GetProvider())
+	providerRef, err := getProviderReference(rm.defaultProviders, providerReq, req.GetProvider())
 	if err != nil {
 		return nil, err
 	}
+	prov, ok := rm.providers.GetProvider(providerRef)
+	if !ok {
+		return nil, fmt.Errorf("unknown provider '%v'", req.GetProvider())
+	}
 
 	label := fmt.Sprintf("ResourceMonitor.Invoke(%s)", tok)
 
@@ -602,7 +621,12 @@ func (rm *resmon) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pu
 
 	// Do the invoke and then return the arguments.
 	logging.V(5).Infof("ResourceMonitor.Invoke received: tok=%v #args=%v", tok, len(args))
+	release, err := rm.limiter.acquire(ctx, providerRef.String(), "")
+	if err != nil {
+		return nil, err
+	}
 	ret, failures, err := prov.Invoke(tok, args)
+	release()
 	if err != nil {
 		return nil, fmt.Errorf("invocation of %v returned an error: %w", tok, err)
 	}
@@ -844,6 +868,9 @@ func (rm *resmon) ReadResource(ctx context.Context,
 	}
 
 	contract.Assert(result != nil)
+	if result.Failed {
+		return nil, fmt.Errorf("resource '%s' of type %s was not read because its deployment step failed", name, t)
+	}
 	marshaled, err := plugin.MarshalProperties(result.State.Outputs, plugin.MarshalOptions{
 		Label:         label,
 		KeepUnknowns:  true,
@@ -873,8 +900,13 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	deleteBeforeReplaceValue := req.GetDeleteBeforeReplace()
 	ignoreChanges := req.GetIgnoreChanges()
 	replaceOnChanges := req.GetReplaceOnChanges()
+	retainOnDelete := req.GetRetainOnDelete()
+	deletedWith := resource.URN(req.GetDeletedWith())
+	maxConcurrency := int(req.GetMaxConcurrency())
+	refuseReplace := req.GetRefuseReplace()
 	id := resource.ID(req.GetImportId())
 	customTimeouts := req.GetCustomTimeouts()
+	retry := req.GetRetryPolicy()
 
 	// Custom resources must have a three-part type so that we can 1) identify if they are providers and 2) retrieve the
 	// provider responsible for managing a particular resource (based on the type's Package).
@@ -976,6 +1008,12 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		additionalSecretOutputs = append(additionalSecretOutputs, resource.PropertyKey(name))
 	}
 
+	if maxConcurrency < 0 || maxConcurrency > 0 && !providers.IsProviderType(t) {
+		return nil, rpcerror.New(codes.InvalidArgument,
+			fmt.Sprintf("maxConcurrency may only be set to a positive number on provider resources; got %d on %v",
+				maxConcurrency, t))
+	}
+
 	var timeouts resource.CustomTimeouts
 	if customTimeouts != nil {
 		if customTimeouts.Create != "" {
@@ -1001,6 +1039,24 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		}
 	}
 
+	var retryPolicy *resource.RetryPolicy
+	if retry != nil {
+		retryPolicy = &resource.RetryPolicy{
+			MaxAttempts: int(retry.GetMaxAttempts()),
+			Errors:      retry.GetErrors(),
+		}
+		if retry.GetBackoff() != "" {
+			duration, err := time.ParseDuration(retry.GetBackoff())
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse retry backoff value %s", retry.GetBackoff())
+			}
+			retryPolicy.Backoff = duration.Seconds()
+		}
+		if err := ValidateRetryPolicy(retryPolicy); err != nil {
+			return nil, err
+		}
+	}
+
 	var deleteBeforeReplace *bool
 	if deleteBeforeReplaceValue || req.GetDeleteBeforeReplaceDefined() {
 		deleteBeforeReplace = &deleteBeforeReplaceValue
@@ -1009,9 +1065,11 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	logging.V(5).Infof(
 		"ResourceMonitor.RegisterResource received: t=%v, name=%v, custom=%v, #props=%v, parent=%v, protect=%v, "+
 			"provider=%v, deps=%v, deleteBeforeReplace=%v, ignoreChanges=%v, aliases=%v, customTimeouts=%v, "+
-			"providers=%v, replaceOnChanges=%v",
+			"providers=%v, replaceOnChanges=%v, retainOnDelete=%v, deletedWith=%v, retryPolicy=%v, maxConcurrency=%v, "+
+			"refuseReplace=%v",
 		t, name, custom, len(props), parent, protect, providerRef, dependencies, deleteBeforeReplace, ignoreChanges,
-		aliases, timeouts, providerRefs, replaceOnChanges)
+		aliases, timeouts, providerRefs, replaceOnChanges, retainOnDelete, deletedWith, retryPolicy, maxConcurrency,
+		refuseReplace)
 
 	// If this is a remote component, fetch its provider and issue the construct call. Otherwise, register the resource.
 	var result *RegisterResult
@@ -1049,7 +1107,8 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		step := &registerResourceEvent{
 			goal: resource.NewGoal(t, name, custom, props, parent, protect, dependencies,
 				providerRef.String(), nil, propertyDependencies, deleteBeforeReplace, ignoreChanges,
-				additionalSecretOutputs, aliases, id, &timeouts, replaceOnChanges),
+				additionalSecretOutputs, aliases, id, &timeouts, replaceOnChanges, retainOnDelete, deletedWith,
+				retryPolicy, maxConcurrency, refuseReplace),
 			done: make(chan *RegisterResult),
 		}
 
@@ -1067,6 +1126,11 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 			logging.V(5).Infof("ResourceMonitor.RegisterResource operation canceled, name=%s", name)
 			return nil, rpcerror.New(codes.Unavailable, "resource monitor shut down while waiting on step's done channel")
 		}
+
+		if result.Failed {
+			return nil, fmt.Errorf("resource '%s' of type %s was not registered because its deployment step failed",
+				name, t)
+		}
 	}
 
 	// Filter out partially-known values if the requestor does not support them.
@@ -155,6 +155,7 @@ type CreateStep struct {
 	detailedDiff  map[string]plugin.PropertyDiff // the structured property diff (only for replacements).
 	replacing     bool                           // true if this is a create due to a replacement.
 	pendingDelete bool                           // true if this replacement should create a pending delete.
+	reasons       []ReplaceReason                // the reasons for the replacement (only for replacements).
 }
 
 var _ Step = (*CreateStep)(nil)
@@ -218,6 +219,7 @@ func (s *CreateStep) Keys() []resource.PropertyKey                 { return s.ke
 func (s *CreateStep) Diffs() []resource.PropertyKey                { return s.diffs }
 func (s *CreateStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
 func (s *CreateStep) Logical() bool                                { return !s.replacing }
+func (s *CreateStep) ReplaceReasons() []ReplaceReason              { return s.reasons }
 
 func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	var resourceError error
@@ -267,25 +269,31 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 // DeleteStep is a mutating step that deletes an existing resource. If `old` is marked "External",
 // DeleteStep is a no-op.
 type DeleteStep struct {
-	deployment *Deployment     // the current deployment.
-	old        *resource.State // the state of the existing resource.
-	replacing  bool            // true if part of a replacement.
+	deployment     *Deployment           // the current deployment.
+	otherDeletions map[resource.URN]bool // the other resources that are being deleted in this deployment.
+	old            *resource.State       // the state of the existing resource.
+	replacing      bool                  // true if part of a replacement.
+	retainOnDelete bool                  // true if the resource should only be removed from the snapshot.
+	reasons        []ReplaceReason       // the reasons for the replacement (only for replacements).
 }
 
 var _ Step = (*DeleteStep)(nil)
 
-func NewDeleteStep(deployment *Deployment, old *resource.State) Step {
+func NewDeleteStep(deployment *Deployment, otherDeletions map[resource.URN]bool, old *resource.State) Step {
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
 	contract.Assert(old.ID != "" || !old.Custom)
 	contract.Assert(!old.Custom || old.Provider != "" || providers.IsProviderType(old.Type))
 	return &DeleteStep{
-		deployment: deployment,
-		old:        old,
+		deployment:     deployment,
+		otherDeletions: otherDeletions,
+		old:            old,
+		retainOnDelete: old.RetainOnDelete,
 	}
 }
 
-func NewDeleteReplacementStep(deployment *Deployment, old *resource.State, pendingReplace bool) Step {
+func NewDeleteReplacementStep(deployment *Deployment, otherDeletions map[resource.URN]bool, old *resource.State,
+	pendingReplace bool) Step {
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
 	contract.Assert(old.ID != "" || !old.Custom)
@@ -306,9 +314,11 @@ func NewDeleteReplacementStep(deployment *Deployment, old *resource.State, pendi
 	contract.Assert(pendingReplace != old.Delete)
 	old.PendingReplacement = pendingReplace
 	return &DeleteStep{
-		deployment: deployment,
-		old:        old,
-		replacing:  true,
+		deployment:     deployment,
+		otherDeletions: otherDeletions,
+		old:            old,
+		replacing:      true,
+		retainOnDelete: old.RetainOnDelete,
 	}
 }
 
@@ -325,14 +335,19 @@ func (s *DeleteStep) Op() StepOp {
 	}
 	return OpDelete
 }
-func (s *DeleteStep) Deployment() *Deployment { return s.deployment }
-func (s *DeleteStep) Type() tokens.Type       { return s.old.Type }
-func (s *DeleteStep) Provider() string        { return s.old.Provider }
-func (s *DeleteStep) URN() resource.URN       { return s.old.URN }
-func (s *DeleteStep) Old() *resource.State    { return s.old }
-func (s *DeleteStep) New() *resource.State    { return nil }
-func (s *DeleteStep) Res() *resource.State    { return s.old }
-func (s *DeleteStep) Logical() bool           { return !s.replacing }
+func (s *DeleteStep) Deployment() *Deployment         { return s.deployment }
+func (s *DeleteStep) Type() tokens.Type               { return s.old.Type }
+func (s *DeleteStep) Provider() string                { return s.old.Provider }
+func (s *DeleteStep) URN() resource.URN               { return s.old.URN }
+func (s *DeleteStep) Old() *resource.State            { return s.old }
+func (s *DeleteStep) New() *resource.State            { return nil }
+func (s *DeleteStep) Res() *resource.State            { return s.old }
+func (s *DeleteStep) Logical() bool                   { return !s.replacing }
+func (s *DeleteStep) ReplaceReasons() []ReplaceReason { return s.reasons }
+
+// RetainOnDelete returns true if this step only removes the resource from the snapshot rather than asking its provider
+// to delete it.
+func (s *DeleteStep) RetainOnDelete() bool { return s.retainOnDelete }
 
 func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// Refuse to delete protected resources.
@@ -344,8 +359,11 @@ func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			"`pulumi state unprotect '%s'`", s.old.URN, s.old.URN)
 	}
 
-	// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle.
-	if !preview && !s.old.External {
+	// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle. Resources marked with
+	// RetainOnDelete are only removed from the snapshot; the provider is never asked to delete them. The same is
+	// true of resources whose DeletedWith resource is being deleted in this deployment, as deleting that resource
+	// also deletes this one.
+	if !preview && !s.old.External && !s.retainOnDelete && !s.isDeletedWith() {
 		if s.old.Custom {
 			// Invoke the Delete RPC function for this provider:
 			prov, err := getProvider(s)
@@ -362,6 +380,11 @@ func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	return resource.StatusOK, func() {}, nil
 }
 
+// isDeletedWith returns true if the resource named by this resource's DeletedWith option is also being deleted.
+func (s *DeleteStep) isDeletedWith() bool {
+	return s.old.DeletedWith != "" && s.otherDeletions[s.old.DeletedWith]
+}
+
 type RemovePendingReplaceStep struct {
 	deployment *Deployment     // the current deployment.
 	old        *resource.State // the state of the existing resource.
@@ -498,6 +521,7 @@ type ReplaceStep struct {
 	diffs         []resource.PropertyKey         // the keys causing a diff.
 	detailedDiff  map[string]plugin.PropertyDiff // the structured property diff.
 	pendingDelete bool                           // true if a pending deletion should happen.
+	reasons       []ReplaceReason                // the reasons for the replacement.
 }
 
 var _ Step = (*ReplaceStep)(nil)
@@ -536,6 +560,7 @@ func (s *ReplaceStep) Keys() []resource.PropertyKey                 { return s.k
 func (s *ReplaceStep) Diffs() []resource.PropertyKey                { return s.diffs }
 func (s *ReplaceStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
 func (s *ReplaceStep) Logical() bool                                { return true }
+func (s *ReplaceStep) ReplaceReasons() []ReplaceReason              { return s.reasons }
 
 func (s *ReplaceStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// If this is a pending delete, we should have marked the old resource for deletion in the CreateReplacement step.
@@ -773,7 +798,7 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 		s.new = resource.NewState(s.old.Type, s.old.URN, s.old.Custom, s.old.Delete, resourceID, inputs, outputs,
 			s.old.Parent, s.old.Protect, s.old.External, s.old.Dependencies, initErrors, s.old.Provider,
 			s.old.PropertyDependencies, s.old.PendingReplacement, s.old.AdditionalSecretOutputs, s.old.Aliases,
-			&s.old.CustomTimeouts, s.old.ImportID)
+			&s.old.CustomTimeouts, s.old.ImportID, s.old.RetainOnDelete, s.old.DeletedWith)
 	} else {
 		s.new = nil
 	}
@@ -792,6 +817,7 @@ type ImportStep struct {
 	diffs         []resource.PropertyKey         // any keys that differed between the user's program and the actual state.
 	detailedDiff  map[string]plugin.PropertyDiff // the structured property diff.
 	ignoreChanges []string                       // a list of property paths to ignore when updating.
+	reasons       []ReplaceReason                // the reasons for the replacement (only for replacements).
 }
 
 func NewImportStep(deployment *Deployment, reg RegisterResourceEvent, new *resource.State,
@@ -864,6 +890,7 @@ func (s *ImportStep) Old() *resource.State                         { return s.ol
 func (s *ImportStep) New() *resource.State                         { return s.new }
 func (s *ImportStep) Res() *resource.State                         { return s.new }
 func (s *ImportStep) Logical() bool                                { return !s.replacing }
+func (s *ImportStep) ReplaceReasons() []ReplaceReason              { return s.reasons }
 func (s *ImportStep) Diffs() []resource.PropertyKey                { return s.diffs }
 func (s *ImportStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
 
@@ -915,7 +942,8 @@ func (s *ImportStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	// differences between the old and new states are between the inputs and outputs.
 	s.old = resource.NewState(s.new.Type, s.new.URN, s.new.Custom, false, s.new.ID, read.Inputs, read.Outputs,
 		s.new.Parent, s.new.Protect, false, s.new.Dependencies, s.new.InitErrors, s.new.Provider,
-		s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts, s.new.ImportID)
+		s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts, s.new.ImportID, s.new.RetainOnDelete,
+		s.new.DeletedWith)
 
 	// If this step came from an import deployment, we need to fetch any required inputs from the state.
 	if s.planned {
@@ -1181,3 +1209,30 @@ func getProvider(s Step) (plugin.Provider, error) {
 	}
 	return provider, nil
 }
+
+// failStep notifies the source event that produced the given step, if any, that the step failed or was skipped. This
+// unblocks the program so that it can observe the failure rather than waiting on a step that will never complete.
+func failStep(s Step) {
+	switch s := s.(type) {
+	case *SameStep:
+		if s.reg != nil {
+			s.reg.Done(&RegisterResult{State: s.new, Failed: true})
+		}
+	case *CreateStep:
+		if s.reg != nil {
+			s.reg.Done(&RegisterResult{State: s.new, Failed: true})
+		}
+	case *UpdateStep:
+		if s.reg != nil {
+			s.reg.Done(&RegisterResult{State: s.new, Failed: true})
+		}
+	case *ImportStep:
+		if s.reg != nil {
+			s.reg.Done(&RegisterResult{State: s.new, Failed: true})
+		}
+	case *ReadStep:
+		if s.event != nil {
+			s.event.Done(&ReadResult{State: s.new, Failed: true})
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
@@ -41,6 +42,9 @@ var (
 	// We (the step executor) are not responsible for reporting those errors so this sentinel ensures
 	// that we don't do so.
 	errStepApplyFailed = errors.New("step application failed")
+
+	// ErrSkipStep is returned by StepExecutorEvents.OnResourceStepPre to ask the step executor to skip a step.
+	ErrSkipStep = errors.New("step skipped")
 )
 
 // The step executor operates in terms of "chains" and "antichains". A chain is set of steps that are totally ordered
@@ -90,6 +94,7 @@ type stepExecutor struct {
 	preview         bool        // Whether or not we are doing a preview.
 	pendingNews     sync.Map    // Resources that have been created but are pending a RegisterResourceOutputs.
 	continueOnError bool        // True if we want to continue the deployment after a step error.
+	failedSteps     sync.Map    // URNs of steps that failed or were skipped when continuing on error, mapped to errors.
 
 	workers        sync.WaitGroup     // WaitGroup tracking the worker goroutines that are owned by this step executor.
 	incomingChains chan incomingChain // Incoming chains that we are to execute
@@ -187,6 +192,24 @@ func (se *stepExecutor) Errored() bool {
 	return se.sawError.Load().(bool)
 }
 
+// Failures returns the URNs of all resources whose steps failed or were skipped during a deployment that continues on
+// error, along with the error that caused each failure.
+func (se *stepExecutor) Failures() map[resource.URN]error {
+	failures := make(map[resource.URN]error)
+	se.failedSteps.Range(func(k, v interface{}) bool {
+		failures[k.(resource.URN)] = v.(error)
+		return true
+	})
+	return failures
+}
+
+// HasFailed returns true if the step for the given URN failed or was skipped during a deployment that continues on
+// error.
+func (se *stepExecutor) HasFailed(urn resource.URN) bool {
+	_, has := se.failedSteps.Load(urn)
+	return has
+}
+
 // SignalCompletion signals to the stepExecutor that there are no more chains left to execute. All worker
 // threads will terminate as soon as they retire all of the work they are currently executing.
 func (se *stepExecutor) SignalCompletion() {
@@ -210,7 +233,7 @@ func (se *stepExecutor) WaitForCompletion() {
 // executeChain executes a chain, one step at a time. If any step in the chain fails to execute, or if the
 // context is canceled, the chain stops execution.
 func (se *stepExecutor) executeChain(workerID int, chain chain) {
-	for _, step := range chain {
+	for i, step := range chain {
 		select {
 		case <-se.ctx.Done():
 			se.log(workerID, "step %v on %v canceled", step.Op(), step.URN())
@@ -230,11 +253,31 @@ func (se *stepExecutor) executeChain(workerID int, chain chain) {
 				diagMsg := diag.RawMessage(step.URN(), err.Error())
 				se.deployment.Diag().Errorf(diagMsg)
 			}
+			if se.continueOnError {
+				se.failChain(workerID, chain[i:], err)
+			}
 			return
 		}
 	}
 }
 
+// failChain records the failure of the first step in the given chain and the skipping of the remainder, and unblocks
+// any source events that are waiting on those steps so that the program can observe the failure.
+func (se *stepExecutor) failChain(workerID int, chain chain, err error) {
+	if err == errStepApplyFailed {
+		if stepErr, has := se.failedSteps.Load(chain[0].URN()); has {
+			err = stepErr.(error)
+		}
+	}
+	for i, step := range chain {
+		if i > 0 {
+			se.log(workerID, "step %v on %v skipped due to earlier failure", step.Op(), step.URN())
+		}
+		se.failedSteps.Store(step.URN(), err)
+		failStep(step)
+	}
+}
+
 func (se *stepExecutor) cancelDueToError() {
 	se.sawError.Store(true)
 	if !se.continueOnError {
@@ -261,6 +304,9 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	if events != nil {
 		var err error
 		payload, err = events.OnResourceStepPre(step)
+		if err == ErrSkipStep {
+			return se.skipStep(workerID, step)
+		}
 		if err != nil {
 			se.log(workerID, "step %v on %v failed pre-resource step: %v", step.Op(), step.URN(), err)
 			return fmt.Errorf("pre-step event returned an error: %w", err)
@@ -268,9 +314,12 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	}
 
 	se.log(workerID, "applying step %v on %v (preview %v)", step.Op(), step.URN(), se.preview)
-	status, stepComplete, err := step.Apply(se.preview)
+	start := time.Now()
+	status, stepComplete, err := se.applyStep(workerID, step)
 
 	if err == nil {
+		recordStepDuration(step, time.Since(start), se.preview)
+
 		// If we have a state object, and this is a create or update, remember it, as we may need to update it later.
 		if step.Logical() && step.New() != nil {
 			if prior, has := se.pendingNews.Load(step.URN()); has {
@@ -305,20 +354,103 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	}
 
 	// Calling stepComplete allows steps that depend on this step to continue. OnResourceStepPost saved the results
-	// of the step in the snapshot, so we are ready to go.
-	if stepComplete != nil {
+	// of the step in the snapshot, so we are ready to go. If we are continuing after errors, a failed step must not
+	// unblock its dependents; failChain will report the failure to the source instead.
+	if stepComplete != nil && (err == nil || !se.continueOnError) {
 		se.log(workerID, "step %v on %v retired", step.Op(), step.URN())
 		stepComplete()
 	}
 
 	if err != nil {
 		se.log(workerID, "step %v on %v failed with an error: %v", step.Op(), step.URN(), err)
+		if se.continueOnError {
+			se.failedSteps.Store(step.URN(), err)
+		}
 		return errStepApplyFailed
 	}
 
 	return nil
 }
 
+// skipStep leaves the resource of a skipped step unchanged. A step that would have produced a new state for the
+// resource is replaced with a same step that retains the resource's old state, just as an untargeted update would be,
+// so that the resource's registration completes with its existing outputs. Any other step is simply not applied.
+func (se *stepExecutor) skipStep(workerID int, step Step) error {
+	se.log(workerID, "step %v on %v skipped", step.Op(), step.URN())
+
+	var reg RegisterResourceEvent
+	switch s := step.(type) {
+	case *UpdateStep:
+		reg = s.reg
+	case *CreateStep:
+		reg = s.reg
+	}
+	old, new := step.Old(), step.New()
+	if reg == nil || old == nil || new == nil || old.Delete {
+		return nil
+	}
+
+	// Unlike an untargeted update, retain the old inputs as well, so that the skipped changes are planned again by
+	// the next deployment.
+	skipped := *new
+	skipped.Inputs = old.Inputs
+	return se.executeStep(workerID, NewSameStep(se.deployment, reg, old, &skipped))
+}
+
+// applyStep applies a single step. If the step fails with an error that its retry policy considers transient, the
+// step is applied again after a delay until it succeeds or the policy's attempts are exhausted.
+func (se *stepExecutor) applyStep(workerID int, step Step) (resource.Status, StepCompleteFunc, error) {
+	policy := se.retryPolicy(step)
+	for attempt := 1; ; attempt++ {
+		release := func() {}
+		if callsProvider(step.Op()) {
+			// Limits are never acquired with a cancellable context: every holder releases its limits once its step
+			// completes, so waiting here cannot block forever.
+			var err error
+			release, err = se.opts.limiter.acquire(context.Background(), step.Provider(), step.Type())
+			contract.AssertNoError(err)
+		}
+		status, stepComplete, err := step.Apply(se.preview)
+		release()
+		if err == nil || !shouldRetry(policy, attempt, step.Op(), status, err) {
+			return status, stepComplete, err
+		}
+
+		delay := retryDelay(policy, attempt)
+		se.log(workerID, "step %v on %v failed on attempt %d, retrying in %v: %v",
+			step.Op(), step.URN(), attempt, delay, err)
+		se.deployment.Diag().Warningf(diag.RawMessage(step.URN(), fmt.Sprintf(
+			"%s failed on attempt %d of %d, retrying in %v: %v", step.Op(), attempt, policy.MaxAttempts, delay, err)))
+
+		select {
+		case <-time.After(delay):
+		case <-se.ctx.Done():
+			return status, stepComplete, err
+		}
+	}
+}
+
+// recordStepDuration records the time taken by a step that created or updated a resource in the resource's new state,
+// so that later deployments can estimate their progress. Other steps carry the duration over from the old state.
+func recordStepDuration(step Step, elapsed time.Duration, preview bool) {
+	new := step.New()
+	if new == nil {
+		return
+	}
+
+	switch step.Op() {
+	case OpCreate, OpCreateReplacement, OpUpdate, OpImport, OpImportReplacement:
+		if !preview {
+			new.StepDuration = elapsed
+			return
+		}
+	}
+
+	if old := step.Old(); old != nil && old != new && new.StepDuration == 0 {
+		new.StepDuration = old.StepDuration
+	}
+}
+
 // log is a simple logging helper for the step executor.
 func (se *stepExecutor) log(workerID int, msg string, args ...interface{}) {
 	if logging.V(stepExecutorLogLevel) {
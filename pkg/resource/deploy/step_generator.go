@@ -17,6 +17,7 @@ package deploy
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/v3/resource/graph"
@@ -64,6 +65,19 @@ type stepGenerator struct {
 	// delete-before-replace.
 	dependentReplaceKeys map[resource.URN][]resource.PropertyKey
 
+	// a map from URN to the reasons that the resource is being replaced, for resources that are being replaced.
+	replaceReasons map[resource.URN][]ReplaceReason
+
+	// set of URNs whose deletion or replacement was refused by a change guard.
+	refused map[resource.URN]bool
+
+	// a map from the old states (not URNs!) of replaced resources to the RetainOnDelete option that the program
+	// currently specifies for them, which governs the deletion of the old resource.
+	replaceRetainOnDelete map[*resource.State]bool
+
+	// a map from each analyzer to whether its policy pack contains any remediation policies.
+	remediators map[plugin.Analyzer]bool
+
 	// a map from old names (aliased URNs) to the new URN that aliased to them.
 	aliased map[resource.URN]resource.URN
 }
@@ -129,9 +143,11 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, res
 		nil,   /* propertyDependencies */
 		false, /* deleteBeforeCreate */
 		event.AdditionalSecretOutputs(),
-		nil, /* aliases */
-		nil, /* customTimeouts */
-		"",  /* importID */
+		nil,   /* aliases */
+		nil,   /* customTimeouts */
+		"",    /* importID */
+		false, /* retainOnDelete */
+		"",    /* deletedWith */
 	)
 	old, hasOld := sg.deployment.Olds()[urn]
 
@@ -179,6 +195,10 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, res
 		contract.Assert(len(steps) == 0)
 		return nil, res
 	}
+	sg.attachReplaceReasons(steps)
+	if sg.refuseGuardedSteps(steps, event.Goal().RefuseReplace) && !sg.deployment.preview {
+		return nil, result.Bail()
+	}
 	if !sg.isTargetedUpdate() {
 		return steps, nil
 	}
@@ -222,6 +242,87 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, res
 	return steps, nil
 }
 
+// analyzerResource returns the view of a resource with the given inputs that is sent to analyzers.
+func (sg *stepGenerator) analyzerResource(new *resource.State, goal *resource.Goal,
+	inputs resource.PropertyMap) plugin.AnalyzerResource {
+
+	r := plugin.AnalyzerResource{
+		URN:        new.URN,
+		Type:       new.Type,
+		Name:       new.URN.Name(),
+		Properties: inputs,
+		Options: plugin.AnalyzerResourceOptions{
+			Protect:                 new.Protect,
+			IgnoreChanges:           goal.IgnoreChanges,
+			DeleteBeforeReplace:     goal.DeleteBeforeReplace,
+			AdditionalSecretOutputs: new.AdditionalSecretOutputs,
+			Aliases:                 new.Aliases,
+			CustomTimeouts:          new.CustomTimeouts,
+		},
+	}
+	providerResource := sg.getProviderResource(new.URN, new.Provider)
+	if providerResource != nil {
+		r.Provider = &plugin.AnalyzerProviderResource{
+			URN:        providerResource.URN,
+			Type:       providerResource.Type,
+			Name:       providerResource.URN.Name(),
+			Properties: providerResource.Inputs,
+		}
+	}
+	return r
+}
+
+// remediate sends a resource's inputs to any analyzers' remediation policies and returns the remediated inputs. Each
+// remediation that changes the inputs is reported, with the inputs before and after it was applied.
+func (sg *stepGenerator) remediate(new *resource.State, goal *resource.Goal,
+	inputs resource.PropertyMap) (resource.PropertyMap, result.Result) {
+
+	for _, analyzer := range sg.deployment.ctx.Host.ListAnalyzers() {
+		remediates, err := sg.hasRemediationPolicies(analyzer)
+		if err != nil {
+			return nil, result.FromError(err)
+		}
+		if !remediates {
+			continue
+		}
+
+		remediations, err := analyzer.Remediate(sg.analyzerResource(new, goal, inputs))
+		if err != nil {
+			return nil, result.FromError(err)
+		}
+		for _, t := range remediations {
+			if t.Properties == nil || t.Properties.DeepEquals(inputs) {
+				continue
+			}
+			sg.opts.Events.OnPolicyRemediation(new.URN, t, inputs, t.Properties)
+			inputs = t.Properties
+		}
+	}
+	return inputs, nil
+}
+
+// hasRemediationPolicies returns true if the given analyzer's policy pack contains any remediation policies. The
+// analyzer is only asked for its policies once per deployment.
+func (sg *stepGenerator) hasRemediationPolicies(analyzer plugin.Analyzer) (bool, error) {
+	if remediates, has := sg.remediators[analyzer]; has {
+		return remediates, nil
+	}
+
+	info, err := analyzer.GetAnalyzerInfo()
+	if err != nil {
+		return false, err
+	}
+	remediates := false
+	for _, policy := range info.Policies {
+		if policy.EnforcementLevel == apitype.Remediate {
+			remediates = true
+			break
+		}
+	}
+	sg.remediators[analyzer] = remediates
+	return remediates, nil
+}
+
 func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, result.Result) {
 	var invalid bool // will be set to true if this object fails validation.
 
@@ -235,6 +336,11 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 	}
 	sg.urns[urn] = true
 
+	// If this is a provider with a concurrency limit, record the limit before any resources can use the provider.
+	if providers.IsProviderType(goal.Type) {
+		sg.opts.limiter.setProviderLimit(urn, goal.MaxConcurrency)
+	}
+
 	// Check for an old resource so that we can figure out if this is a create, delete, etc., and/or
 	// to diff.  We look up first by URN and then by any provided aliases.  If it is found using an
 	// alias, record that alias so that we do not delete the aliased resource later.
@@ -276,7 +382,7 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 	// get serialized into the checkpoint file.
 	new := resource.NewState(goal.Type, urn, goal.Custom, false, "", inputs, nil, goal.Parent, goal.Protect, false,
 		goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
-		goal.AdditionalSecretOutputs, goal.Aliases, &goal.CustomTimeouts, "")
+		goal.AdditionalSecretOutputs, goal.Aliases, &goal.CustomTimeouts, "", goal.RetainOnDelete, goal.DeletedWith)
 
 	// Mark the URN/resource as having been seen. So we can run analyzers on all resources seen, as well as
 	// lookup providers for calculating replacement of resources that use the provider.
@@ -318,6 +424,7 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		new.ID = goal.ID
 		new.ImportID = goal.ID
 		if isReplace := hasOld && !recreating; isReplace {
+			sg.replaceReasons[urn] = []ReplaceReason{{Kind: ReplaceReasonImport}}
 			return []Step{
 				NewImportReplacementStep(sg.deployment, event, old, new, goal.IgnoreChanges),
 				NewReplaceStep(sg.deployment, old, new, nil, nil, nil, true),
@@ -335,60 +442,50 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		// invalid (they got deleted) so don't consider them. Similarly, if the old resource was External,
 		// don't consider those inputs since Pulumi does not own them. Finally, if the resource has been
 		// targeted for replacement, ignore its old state.
+		checkOlds, checkNews := oldInputs, inputs
 		if recreating || wasExternal || sg.isTargetedReplace(urn) {
-			inputs, failures, err = prov.Check(urn, nil, goal.Properties, allowUnknowns)
-		} else {
-			inputs, failures, err = prov.Check(urn, oldInputs, inputs, allowUnknowns)
+			checkOlds, checkNews = nil, goal.Properties
+		}
+
+		// Apply any policy remediations first, so that the provider checks the remediated inputs.
+		checkNews, res = sg.remediate(new, goal, checkNews)
+		if res != nil {
+			return nil, res
 		}
 
+		inputs, failures, err = prov.Check(urn, checkOlds, checkNews, allowUnknowns)
 		if err != nil {
 			return nil, result.FromError(err)
 		} else if issueCheckErrors(sg.deployment, new, urn, failures) {
 			invalid = true
 		}
 		new.Inputs = inputs
+	} else {
+		inputs, res = sg.remediate(new, goal, inputs)
+		if res != nil {
+			return nil, res
+		}
+		new.Inputs = inputs
 	}
 
 	// Send the resource off to any Analyzers before being operated on.
 	analyzers := sg.deployment.ctx.Host.ListAnalyzers()
 	for _, analyzer := range analyzers {
-		r := plugin.AnalyzerResource{
-			URN:        new.URN,
-			Type:       new.Type,
-			Name:       new.URN.Name(),
-			Properties: inputs,
-			Options: plugin.AnalyzerResourceOptions{
-				Protect:                 new.Protect,
-				IgnoreChanges:           goal.IgnoreChanges,
-				DeleteBeforeReplace:     goal.DeleteBeforeReplace,
-				AdditionalSecretOutputs: new.AdditionalSecretOutputs,
-				Aliases:                 new.Aliases,
-				CustomTimeouts:          new.CustomTimeouts,
-			},
-		}
-		providerResource := sg.getProviderResource(new.URN, new.Provider)
-		if providerResource != nil {
-			r.Provider = &plugin.AnalyzerProviderResource{
-				URN:        providerResource.URN,
-				Type:       providerResource.Type,
-				Name:       providerResource.URN.Name(),
-				Properties: providerResource.Inputs,
-			}
-		}
-
+		r := sg.analyzerResource(new, goal, inputs)
 		diagnostics, err := analyzer.Analyze(r)
 		if err != nil {
 			return nil, result.FromError(err)
 		}
 		for _, d := range diagnostics {
+			// For now, we always use the URN we have here rather than a URN specified with the diagnostic.
+			d, waiver := sg.waivePolicyViolation(new.URN, d)
 			if d.EnforcementLevel == apitype.Mandatory {
 				if !sg.deployment.preview {
 					invalid = true
 				}
 				sg.sawError = true
 			}
-			// For now, we always use the URN we have here rather than a URN specified with the diagnostic.
-			sg.opts.Events.OnPolicyViolation(new.URN, d)
+			sg.opts.Events.OnPolicyViolation(new.URN, d, waiver)
 		}
 	}
 
@@ -436,6 +533,7 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 	if wasExternal {
 		logging.V(7).Infof("Planner recognized '%s' as old external resource, creating instead", urn)
 		sg.creates[urn] = true
+		sg.replaceReasons[urn] = []ReplaceReason{{Kind: ReplaceReasonExternal}}
 		if err != nil {
 			return nil, result.FromError(err)
 		}
@@ -558,6 +656,7 @@ func (sg *stepGenerator) generateStepsFromDiff(
 	hasInitErrors := len(old.InitErrors) > 0
 
 	// Update the diff to apply any replaceOnChanges annotations and to include initErrors in the diff.
+	providerReplaceKeys := diff.ReplaceKeys
 	diff, err = applyReplaceOnChanges(diff, goal.ReplaceOnChanges, hasInitErrors)
 	if err != nil {
 		return nil, result.FromError(err)
@@ -579,6 +678,15 @@ func (sg *stepGenerator) generateStepsFromDiff(
 			}
 
 			sg.replaces[urn] = true
+			if _, ok := sg.replaceReasons[urn]; !ok {
+				sg.replaceReasons[urn] = diffReplaceReasons(providerReplaceKeys, diff.ReplaceKeys)
+			}
+
+			// The old resource will be deleted as part of this replacement. Whether that deletion should be
+			// performed by the provider or should only drop the resource from the snapshot is determined by the
+			// program's current options rather than the options recorded when the old resource was created. The old
+			// state is shared with the base snapshot, so the option is recorded here rather than written to it.
+			sg.replaceRetainOnDelete[old] = new.RetainOnDelete
 
 			// If we are going to perform a replacement, we need to recompute the default values.  The above logic
 			// had assumed that we were going to carry them over from the old resource, which is no longer true.
@@ -629,6 +737,9 @@ func (sg *stepGenerator) generateStepsFromDiff(
 				//
 				// To do this, we'll utilize the dependency information contained in the snapshot if it is
 				// trustworthy, which is interpreted by the DependencyGraph type.
+				//
+				// These deletes are scheduled before the full set of deletions for this deployment is known, so
+				// they never skip their provider deletes due to DeletedWith.
 				var steps []Step
 				if sg.opts.TrustDependencies {
 					toReplace, res := sg.calculateDependentReplacements(old)
@@ -636,6 +747,20 @@ func (sg *stepGenerator) generateStepsFromDiff(
 						return nil, res
 					}
 
+					// Record why each dependent must be replaced. This is done in dependency order so that each
+					// reason can include the reasons for the upstream replacement that caused it.
+					for _, dep := range toReplace {
+						if sg.deletes[dep.res.URN] {
+							continue
+						}
+						sg.replaceReasons[dep.res.URN] = []ReplaceReason{{
+							Kind:            ReplaceReasonDependency,
+							Properties:      dep.keys,
+							Upstream:        dep.upstream,
+							UpstreamReasons: sg.replaceReasons[dep.upstream],
+						}}
+					}
+
 					// Deletions must occur in reverse dependency order, and `deps` is returned in dependency
 					// order, so we iterate in reverse.
 					for i := len(toReplace) - 1; i >= 0; i-- {
@@ -651,7 +776,7 @@ func (sg *stepGenerator) generateStepsFromDiff(
 						logging.V(7).Infof("Planner decided to delete '%v' due to dependence on condemned resource '%v'",
 							dependentResource.URN, urn)
 
-						steps = append(steps, NewDeleteReplacementStep(sg.deployment, dependentResource, true))
+						steps = append(steps, NewDeleteReplacementStep(sg.deployment, nil, dependentResource, true))
 						// Mark the condemned resource as deleted. We won't know until later in the deployment whether
 						// or not we're going to be replacing this resource.
 						sg.deletes[dependentResource.URN] = true
@@ -659,7 +784,7 @@ func (sg *stepGenerator) generateStepsFromDiff(
 				}
 
 				return append(steps,
-					NewDeleteReplacementStep(sg.deployment, old, true),
+					sg.newDeleteReplacementStep(nil, old, true),
 					NewReplaceStep(sg.deployment, old, new, diff.ReplaceKeys, diff.ChangedKeys, diff.DetailedDiff, false),
 					NewCreateReplacementStep(
 						sg.deployment, event, old, new, diff.ReplaceKeys, diff.ChangedKeys, diff.DetailedDiff, false),
@@ -696,11 +821,24 @@ func (sg *stepGenerator) generateStepsFromDiff(
 	return nil, nil
 }
 
+// newDeleteReplacementStep creates a step that deletes the old state of a replaced resource. If the replacement was
+// planned in this deployment, the program's current RetainOnDelete option overrides the one recorded in the old state.
+func (sg *stepGenerator) newDeleteReplacementStep(otherDeletions map[resource.URN]bool, old *resource.State,
+	pendingReplace bool) Step {
+
+	step := NewDeleteReplacementStep(sg.deployment, otherDeletions, old, pendingReplace).(*DeleteStep)
+	if retainOnDelete, ok := sg.replaceRetainOnDelete[old]; ok {
+		step.retainOnDelete = retainOnDelete
+	}
+	return step
+}
+
 func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]Step, result.Result) {
 	// To compute the deletion list, we must walk the list of old resources *backwards*.  This is because the list is
 	// stored in dependency order, and earlier elements are possibly leaf nodes for later elements.  We must not delete
 	// dependencies prior to their dependent nodes.
 	var dels []Step
+	deletedURNs := make(map[resource.URN]bool)
 	if prev := sg.deployment.prev; prev != nil {
 		for i := len(prev.Resources) - 1; i >= 0; i-- {
 			// If this resource is explicitly marked for deletion or wasn't seen at all, delete it.
@@ -735,7 +873,7 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 
 				logging.V(7).Infof("Planner decided to delete '%v' due to replacement", res.URN)
 				sg.deletes[res.URN] = true
-				dels = append(dels, NewDeleteReplacementStep(sg.deployment, res, false))
+				dels = append(dels, sg.newDeleteReplacementStep(deletedURNs, res, false))
 			} else if _, aliased := sg.aliased[res.URN]; !sg.sames[res.URN] && !sg.updates[res.URN] && !sg.replaces[res.URN] &&
 				!sg.reads[res.URN] && !aliased {
 				// NOTE: we deliberately do not check sg.deletes here, as it is possible for us to issue multiple
@@ -743,7 +881,7 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 				logging.V(7).Infof("Planner decided to delete '%v'", res.URN)
 				sg.deletes[res.URN] = true
 				if !res.PendingReplacement {
-					dels = append(dels, NewDeleteStep(sg.deployment, res))
+					dels = append(dels, NewDeleteStep(sg.deployment, deletedURNs, res))
 				} else {
 					dels = append(dels, NewRemovePendingReplaceStep(sg.deployment, res))
 				}
@@ -769,6 +907,18 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 		dels = filtered
 	}
 
+	if sg.refuseGuardedSteps(dels, false) && !sg.deployment.preview {
+		return nil, result.Bail()
+	}
+
+	// Record the set of resources that will actually be deleted so that resources which declared one of them as
+	// their DeletedWith resource can skip their own provider deletes.
+	for _, step := range dels {
+		if _, isDelete := step.(*DeleteStep); isDelete {
+			deletedURNs[step.URN()] = true
+		}
+	}
+
 	deletingUnspecifiedTarget := false
 	for _, step := range dels {
 		urn := step.URN()
@@ -800,6 +950,62 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 	return dels, nil
 }
 
+// attachReplaceReasons records the reasons for each replacement among the given steps on the steps themselves, so
+// that the reasons are reported along with the steps.
+func (sg *stepGenerator) attachReplaceReasons(steps []Step) {
+	for _, step := range steps {
+		reasons, ok := sg.replaceReasons[step.URN()]
+		if !ok {
+			continue
+		}
+
+		switch s := step.(type) {
+		case *ReplaceStep:
+			s.reasons = reasons
+		case *CreateStep:
+			if s.replacing {
+				s.reasons = reasons
+			}
+		case *DeleteStep:
+			if s.replacing {
+				s.reasons = reasons
+			}
+		case *ImportStep:
+			if s.replacing {
+				s.reasons = reasons
+			}
+		}
+	}
+}
+
+// refuseGuardedSteps reports an error for each of the given steps that would delete or replace a resource protected
+// by the deployment's change guards, and returns true if there were any. If refuseReplace is true, all replacements
+// are refused. As with targeting errors, a preview keeps going so that the user hears about every refused change;
+// each resource is only reported once.
+func (sg *stepGenerator) refuseGuardedSteps(steps []Step, refuseReplace bool) bool {
+	refused := false
+	for _, step := range steps {
+		if sg.refused[step.URN()] {
+			continue
+		}
+
+		reason, ok := sg.opts.ChangeGuards.refusal(step)
+		if !ok && refuseReplace && isReplacementOp(step.Op()) {
+			reason, ok = "its refuseReplace resource option is set", true
+		}
+		if !ok {
+			continue
+		}
+
+		sg.deployment.Diag().Errorf(diag.GetResourceChangeRefusedError(step.URN()),
+			guardedOpDescription(step.Op()), step.URN(), reason)
+		sg.sawError = true
+		sg.refused[step.URN()] = true
+		refused = true
+	}
+	return refused
+}
+
 // getTargetDependents returns the (transitive) set of dependents on the target resources.
 // This includes both implicit and explicit dependents in the DAG itself, as well as children.
 func (sg *stepGenerator) getTargetDependents(targetsOpt map[resource.URN]bool) map[resource.URN]bool {
@@ -904,7 +1110,7 @@ func (sg *stepGenerator) GeneratePendingDeletes() []Step {
 				logging.V(7).Infof(
 					"stepGenerator.GeneratePendingDeletes(): resource (%v, %v) is pending deletion", res.URN, res.ID)
 				sg.pendingDeletes[res] = true
-				dels = append(dels, NewDeleteStep(sg.deployment, res))
+				dels = append(dels, NewDeleteStep(sg.deployment, nil, res))
 			}
 		}
 	}
@@ -1000,7 +1206,9 @@ func (sg *stepGenerator) ScheduleDeletes(deleteSteps []Step) []antichain {
 
 // providerChanged diffs the Provider field of old and new resources, returning true if the rest of the step generator
 // should consider there to be a diff between these two resources.
-func (sg *stepGenerator) providerChanged(urn resource.URN, old, new *resource.State) (bool, error) {
+func (sg *stepGenerator) providerChanged(urn resource.URN, old, new *resource.State) (bool,
+	[]resource.PropertyKey, error) {
+
 	// If a resource's Provider field has changed, we may need to show a diff and we may not. This is subtle. See
 	// pulumi/pulumi#2753 for more details.
 	//
@@ -1012,7 +1220,7 @@ func (sg *stepGenerator) providerChanged(urn resource.URN, old, new *resource.St
 	// will work correctly together when not the same version.
 
 	if old.Provider == new.Provider {
-		return false, nil
+		return false, nil, nil
 	}
 
 	logging.V(stepExecutorLogLevel).Infof("sg.diffProvider(%s, ...): observed provider diff", urn)
@@ -1020,17 +1228,17 @@ func (sg *stepGenerator) providerChanged(urn resource.URN, old, new *resource.St
 
 	oldRef, err := providers.ParseReference(old.Provider)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	newRef, err := providers.ParseReference(new.Provider)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 
 	if alias, ok := sg.aliased[oldRef.URN()]; ok && alias == newRef.URN() {
 		logging.V(stepExecutorLogLevel).Infof(
 			"sg.diffProvider(%s, ...): observed an aliased provider from %q to %q", urn, oldRef.URN(), newRef.URN())
-		return false, nil
+		return false, nil, nil
 	}
 
 	// If one or both of these providers are not default providers, we will need to accept the diff and replace
@@ -1044,7 +1252,7 @@ func (sg *stepGenerator) providerChanged(urn resource.URN, old, new *resource.St
 		logging.V(stepExecutorLogLevel).Infof(
 			"sg.diffProvider(%s, ...): new provider %q is default: %v",
 			urn, newRef.URN(), providers.IsDefaultProvider(newRef.URN()))
-		return true, err
+		return true, nil, err
 	}
 
 	// If both of these providers are default providers, use the *new provider* to diff the config and determine if
@@ -1055,7 +1263,7 @@ func (sg *stepGenerator) providerChanged(urn resource.URN, old, new *resource.St
 	// performance problem, this result can be cached.
 	newProv, ok := sg.deployment.providers.GetProvider(newRef)
 	if !ok {
-		return false, fmt.Errorf("failed to resolve provider reference: %q", oldRef.String())
+		return false, nil, fmt.Errorf("failed to resolve provider reference: %q", oldRef.String())
 	}
 
 	oldRes, ok := sg.deployment.olds[oldRef.URN()]
@@ -1065,20 +1273,20 @@ func (sg *stepGenerator) providerChanged(urn resource.URN, old, new *resource.St
 
 	diff, err := newProv.DiffConfig(newRef.URN(), oldRes.Inputs, newRes.Inputs, true, nil)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 
 	// If there is a replacement diff, we must also replace this resource.
 	if diff.Replace() {
 		logging.V(stepExecutorLogLevel).Infof(
 			"sg.diffProvider(%s, ...): new provider's DiffConfig reported replacement", urn)
-		return true, nil
+		return true, diff.ReplaceKeys, nil
 	}
 
 	// Otherwise, it's safe to allow this new provider to replace our old one.
 	logging.V(stepExecutorLogLevel).Infof(
 		"sg.diffProvider(%s, ...): both providers are default, proceeding with resource diff", urn)
-	return false, nil
+	return false, nil, nil
 }
 
 // diff returns a DiffResult for the given resource.
@@ -1088,15 +1296,21 @@ func (sg *stepGenerator) diff(urn resource.URN, old, new *resource.State, oldInp
 
 	// If this resource is marked for replacement, just return a "replace" diff that blames the id.
 	if sg.isTargetedReplace(urn) {
+		sg.replaceReasons[urn] = []ReplaceReason{{Kind: ReplaceReasonTargeted}}
 		return plugin.DiffResult{Changes: plugin.DiffSome, ReplaceKeys: []resource.PropertyKey{"id"}}, nil
 	}
 
 	// Before diffing the resource, diff the provider field. If the provider field changes, we may or may
 	// not need to replace the resource.
-	providerChanged, err := sg.providerChanged(urn, old, new)
+	providerChanged, configKeys, err := sg.providerChanged(urn, old, new)
 	if err != nil {
 		return plugin.DiffResult{}, err
 	} else if providerChanged {
+		reason := ReplaceReason{Kind: ReplaceReasonProviderChanged, Properties: configKeys}
+		if ref, err := providers.ParseReference(new.Provider); err == nil {
+			reason.Upstream, reason.UpstreamReasons = ref.URN(), sg.replaceReasons[ref.URN()]
+		}
+		sg.replaceReasons[urn] = []ReplaceReason{reason}
 		return plugin.DiffResult{Changes: plugin.DiffSome, ReplaceKeys: []resource.PropertyKey{"provider"}}, nil
 	}
 
@@ -1350,8 +1564,9 @@ func applyReplaceOnChanges(diff plugin.DiffResult,
 }
 
 type dependentReplace struct {
-	res  *resource.State
-	keys []resource.PropertyKey
+	res      *resource.State
+	keys     []resource.PropertyKey
+	upstream resource.URN // the resource in the replace set that caused this replacement.
 }
 
 func (sg *stepGenerator) calculateDependentReplacements(root *resource.State) ([]dependentReplace, result.Result) {
@@ -1381,30 +1596,33 @@ func (sg *stepGenerator) calculateDependentReplacements(root *resource.State) ([
 	var toReplace []dependentReplace
 	replaceSet := map[resource.URN]bool{root.URN: true}
 
-	requiresReplacement := func(r *resource.State) (bool, []resource.PropertyKey, result.Result) {
+	requiresReplacement := func(r *resource.State) (bool, []resource.PropertyKey, resource.URN, result.Result) {
 		// Neither component nor external resources require replacement.
 		if !r.Custom || r.External {
-			return false, nil, nil
+			return false, nil, "", nil
 		}
 
 		// If the resource's provider is in the replace set, we must replace this resource.
 		if r.Provider != "" {
 			ref, err := providers.ParseReference(r.Provider)
 			if err != nil {
-				return false, nil, result.FromError(err)
+				return false, nil, "", result.FromError(err)
 			}
 			if replaceSet[ref.URN()] {
-				return true, nil, nil
+				return true, nil, ref.URN(), nil
 			}
 		}
 
 		// Scan the properties of this resource in order to determine whether or not any of them depend on a resource
 		// that requires replacement and build a set of input properties for the provider diff.
-		hasDependencyInReplaceSet, inputsForDiff := false, resource.PropertyMap{}
+		var upstream resource.URN
+		inputsForDiff := resource.PropertyMap{}
 		for pk, pv := range r.Inputs {
 			for _, propertyDep := range r.PropertyDependencies[pk] {
 				if replaceSet[propertyDep] {
-					hasDependencyInReplaceSet = true
+					if upstream == "" || propertyDep < upstream {
+						upstream = propertyDep
+					}
 					pv = resource.MakeComputed(resource.NewStringProperty("<unknown>"))
 				}
 			}
@@ -1413,24 +1631,24 @@ func (sg *stepGenerator) calculateDependentReplacements(root *resource.State) ([
 
 		// If none of this resource's properties depend on a resource in the replace set, then none of the properties
 		// may change and this resource does not need to be replaced.
-		if !hasDependencyInReplaceSet {
-			return false, nil, nil
+		if upstream == "" {
+			return false, nil, "", nil
 		}
 
 		// Otherwise, fetch the resource's provider. Since we have filtered out component resources, this resource must
 		// have a provider.
 		prov, res := sg.loadResourceProvider(r.URN, r.Custom, r.Provider, r.Type)
 		if res != nil {
-			return false, nil, res
+			return false, nil, "", res
 		}
 		contract.Assert(prov != nil)
 
 		// Call the provider's `Diff` method and return.
 		diff, err := prov.Diff(r.URN, r.ID, r.Outputs, inputsForDiff, true, nil)
 		if err != nil {
-			return false, nil, result.FromError(err)
+			return false, nil, "", result.FromError(err)
 		}
-		return diff.Replace(), diff.ReplaceKeys, nil
+		return diff.Replace(), diff.ReplaceKeys, upstream, nil
 	}
 
 	// Walk the root resource's dependents in order and build up the set of resources that require replacement.
@@ -1445,12 +1663,13 @@ func (sg *stepGenerator) calculateDependentReplacements(root *resource.State) ([
 	// encountered while walking the old dependency graph to determine the set of dependents.
 	impossibleDependents := sg.urns
 	for _, d := range sg.deployment.depGraph.DependingOn(root, impossibleDependents, false) {
-		replace, keys, res := requiresReplacement(d)
+		replace, keys, upstream, res := requiresReplacement(d)
 		if res != nil {
 			return nil, res
 		}
 		if replace {
-			toReplace, replaceSet[d.URN] = append(toReplace, dependentReplace{res: d, keys: keys}), true
+			dep := dependentReplace{res: d, keys: keys, upstream: upstream}
+			toReplace, replaceSet[d.URN] = append(toReplace, dep), true
 		}
 	}
 
@@ -1504,7 +1723,6 @@ func (sg *stepGenerator) AnalyzeResources() result.Result {
 			return result.FromError(aErr)
 		}
 		for _, d := range diagnostics {
-			sg.sawError = sg.sawError || (d.EnforcementLevel == apitype.Mandatory)
 			// If a URN was provided and it is a URN associated with a resource in the stack, use it.
 			// Otherwise, if the URN is empty or is not associated with a resource in the stack, use
 			// the default root stack URN.
@@ -1517,13 +1735,27 @@ func (sg *stepGenerator) AnalyzeResources() result.Result {
 			if urn == "" {
 				urn = resource.DefaultRootStackURN(sg.deployment.Target().Name, sg.deployment.source.Project())
 			}
-			sg.opts.Events.OnPolicyViolation(urn, d)
+			d, waiver := sg.waivePolicyViolation(urn, d)
+			sg.sawError = sg.sawError || (d.EnforcementLevel == apitype.Mandatory)
+			sg.opts.Events.OnPolicyViolation(urn, d, waiver)
 		}
 	}
 
 	return nil
 }
 
+// waivePolicyViolation applies the target's policy waivers to a violation of a policy by the resource with the given
+// URN.
+func (sg *stepGenerator) waivePolicyViolation(urn resource.URN,
+	d plugin.AnalyzeDiagnostic) (plugin.AnalyzeDiagnostic, *PolicyWaiverMatch) {
+
+	target := sg.deployment.Target()
+	if target == nil {
+		return d, nil
+	}
+	return waivePolicyViolation(target.PolicyWaivers, urn, d, time.Now())
+}
+
 // newStepGenerator creates a new step generator that operates on the given deployment.
 func newStepGenerator(
 	deployment *Deployment, opts Options, updateTargetsOpt, replaceTargetsOpt map[resource.URN]bool) *stepGenerator {
@@ -1544,6 +1776,11 @@ func newStepGenerator(
 		pendingDeletes:       make(map[*resource.State]bool),
 		providers:            make(map[resource.URN]*resource.State),
 		dependentReplaceKeys: make(map[resource.URN][]resource.PropertyKey),
+		replaceReasons:       make(map[resource.URN][]ReplaceReason),
 		aliased:              make(map[resource.URN]resource.URN),
+
+		refused:               make(map[resource.URN]bool),
+		replaceRetainOnDelete: make(map[*resource.State]bool),
+		remediators:           make(map[plugin.Analyzer]bool),
 	}
 }
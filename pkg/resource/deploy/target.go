@@ -26,6 +26,8 @@ type Target struct {
 	Config    config.Map       // optional configuration key/value pairs.
 	Decrypter config.Decrypter // decrypter for secret configuration values.
 	Snapshot  *Snapshot        // the last snapshot deployed to the target.
+
+	PolicyWaivers []PolicyWaiver // waivers for policy violations by particular resources, if any.
 }
 
 // GetPackageConfig returns the set of configuration parameters for the indicated package, if any.
@@ -0,0 +1,165 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// RemovePendingOperation removes the given pending operation from the snapshot without otherwise changing the
+// snapshot's resources. This is the appropriate resolution for an operation that is known not to have taken effect.
+func RemovePendingOperation(snap *deploy.Snapshot, op resource.Operation) error {
+	contract.Require(snap != nil, "snap")
+
+	for i, pending := range snap.PendingOperations {
+		if pending.Resource == op.Resource && pending.Type == op.Type {
+			snap.PendingOperations = append(snap.PendingOperations[:i:i], snap.PendingOperations[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no pending %s operation found for resource %q", op.Type, op.Resource.URN)
+}
+
+// AdoptPendingCreate resolves a pending create by reading the resource with the given ID from its provider and adding
+// the result to the snapshot. An error is returned if the provider reports that no such resource exists.
+func AdoptPendingCreate(snap *deploy.Snapshot, op resource.Operation, id resource.ID, prov plugin.Provider) error {
+	contract.Require(snap != nil, "snap")
+	contract.Require(prov != nil, "prov")
+	contract.Require(id != "", "id")
+
+	if op.Type != resource.OperationTypeCreating {
+		return fmt.Errorf("resource %q has a pending %s operation, not a pending create", op.Resource.URN, op.Type)
+	}
+	if !op.Resource.Custom {
+		return fmt.Errorf("resource %q is a component resource and cannot be read from a provider", op.Resource.URN)
+	}
+
+	read, _, err := prov.Read(op.Resource.URN, id, nil, nil)
+	if err != nil {
+		return fmt.Errorf("reading resource %q: %w", op.Resource.URN, err)
+	}
+	if read.Outputs == nil {
+		return fmt.Errorf("resource %q with ID %q does not exist", op.Resource.URN, id)
+	}
+
+	adopted := *op.Resource
+	adopted.ID = read.ID
+	if adopted.ID == "" {
+		adopted.ID = id
+	}
+	if read.Inputs != nil {
+		adopted.Inputs = read.Inputs
+	}
+	adopted.Outputs = read.Outputs
+	adopted.InitErrors = nil
+
+	// If the pending create was replacing an existing resource, the old copy of the resource is still in the snapshot.
+	// As the engine does for replacements, the adopted resource is placed before the old copy, which is marked for
+	// deletion. An old copy that is pending replacement has already been deleted, so the adopted resource replaces it.
+	resources := snap.Resources
+	updated := make([]*resource.State, 0, len(resources)+1)
+	replacing := false
+	for _, res := range resources {
+		if !replacing && res.URN == adopted.URN && !res.Delete {
+			replacing = true
+			updated = append(updated, &adopted)
+			if res.PendingReplacement {
+				continue
+			}
+
+			old := *res
+			old.Delete = true
+			res = &old
+		}
+		updated = append(updated, res)
+	}
+	if !replacing {
+		updated = append(updated, &adopted)
+	}
+
+	snap.Resources = updated
+	if err := snap.VerifyIntegrity(); err != nil {
+		snap.Resources = resources
+		return fmt.Errorf("adopting resource %q: %w", op.Resource.URN, err)
+	}
+
+	return RemovePendingOperation(snap, op)
+}
+
+// RefreshPendingOperation resolves a pending update or delete by reading the affected resource from its provider. If
+// the resource still exists, its state in the snapshot is refreshed; otherwise, it is removed from the snapshot.
+func RefreshPendingOperation(snap *deploy.Snapshot, op resource.Operation, prov plugin.Provider) error {
+	contract.Require(snap != nil, "snap")
+	contract.Require(prov != nil, "prov")
+
+	if op.Type != resource.OperationTypeUpdating && op.Type != resource.OperationTypeDeleting {
+		return fmt.Errorf("resource %q has a pending %s operation, which cannot be refreshed",
+			op.Resource.URN, op.Type)
+	}
+
+	// The pending operation records the state the engine was moving the resource towards, so find the state that is
+	// actually recorded in the snapshot. Several resources may share the operation's URN if the resource was replaced,
+	// but an update or delete never changes the resource's ID.
+	var existing *resource.State
+	for _, res := range snap.Resources {
+		if res.URN == op.Resource.URN && res.ID == op.Resource.ID {
+			existing = res
+			break
+		}
+	}
+	if existing == nil {
+		return fmt.Errorf("no resource found in the snapshot for pending %s operation on %q", op.Type, op.Resource.URN)
+	}
+
+	read, _, err := prov.Read(existing.URN, existing.ID, existing.Inputs, existing.Outputs)
+	if err != nil {
+		return fmt.Errorf("reading resource %q: %w", existing.URN, err)
+	}
+
+	if read.Outputs == nil {
+		if existing.Delete {
+			// Nothing depends on a resource that is awaiting deletion, as its dependents refer to its replacement.
+			snap.Resources = removeState(snap.Resources, existing)
+		} else if err := DeleteResource(snap, existing); err != nil {
+			return err
+		}
+	} else {
+		if read.ID != "" {
+			existing.ID = read.ID
+		}
+		if read.Inputs != nil {
+			existing.Inputs = read.Inputs
+		}
+		existing.Outputs = read.Outputs
+	}
+
+	return RemovePendingOperation(snap, op)
+}
+
+// removeState returns the given resources without the given state.
+func removeState(resources []*resource.State, state *resource.State) []*resource.State {
+	var result []*resource.State
+	for _, res := range resources {
+		if res != state {
+			result = append(result, res)
+		}
+	}
+	return result
+}
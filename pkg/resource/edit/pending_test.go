@@ -0,0 +1,176 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newReadProvider(live map[resource.ID]resource.PropertyMap) *deploytest.Provider {
+	return &deploytest.Provider{
+		ReadF: func(urn resource.URN, id resource.ID,
+			inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error) {
+
+			outputs, ok := live[id]
+			if !ok {
+				return plugin.ReadResult{}, resource.StatusOK, nil
+			}
+			return plugin.ReadResult{ID: id, Outputs: outputs}, resource.StatusOK, nil
+		},
+	}
+}
+
+func TestRemovePendingOperation(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA)
+	snap := NewSnapshot([]*resource.State{pA})
+	snap.PendingOperations = []resource.Operation{
+		resource.NewOperation(a, resource.OperationTypeCreating),
+		resource.NewOperation(b, resource.OperationTypeCreating),
+	}
+
+	err := RemovePendingOperation(snap, snap.PendingOperations[0])
+	assert.NoError(t, err)
+	assert.Len(t, snap.PendingOperations, 1)
+	assert.Equal(t, b, snap.PendingOperations[0].Resource)
+	assert.Equal(t, []*resource.State{pA}, snap.Resources)
+
+	err = RemovePendingOperation(snap, resource.NewOperation(a, resource.OperationTypeCreating))
+	assert.Error(t, err)
+}
+
+func TestAdoptPendingCreate(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	a.Custom = true
+	snap := NewSnapshot([]*resource.State{pA})
+	op := resource.NewOperation(a, resource.OperationTypeCreating)
+	snap.PendingOperations = []resource.Operation{op}
+
+	prov := newReadProvider(map[resource.ID]resource.PropertyMap{
+		"real-id": {"foo": resource.NewStringProperty("bar")},
+	})
+
+	// Adopting a resource that doesn't exist should leave the snapshot untouched.
+	err := AdoptPendingCreate(snap, op, "missing-id", prov)
+	assert.Error(t, err)
+	assert.Len(t, snap.Resources, 1)
+	assert.Len(t, snap.PendingOperations, 1)
+
+	err = AdoptPendingCreate(snap, op, "real-id", prov)
+	assert.NoError(t, err)
+	assert.Empty(t, snap.PendingOperations)
+	assert.Len(t, snap.Resources, 2)
+	adopted := snap.Resources[1]
+	assert.Equal(t, a.URN, adopted.URN)
+	assert.Equal(t, resource.ID("real-id"), adopted.ID)
+	assert.Equal(t, resource.NewStringProperty("bar"), adopted.Outputs["foo"])
+}
+
+func TestRefreshPendingOperation(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	a.Custom, a.ID = true, "a-id"
+	b := NewResource("b", pA)
+	b.Custom, b.ID = true, "b-id"
+	snap := NewSnapshot([]*resource.State{pA, a, b})
+
+	newA := *a
+	newA.Outputs = resource.PropertyMap{"foo": resource.NewStringProperty("pending")}
+	updateOp := resource.NewOperation(&newA, resource.OperationTypeUpdating)
+	deleteOp := resource.NewOperation(b, resource.OperationTypeDeleting)
+	snap.PendingOperations = []resource.Operation{updateOp, deleteOp}
+
+	prov := newReadProvider(map[resource.ID]resource.PropertyMap{
+		"a-id": {"foo": resource.NewStringProperty("live")},
+	})
+
+	// The resource with the pending update still exists, so its outputs are refreshed.
+	err := RefreshPendingOperation(snap, updateOp, prov)
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewStringProperty("live"), a.Outputs["foo"])
+
+	// The resource with the pending delete is gone, so it is removed from the snapshot.
+	err = RefreshPendingOperation(snap, deleteOp, prov)
+	assert.NoError(t, err)
+	assert.Equal(t, []*resource.State{pA, a}, snap.Resources)
+	assert.Empty(t, snap.PendingOperations)
+}
+
+func TestAdoptPendingCreateReplacement(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	old := NewResource("a", pA)
+	old.Custom, old.ID = true, "old-id"
+	snap := NewSnapshot([]*resource.State{pA, old})
+
+	newA := *old
+	newA.ID = ""
+	op := resource.NewOperation(&newA, resource.OperationTypeCreating)
+	snap.PendingOperations = []resource.Operation{op}
+
+	prov := newReadProvider(map[resource.ID]resource.PropertyMap{
+		"new-id": {"foo": resource.NewStringProperty("bar")},
+	})
+
+	// The adopted resource takes the old resource's place, and the old resource is marked for deletion.
+	err := AdoptPendingCreate(snap, op, "new-id", prov)
+	assert.NoError(t, err)
+	assert.Empty(t, snap.PendingOperations)
+	assert.Len(t, snap.Resources, 3)
+	assert.Equal(t, resource.ID("new-id"), snap.Resources[1].ID)
+	assert.False(t, snap.Resources[1].Delete)
+	assert.Equal(t, resource.ID("old-id"), snap.Resources[2].ID)
+	assert.True(t, snap.Resources[2].Delete)
+	assert.NoError(t, snap.VerifyIntegrity())
+}
+
+func TestRefreshPendingDeleteOfReplacedResource(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	a.Custom, a.ID = true, "new-id"
+	old := NewResource("a", pA)
+	old.Custom, old.ID, old.Delete = true, "old-id", true
+	snap := NewSnapshot([]*resource.State{pA, a, old})
+
+	deleteOp := resource.NewOperation(old, resource.OperationTypeDeleting)
+	snap.PendingOperations = []resource.Operation{deleteOp}
+
+	prov := newReadProvider(map[resource.ID]resource.PropertyMap{
+		"new-id": {"foo": resource.NewStringProperty("live")},
+	})
+
+	// The replaced resource is gone, so only it is removed from the snapshot.
+	err := RefreshPendingOperation(snap, deleteOp, prov)
+	assert.NoError(t, err)
+	assert.Equal(t, []*resource.State{pA, a}, snap.Resources)
+	assert.Empty(t, snap.PendingOperations)
+}
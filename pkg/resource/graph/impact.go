@@ -0,0 +1,168 @@
+// Copyright 2016-2022, Pulumi Corporation.  All rights reserved.
+
+package graph
+
+import (
+	"sort"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// EdgeKind identifies the way in which one resource depends on another.
+type EdgeKind string
+
+const (
+	// EdgeParent means that the resource is a child of the other resource.
+	EdgeParent EdgeKind = "parent"
+	// EdgeDependency means that the resource depends on the other resource without consuming any of its properties.
+	EdgeDependency EdgeKind = "dependency"
+	// EdgeProperty means that one of the resource's input properties depends on the other resource.
+	EdgeProperty EdgeKind = "property"
+	// EdgeProvider means that the resource is managed by the other resource, which is a provider.
+	EdgeProvider EdgeKind = "provider"
+)
+
+// Edge is a direct dependency of one resource on another.
+type Edge struct {
+	Kind     EdgeKind             // the kind of dependency.
+	URN      resource.URN         // the resource depended on.
+	Property resource.PropertyKey // the property that depends on the resource, for property edges.
+}
+
+// ImpactReplace describes whether a resource is replaced along with the resource whose impact is being computed.
+type ImpactReplace string
+
+const (
+	// ImpactNotReplaced means that the resource is not replaced, although it may be updated.
+	ImpactNotReplaced ImpactReplace = ""
+	// ImpactReplaced means that the resource is replaced, because its provider is replaced.
+	ImpactReplaced ImpactReplace = "replace"
+	// ImpactMayBeReplaced means that the resource is replaced if its provider reports that changes to the properties
+	// that depend on replaced resources require replacement.
+	ImpactMayBeReplaced ImpactReplace = "may-replace"
+)
+
+// ImpactedResource is a resource that directly or indirectly depends on the resource whose impact is being computed.
+type ImpactedResource struct {
+	State       *resource.State        // the impacted resource.
+	Edges       []Edge                 // the direct dependencies through which the resource is impacted.
+	Replace     ImpactReplace          // whether the resource is replaced along with the impacting resource.
+	ReplaceKeys []resource.PropertyKey // the properties that depend on replaced resources, if any.
+}
+
+// ImpactOf returns every resource that directly or indirectly depends on the given resource through parents,
+// dependencies, property dependencies or provider references, in topological order.
+//
+// Each impacted resource is also marked with whether it would be replaced if the given resource were deleted before
+// being replaced. This follows the rules the engine uses for delete-before-replace: a custom resource is replaced if
+// its provider is replaced, and may be replaced if any of its input properties depend on a replaced resource. Whether
+// the latter actually requires replacement is up to the resource's provider. Children are not replaced along with
+// their parents, and neither component nor external resources are ever replaced.
+func (dg *DependencyGraph) ImpactOf(res *resource.State) []ImpactedResource {
+	cursorIndex, ok := dg.index[res]
+	contract.Assert(ok)
+
+	impactedSet := map[resource.URN]bool{res.URN: true}
+	replaceSet := map[resource.URN]bool{res.URN: true}
+
+	var impacted []ImpactedResource
+	for i := cursorIndex + 1; i < len(dg.resources); i++ {
+		candidate := dg.resources[i]
+		if candidate.Delete {
+			continue
+		}
+
+		edges := impactEdges(candidate, impactedSet)
+		if len(edges) == 0 {
+			continue
+		}
+
+		replace, keys := impactReplacement(candidate, replaceSet)
+		if replace != ImpactNotReplaced {
+			replaceSet[candidate.URN] = true
+		}
+
+		impactedSet[candidate.URN] = true
+		impacted = append(impacted, ImpactedResource{
+			State:       candidate,
+			Edges:       edges,
+			Replace:     replace,
+			ReplaceKeys: keys,
+		})
+	}
+	return impacted
+}
+
+// impactEdges returns the direct dependencies of the given resource on the resources in the given set.
+func impactEdges(r *resource.State, set map[resource.URN]bool) []Edge {
+	var edges []Edge
+	if set[r.Parent] {
+		edges = append(edges, Edge{Kind: EdgeParent, URN: r.Parent})
+	}
+
+	// Property dependencies are reported per property; any remaining dependencies are reported as plain dependencies.
+	viaProperty := make(map[resource.URN]bool)
+	for _, key := range sortedPropertyKeys(r.PropertyDependencies) {
+		for _, dep := range r.PropertyDependencies[key] {
+			if set[dep] {
+				edges = append(edges, Edge{Kind: EdgeProperty, URN: dep, Property: key})
+				viaProperty[dep] = true
+			}
+		}
+	}
+	for _, dep := range r.Dependencies {
+		if set[dep] && !viaProperty[dep] {
+			edges = append(edges, Edge{Kind: EdgeDependency, URN: dep})
+		}
+	}
+
+	if r.Provider != "" {
+		ref, err := providers.ParseReference(r.Provider)
+		contract.Assert(err == nil)
+		if set[ref.URN()] {
+			edges = append(edges, Edge{Kind: EdgeProvider, URN: ref.URN()})
+		}
+	}
+	return edges
+}
+
+// impactReplacement returns whether the given resource is replaced when the resources in the given set are, and the
+// properties that depend on those resources.
+func impactReplacement(r *resource.State, replaceSet map[resource.URN]bool) (ImpactReplace, []resource.PropertyKey) {
+	if !r.Custom || r.External {
+		return ImpactNotReplaced, nil
+	}
+
+	if r.Provider != "" {
+		ref, err := providers.ParseReference(r.Provider)
+		contract.Assert(err == nil)
+		if replaceSet[ref.URN()] {
+			return ImpactReplaced, nil
+		}
+	}
+
+	var keys []resource.PropertyKey
+	for _, key := range sortedPropertyKeys(r.PropertyDependencies) {
+		for _, dep := range r.PropertyDependencies[key] {
+			if replaceSet[dep] {
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return ImpactNotReplaced, nil
+	}
+	return ImpactMayBeReplaced, keys
+}
+
+func sortedPropertyKeys(m map[resource.PropertyKey][]resource.URN) []resource.PropertyKey {
+	keys := make([]resource.PropertyKey, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
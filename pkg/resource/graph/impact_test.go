@@ -0,0 +1,70 @@
+// Copyright 2016-2022, Pulumi Corporation.  All rights reserved.
+
+package graph
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImpactOf(t *testing.T) {
+	custom := func(r *resource.State) *resource.State {
+		r.Custom = true
+		return r
+	}
+
+	// pA manages a; b consumes a property of a; pB is configured with a property of b and manages c; d merely depends
+	// on b; e is a child of d; f is unrelated.
+	pA := NewProviderResource("test", "pA", "0")
+	a := custom(NewResource("a", pA))
+	b := custom(NewResource("b", pA, a.URN))
+	b.PropertyDependencies = map[resource.PropertyKey][]resource.URN{"in": {a.URN}}
+	pB := custom(NewProviderResource("test", "pB", "1", b.URN))
+	pB.PropertyDependencies = map[resource.PropertyKey][]resource.URN{"region": {b.URN}}
+	c := custom(NewResource("c", pB))
+	d := custom(NewResource("d", pA, b.URN))
+	e := custom(NewResource("e", pA))
+	e.Parent = d.URN
+	f := custom(NewResource("f", pA))
+
+	dg := NewDependencyGraph([]*resource.State{pA, a, b, pB, c, d, e, f})
+	impacted := dg.ImpactOf(a)
+
+	assert.Equal(t, []ImpactedResource{
+		{
+			State:       b,
+			Edges:       []Edge{{Kind: EdgeProperty, URN: a.URN, Property: "in"}},
+			Replace:     ImpactMayBeReplaced,
+			ReplaceKeys: []resource.PropertyKey{"in"},
+		},
+		{
+			State:       pB,
+			Edges:       []Edge{{Kind: EdgeProperty, URN: b.URN, Property: "region"}},
+			Replace:     ImpactMayBeReplaced,
+			ReplaceKeys: []resource.PropertyKey{"region"},
+		},
+		{
+			State:   c,
+			Edges:   []Edge{{Kind: EdgeProvider, URN: pB.URN}},
+			Replace: ImpactReplaced,
+		},
+		{
+			State: d,
+			Edges: []Edge{{Kind: EdgeDependency, URN: b.URN}},
+		},
+		{
+			State: e,
+			Edges: []Edge{{Kind: EdgeParent, URN: d.URN}},
+		},
+	}, impacted)
+
+	// Replacing a provider replaces everything it manages.
+	for _, r := range dg.ImpactOf(pA) {
+		if r.State.Provider == a.Provider {
+			assert.Equal(t, ImpactReplaced, r.Replace, r.State.URN)
+		}
+	}
+	assert.Empty(t, dg.ImpactOf(f))
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -94,6 +95,8 @@ func TestDeploymentSerialization(t *testing.T) {
 		nil,
 		nil,
 		"",
+		false,
+		"",
 	)
 
 	dep, err := SerializeResource(res, config.NopEncrypter, false /* showSecrets */)
@@ -183,6 +186,23 @@ func TestDeploymentSerialization(t *testing.T) {
 	assert.Equal(t, 0, len(dep.Outputs["out-empty-map"].(map[string]interface{})))
 }
 
+func TestStepDurationSerialization(t *testing.T) {
+	t.Parallel()
+
+	urn := resource.NewURN("test", "test", "", "test:index:Resource", "res")
+	res := resource.NewState("test:index:Resource", urn, true, false, "id", resource.PropertyMap{},
+		resource.PropertyMap{}, "", false, false, nil, nil, "", nil, false, nil, nil, nil, "", false, "")
+	res.StepDuration = 1500 * time.Millisecond
+
+	dep, err := SerializeResource(res, config.NopEncrypter, false /* showSecrets */)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, dep.StepDuration)
+
+	state, err := DeserializeResource(dep, config.NopDecrypter, config.NopEncrypter)
+	require.NoError(t, err)
+	assert.Equal(t, res.StepDuration, state.StepDuration)
+}
+
 func TestLoadTooNewDeployment(t *testing.T) {
 	untypedDeployment := &apitype.UntypedDeployment{
 		Version: apitype.DeploymentSchemaVersionCurrent + 1,
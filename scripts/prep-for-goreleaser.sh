@@ -20,4 +20,6 @@ cp sdk/nodejs/dist/pulumi-analyzer-policy .
 cp sdk/nodejs/dist/pulumi-analyzer-policy.cmd .
 cp sdk/python/dist/pulumi-analyzer-policy-python .
 cp sdk/python/dist/pulumi-analyzer-policy-python.cmd .
+cp sdk/go/dist/pulumi-analyzer-policy-go .
+cp sdk/go/dist/pulumi-analyzer-policy-go.cmd .
 cp sdk/python/cmd/pulumi-language-python-exec .
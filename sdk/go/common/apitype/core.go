@@ -324,6 +324,14 @@ type ResourceV3 struct {
 	CustomTimeouts *resource.CustomTimeouts `json:"customTimeouts,omitempty" yaml:"customTimeouts,omitempty"`
 	// ImportID is the import input used for imported resources.
 	ImportID resource.ID `json:"importID,omitempty" yaml:"importID,omitempty"`
+	// RetainOnDelete is set to true when the resource should be removed from state instead of being deleted by its
+	// provider.
+	RetainOnDelete bool `json:"retainOnDelete,omitempty" yaml:"retainOnDelete,omitempty"`
+	// DeletedWith is the URN of a resource whose deletion also deletes this resource. If both are deleted in the
+	// same deployment, this resource is removed from state without being deleted by its provider.
+	DeletedWith resource.URN `json:"deletedWith,omitempty" yaml:"deletedWith,omitempty"`
+	// StepDuration is the time, in seconds, taken by the last step that created or updated this resource.
+	StepDuration float64 `json:"stepDuration,omitempty" yaml:"stepDuration,omitempty"`
 }
 
 // ManifestV1 captures meta-information about this checkpoint file, such as versions of binaries, etc.
@@ -57,6 +57,45 @@ type PolicyEvent struct {
 
 	// EnforcementLevel is one of "warning" or "mandatory".
 	EnforcementLevel string `json:"enforcementLevel"`
+
+	// Waiver is the stack's policy waiver that matched the violation, if any.
+	Waiver *PolicyWaiverEvent `json:"waiver,omitempty"`
+}
+
+// PolicyWaiverEvent describes the policy waiver that matched a policy violation. A violation whose waiver has not
+// expired is reported with the advisory enforcement level.
+type PolicyWaiverEvent struct {
+	Reason string `json:"reason"`
+	// Expires is the RFC 3339 time from which the waiver no longer applies.
+	Expires string `json:"expires"`
+	// Expired is true if the waiver had expired, in which case the violation was not waived.
+	Expired bool `json:"expired,omitempty"`
+}
+
+// PolicyRemediationEvent is emitted whenever a remediation policy changes a resource's inputs.
+type PolicyRemediationEvent struct {
+	ResourceURN          string `json:"resourceUrn,omitempty"`
+	Color                string `json:"color"`
+	PolicyName           string `json:"policyName"`
+	PolicyPackName       string `json:"policyPackName"`
+	PolicyPackVersion    string `json:"policyPackVersion"`
+	PolicyPackVersionTag string `json:"policyPackVersionTag"`
+
+	// Before and After are the resource's inputs before and after the remediation. Secrets are not included.
+	Before map[string]interface{} `json:"before,omitempty"`
+	After  map[string]interface{} `json:"after,omitempty"`
+}
+
+// ProviderCallEvent is emitted whenever a call to a resource provider returns, if provider call profiling is enabled.
+type ProviderCallEvent struct {
+	Provider string `json:"provider"`
+	Method   string `json:"method"`
+	URN      string `json:"urn,omitempty"`
+	Token    string `json:"token,omitempty"`
+	// StartTime and EndTime are Unix timestamps (nanoseconds) of when the call started and returned.
+	StartTime int64 `json:"startTime"`
+	EndTime   int64 `json:"endTime"`
+	Failed    bool  `json:"failed,omitempty"`
 }
 
 // PreludeEvent is emitted at the start of an update.
@@ -132,6 +171,23 @@ type StepEventMetadata struct {
 	Logical bool `json:"logical,omitempty"`
 	// Provider actually performing the step.
 	Provider string `json:"provider"`
+	// ReplaceReasons explains why the resource is being replaced (only applicable to steps that are part of a
+	// replacement).
+	ReplaceReasons []ReplaceReason `json:"replaceReasons,omitempty"`
+}
+
+// ReplaceReason is one cause of a resource's replacement.
+type ReplaceReason struct {
+	// Kind identifies the cause, e.g. "provider-diff", "replace-on-changes" or "dependency-replaced".
+	Kind string `json:"kind"`
+	// Properties are the properties that caused the replacement, if any.
+	Properties []string `json:"properties,omitempty"`
+	// Upstream is the URN of the resource that caused the replacement, if any.
+	Upstream string `json:"upstream,omitempty"`
+	// UpstreamReasons explains why the upstream resource is itself being replaced, if it is.
+	UpstreamReasons []ReplaceReason `json:"upstreamReasons,omitempty"`
+	// Description is a human-readable description of the cause.
+	Description string `json:"description"`
 }
 
 // StepEventStateMetadata is the more detailed state information for a resource as it relates to
@@ -150,6 +206,8 @@ type StepEventStateMetadata struct {
 	Parent string `json:"parent"`
 	// Protect is true to "protect" this resource (protected resources cannot be deleted).
 	Protect bool `json:"protect,omitempty"`
+	// RetainOnDelete is true if the resource is removed from state instead of being deleted by its provider.
+	RetainOnDelete bool `json:"retainOnDelete,omitempty"`
 	// Inputs contains the resource's input properties (as specified by the program). Secrets have
 	// filtered out, and large assets have been replaced by hashes as applicable.
 	Inputs map[string]interface{} `json:"inputs"`
@@ -165,12 +223,17 @@ type StepEventStateMetadata struct {
 type ResourcePreEvent struct {
 	Metadata StepEventMetadata `json:"metadata"`
 	Planning bool              `json:"planning,omitempty"`
+	// StartTime is a Unix timestamp (nanoseconds) of when the step started.
+	StartTime int64 `json:"startTime,omitempty"`
 }
 
 // ResOutputsEvent is emitted when a resource is finished being provisioned.
 type ResOutputsEvent struct {
 	Metadata StepEventMetadata `json:"metadata"`
 	Planning bool              `json:"planning,omitempty"`
+	// StartTime and EndTime are Unix timestamps (nanoseconds) of when the step started and completed.
+	StartTime int64 `json:"startTime,omitempty"`
+	EndTime   int64 `json:"endTime,omitempty"`
 }
 
 // ResOpFailedEvent is emitted when a resource operation fails. Typically a DiagnosticEvent is
@@ -179,6 +242,9 @@ type ResOpFailedEvent struct {
 	Metadata StepEventMetadata `json:"metadata"`
 	Status   int               `json:"status"`
 	Steps    int               `json:"steps"`
+	// StartTime and EndTime are Unix timestamps (nanoseconds) of when the step started and failed.
+	StartTime int64 `json:"startTime,omitempty"`
+	EndTime   int64 `json:"endTime,omitempty"`
 }
 
 // EngineEvent describes a Pulumi engine event, such as a change to a resource or diagnostic
@@ -197,15 +263,17 @@ type EngineEvent struct {
 	// Timestamp is a Unix timestamp (seconds) of when the event was emitted.
 	Timestamp int `json:"timestamp"`
 
-	CancelEvent      *CancelEvent       `json:"cancelEvent,omitempty"`
-	StdoutEvent      *StdoutEngineEvent `json:"stdoutEvent,omitempty"`
-	DiagnosticEvent  *DiagnosticEvent   `json:"diagnosticEvent,omitempty"`
-	PreludeEvent     *PreludeEvent      `json:"preludeEvent,omitempty"`
-	SummaryEvent     *SummaryEvent      `json:"summaryEvent,omitempty"`
-	ResourcePreEvent *ResourcePreEvent  `json:"resourcePreEvent,omitempty"`
-	ResOutputsEvent  *ResOutputsEvent   `json:"resOutputsEvent,omitempty"`
-	ResOpFailedEvent *ResOpFailedEvent  `json:"resOpFailedEvent,omitempty"`
-	PolicyEvent      *PolicyEvent       `json:"policyEvent,omitempty"`
+	CancelEvent            *CancelEvent            `json:"cancelEvent,omitempty"`
+	StdoutEvent            *StdoutEngineEvent      `json:"stdoutEvent,omitempty"`
+	DiagnosticEvent        *DiagnosticEvent        `json:"diagnosticEvent,omitempty"`
+	PreludeEvent           *PreludeEvent           `json:"preludeEvent,omitempty"`
+	SummaryEvent           *SummaryEvent           `json:"summaryEvent,omitempty"`
+	ResourcePreEvent       *ResourcePreEvent       `json:"resourcePreEvent,omitempty"`
+	ResOutputsEvent        *ResOutputsEvent        `json:"resOutputsEvent,omitempty"`
+	ResOpFailedEvent       *ResOpFailedEvent       `json:"resOpFailedEvent,omitempty"`
+	PolicyEvent            *PolicyEvent            `json:"policyEvent,omitempty"`
+	ProviderCallEvent      *ProviderCallEvent      `json:"providerCallEvent,omitempty"`
+	PolicyRemediationEvent *PolicyRemediationEvent `json:"policyRemediationEvent,omitempty"`
 }
 
 // EngineEventBatch is a group of engine events.
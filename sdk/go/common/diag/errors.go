@@ -80,3 +80,8 @@ func GetResourceWillBeDestroyedButWasNotSpecifiedInTargetList(urn resource.URN)
 	return newError(urn, 2014, `Resource '%v' will be destroyed but was not specified in --target list.
 Either include resource in --target list or pass --target-dependents to proceed.`)
 }
+
+func GetResourceChangeRefusedError(urn resource.URN) *Diag {
+	return newError(urn, 2015, `Refusing to %v resource '%v' because %v.
+If this change is intended, remove the guard and run the operation again.`)
+}
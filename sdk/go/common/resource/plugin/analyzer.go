@@ -43,6 +43,9 @@ type Analyzer interface {
 	GetPluginInfo() (workspace.PluginInfo, error)
 	// Configure configures the analyzer, passing configuration properties for each policy.
 	Configure(policyConfig map[string]AnalyzerPolicyConfig) error
+	// Remediate is given the inputs of a single resource before they are checked by its provider, and returns the
+	// changes that any remediation policies make to them, in the order they were applied.
+	Remediate(r AnalyzerResource) ([]Remediation, error)
 }
 
 // AnalyzerResource mirrors a resource that is passed to `Analyze`.
@@ -94,6 +97,16 @@ type AnalyzeDiagnostic struct {
 	URN               resource.URN
 }
 
+// Remediation describes the change that a remediation policy made to a resource's inputs.
+type Remediation struct {
+	PolicyName        string
+	PolicyPackName    string
+	PolicyPackVersion string
+	Description       string
+	// Properties are the resource's inputs after the remediation was applied.
+	Properties resource.PropertyMap
+}
+
 // AnalyzerInfo provides metadata about a PolicyPack inside an analyzer.
 type AnalyzerInfo struct {
 	Name           string
@@ -306,7 +306,7 @@ func (a *analyzer) GetAnalyzerInfo() (AnalyzerInfo, error) {
 			}
 			schema.Properties["enforcementLevel"] = JSONSchema{
 				"type": "string",
-				"enum": []string{"advisory", "mandatory", "disabled"},
+				"enum": []string{"advisory", "mandatory", "disabled", "remediate"},
 			}
 		}
 
@@ -412,6 +412,74 @@ func (a *analyzer) Configure(policyConfig map[string]AnalyzerPolicyConfig) error
 	return nil
 }
 
+// Remediate returns the changes that the analyzer's remediation policies make to a single resource's inputs.
+func (a *analyzer) Remediate(r AnalyzerResource) ([]Remediation, error) {
+	label := fmt.Sprintf("%s.Remediate(%s)", a.label(), r.Type)
+	logging.V(7).Infof("%s executing (#props=%d)", label, len(r.Properties))
+	mprops, err := MarshalProperties(r.Properties,
+		MarshalOptions{KeepUnknowns: true, KeepSecrets: true, SkipInternalKeys: true})
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := marshalProvider(r.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Remediate(a.ctx.Request(), &pulumirpc.AnalyzeRequest{
+		Urn:        string(r.URN),
+		Type:       string(r.Type),
+		Name:       string(r.Name),
+		Properties: mprops,
+		Options:    marshalResourceOptions(r.Options),
+		Provider:   provider,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		// Policy packs built against older SDKs do not implement Remediate, which just means that they have no
+		// remediation policies.
+		if rpcError.Code() == codes.Unimplemented {
+			logging.V(7).Infof("%s is unimplemented, skipping: err=%v", label, rpcError)
+			return nil, nil
+		}
+
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+		return nil, rpcError
+	}
+
+	remediations := resp.GetRemediations()
+	logging.V(7).Infof("%s success: remediations=#%d", label, len(remediations))
+
+	results := make([]Remediation, len(remediations))
+	for i, rem := range remediations {
+		props, err := UnmarshalProperties(rem.GetProperties(), MarshalOptions{
+			Label:         label,
+			KeepUnknowns:  true,
+			KeepSecrets:   true,
+			KeepResources: true,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "converting remediation results")
+		}
+
+		// The version from PulumiPolicy.yaml is used, if set, over the version from the remediation.
+		policyPackVersion := rem.GetPolicyPackVersion()
+		if a.version != "" {
+			policyPackVersion = a.version
+		}
+
+		results[i] = Remediation{
+			PolicyName:        rem.GetPolicyName(),
+			PolicyPackName:    rem.GetPolicyPackName(),
+			PolicyPackVersion: policyPackVersion,
+			Description:       rem.GetDescription(),
+			Properties:        props,
+		}
+	}
+	return results, nil
+}
+
 // Close tears down the underlying plugin RPC connection and process.
 func (a *analyzer) Close() error {
 	return a.plug.Close()
@@ -471,6 +539,8 @@ func marshalEnforcementLevel(el apitype.EnforcementLevel) pulumirpc.EnforcementL
 		return pulumirpc.EnforcementLevel_MANDATORY
 	case apitype.Disabled:
 		return pulumirpc.EnforcementLevel_DISABLED
+	case apitype.Remediate:
+		return pulumirpc.EnforcementLevel_REMEDIATE
 	}
 	contract.Failf("Unrecognized enforcement level %s", el)
 	return 0
@@ -590,6 +660,8 @@ func convertEnforcementLevel(el pulumirpc.EnforcementLevel) (apitype.Enforcement
 		return apitype.Mandatory, nil
 	case pulumirpc.EnforcementLevel_DISABLED:
 		return apitype.Disabled, nil
+	case pulumirpc.EnforcementLevel_REMEDIATE:
+		return apitype.Remediate, nil
 
 	default:
 		return "", fmt.Errorf("Invalid enforcement level %d", el)
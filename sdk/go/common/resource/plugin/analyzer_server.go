@@ -0,0 +1,323 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+type analyzerServer struct {
+	analyzer Analyzer
+}
+
+// NewAnalyzerServer returns a gRPC server for the Analyzer interface that forwards each call to the given analyzer.
+func NewAnalyzerServer(analyzer Analyzer) pulumirpc.AnalyzerServer {
+	return &analyzerServer{analyzer: analyzer}
+}
+
+func (a *analyzerServer) unmarshalOptions(label string) MarshalOptions {
+	return MarshalOptions{
+		Label:         label,
+		KeepUnknowns:  true,
+		KeepSecrets:   true,
+		KeepResources: true,
+	}
+}
+
+func (a *analyzerServer) unmarshalResource(label, urn, t, name string, props *structpb.Struct,
+	opts *pulumirpc.AnalyzerResourceOptions, provider *pulumirpc.AnalyzerProviderResource) (AnalyzerResource, error) {
+
+	properties, err := UnmarshalProperties(props, a.unmarshalOptions(label))
+	if err != nil {
+		return AnalyzerResource{}, err
+	}
+
+	var prov *AnalyzerProviderResource
+	if provider != nil {
+		providerProperties, err := UnmarshalProperties(provider.GetProperties(), a.unmarshalOptions(label+".provider"))
+		if err != nil {
+			return AnalyzerResource{}, err
+		}
+		prov = &AnalyzerProviderResource{
+			URN:        resource.URN(provider.GetUrn()),
+			Type:       tokens.Type(provider.GetType()),
+			Name:       tokens.QName(provider.GetName()),
+			Properties: providerProperties,
+		}
+	}
+
+	return AnalyzerResource{
+		URN:        resource.URN(urn),
+		Type:       tokens.Type(t),
+		Name:       tokens.QName(name),
+		Properties: properties,
+		Options:    unmarshalResourceOptions(opts),
+		Provider:   prov,
+	}, nil
+}
+
+func (a *analyzerServer) marshalDiagnostics(diagnostics []AnalyzeDiagnostic) *pulumirpc.AnalyzeResponse {
+	rpcDiagnostics := make([]*pulumirpc.AnalyzeDiagnostic, len(diagnostics))
+	for i, d := range diagnostics {
+		rpcDiagnostics[i] = &pulumirpc.AnalyzeDiagnostic{
+			PolicyName:        d.PolicyName,
+			PolicyPackName:    d.PolicyPackName,
+			PolicyPackVersion: d.PolicyPackVersion,
+			Description:       d.Description,
+			Message:           d.Message,
+			Tags:              d.Tags,
+			EnforcementLevel:  marshalEnforcementLevel(d.EnforcementLevel),
+			Urn:               string(d.URN),
+		}
+	}
+	return &pulumirpc.AnalyzeResponse{Diagnostics: rpcDiagnostics}
+}
+
+func (a *analyzerServer) Analyze(ctx context.Context,
+	req *pulumirpc.AnalyzeRequest) (*pulumirpc.AnalyzeResponse, error) {
+
+	r, err := a.unmarshalResource("Analyze", req.GetUrn(), req.GetType(), req.GetName(), req.GetProperties(),
+		req.GetOptions(), req.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	diagnostics, err := a.analyzer.Analyze(r)
+	if err != nil {
+		return nil, err
+	}
+	return a.marshalDiagnostics(diagnostics), nil
+}
+
+func (a *analyzerServer) AnalyzeStack(ctx context.Context,
+	req *pulumirpc.AnalyzeStackRequest) (*pulumirpc.AnalyzeResponse, error) {
+
+	resources := make([]AnalyzerStackResource, len(req.GetResources()))
+	for i, res := range req.GetResources() {
+		r, err := a.unmarshalResource("AnalyzeStack", res.GetUrn(), res.GetType(), res.GetName(),
+			res.GetProperties(), res.GetOptions(), res.GetProvider())
+		if err != nil {
+			return nil, err
+		}
+
+		propertyDeps := make(map[resource.PropertyKey][]resource.URN)
+		for k, deps := range res.GetPropertyDependencies() {
+			propertyDeps[resource.PropertyKey(k)] = unmarshalURNs(deps.GetUrns())
+		}
+
+		resources[i] = AnalyzerStackResource{
+			AnalyzerResource:     r,
+			Parent:               resource.URN(res.GetParent()),
+			Dependencies:         unmarshalURNs(res.GetDependencies()),
+			PropertyDependencies: propertyDeps,
+		}
+	}
+
+	diagnostics, err := a.analyzer.AnalyzeStack(resources)
+	if err != nil {
+		return nil, err
+	}
+	return a.marshalDiagnostics(diagnostics), nil
+}
+
+func (a *analyzerServer) GetAnalyzerInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.AnalyzerInfo, error) {
+	info, err := a.analyzer.GetAnalyzerInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]*pulumirpc.PolicyInfo, len(info.Policies))
+	for i, p := range info.Policies {
+		var schema *pulumirpc.PolicyConfigSchema
+		if p.ConfigSchema != nil {
+			properties := make(map[string]interface{}, len(p.ConfigSchema.Properties))
+			for k, v := range p.ConfigSchema.Properties {
+				properties[k] = map[string]interface{}(v)
+			}
+			props, err := marshalJSONMap(properties)
+			if err != nil {
+				return nil, fmt.Errorf("marshaling config schema of policy %q: %w", p.Name, err)
+			}
+			schema = &pulumirpc.PolicyConfigSchema{Properties: props, Required: p.ConfigSchema.Required}
+		}
+
+		policies[i] = &pulumirpc.PolicyInfo{
+			Name:             p.Name,
+			DisplayName:      p.DisplayName,
+			Description:      p.Description,
+			Message:          p.Message,
+			EnforcementLevel: marshalEnforcementLevel(p.EnforcementLevel),
+			ConfigSchema:     schema,
+		}
+	}
+
+	initialConfig := make(map[string]*pulumirpc.PolicyConfig, len(info.InitialConfig))
+	for k, v := range info.InitialConfig {
+		props, err := marshalJSONMap(v.Properties)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling initial config of policy %q: %w", k, err)
+		}
+		initialConfig[k] = &pulumirpc.PolicyConfig{
+			EnforcementLevel: marshalEnforcementLevel(v.EnforcementLevel),
+			Properties:       props,
+		}
+	}
+
+	return &pulumirpc.AnalyzerInfo{
+		Name:           info.Name,
+		DisplayName:    info.DisplayName,
+		Version:        info.Version,
+		SupportsConfig: info.SupportsConfig,
+		Policies:       policies,
+		InitialConfig:  initialConfig,
+	}, nil
+}
+
+func (a *analyzerServer) GetPluginInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	info, err := a.analyzer.GetPluginInfo()
+	if err != nil {
+		return nil, err
+	}
+	var version string
+	if info.Version != nil {
+		version = info.Version.String()
+	}
+	return &pulumirpc.PluginInfo{Version: version}, nil
+}
+
+func (a *analyzerServer) Configure(ctx context.Context,
+	req *pulumirpc.ConfigureAnalyzerRequest) (*pbempty.Empty, error) {
+
+	config := make(map[string]AnalyzerPolicyConfig, len(req.GetPolicyConfig()))
+	for k, v := range req.GetPolicyConfig() {
+		enforcementLevel, err := convertEnforcementLevel(v.GetEnforcementLevel())
+		if err != nil {
+			return nil, err
+		}
+		config[k] = AnalyzerPolicyConfig{
+			EnforcementLevel: enforcementLevel,
+			Properties:       unmarshalMap(v.GetProperties()),
+		}
+	}
+
+	if err := a.analyzer.Configure(config); err != nil {
+		return nil, err
+	}
+	return &pbempty.Empty{}, nil
+}
+
+func (a *analyzerServer) Remediate(ctx context.Context,
+	req *pulumirpc.AnalyzeRequest) (*pulumirpc.RemediateResponse, error) {
+
+	r, err := a.unmarshalResource("Remediate", req.GetUrn(), req.GetType(), req.GetName(), req.GetProperties(),
+		req.GetOptions(), req.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	remediations, err := a.analyzer.Remediate(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcRemediations := make([]*pulumirpc.Remediation, len(remediations))
+	for i, rem := range remediations {
+		props, err := MarshalProperties(rem.Properties, MarshalOptions{
+			Label:         "Remediate",
+			KeepUnknowns:  true,
+			KeepSecrets:   true,
+			KeepResources: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("marshaling remediation of policy %q: %w", rem.PolicyName, err)
+		}
+		rpcRemediations[i] = &pulumirpc.Remediation{
+			PolicyName:        rem.PolicyName,
+			PolicyPackName:    rem.PolicyPackName,
+			PolicyPackVersion: rem.PolicyPackVersion,
+			Description:       rem.Description,
+			Properties:        props,
+		}
+	}
+	return &pulumirpc.RemediateResponse{Remediations: rpcRemediations}, nil
+}
+
+func unmarshalResourceOptions(opts *pulumirpc.AnalyzerResourceOptions) AnalyzerResourceOptions {
+	if opts == nil {
+		return AnalyzerResourceOptions{}
+	}
+
+	secs := make([]resource.PropertyKey, len(opts.GetAdditionalSecretOutputs()))
+	for i, k := range opts.GetAdditionalSecretOutputs() {
+		secs[i] = resource.PropertyKey(k)
+	}
+
+	var deleteBeforeReplace *bool
+	if opts.GetDeleteBeforeReplaceDefined() {
+		dbr := opts.GetDeleteBeforeReplace()
+		deleteBeforeReplace = &dbr
+	}
+
+	result := AnalyzerResourceOptions{
+		Protect:                 opts.GetProtect(),
+		IgnoreChanges:           opts.GetIgnoreChanges(),
+		DeleteBeforeReplace:     deleteBeforeReplace,
+		AdditionalSecretOutputs: secs,
+		Aliases:                 unmarshalURNs(opts.GetAliases()),
+	}
+	if timeouts := opts.GetCustomTimeouts(); timeouts != nil {
+		result.CustomTimeouts = resource.CustomTimeouts{
+			Create: timeouts.GetCreate(),
+			Update: timeouts.GetUpdate(),
+			Delete: timeouts.GetDelete(),
+		}
+	}
+	return result
+}
+
+func unmarshalURNs(urns []string) []resource.URN {
+	result := make([]resource.URN, len(urns))
+	for i, urn := range urns {
+		result[i] = resource.URN(urn)
+	}
+	return result
+}
+
+// marshalJSONMap marshals a map of arbitrary JSON-compatible values, such as the ints and typed slices that policy
+// authors may use in a config schema, by first normalizing it through JSON.
+func marshalJSONMap(m map[string]interface{}) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	bytes, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(bytes, &normalized); err != nil {
+		return nil, err
+	}
+	return marshalMap(normalized), nil
+}
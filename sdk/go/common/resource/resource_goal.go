@@ -38,6 +38,11 @@ type Goal struct {
 	ID                      ID                    // the expected ID of the resource, if any.
 	CustomTimeouts          CustomTimeouts        // an optional config object for resource options
 	ReplaceOnChanges        []string              // a list of property paths that if changed should force a replacement.
+	RetainOnDelete          bool                  // if true the resource will not be deleted by its provider.
+	DeletedWith             URN                   // if set, skip deleting the resource when this resource is deleted.
+	RetryPolicy             *RetryPolicy          // an optional policy for retrying failed provider operations.
+	MaxConcurrency          int                   // if set on a provider, the maximum number of concurrent operations.
+	RefuseReplace           bool                  // if true, the deployment will fail rather than replace this resource.
 }
 
 // NewGoal allocates a new resource goal state.
@@ -45,7 +50,8 @@ func NewGoal(t tokens.Type, name tokens.QName, custom bool, props PropertyMap,
 	parent URN, protect bool, dependencies []URN, provider string, initErrors []string,
 	propertyDependencies map[PropertyKey][]URN, deleteBeforeReplace *bool, ignoreChanges []string,
 	additionalSecretOutputs []PropertyKey, aliases []URN, id ID, customTimeouts *CustomTimeouts,
-	replaceOnChanges []string) *Goal {
+	replaceOnChanges []string, retainOnDelete bool, deletedWith URN, retryPolicy *RetryPolicy,
+	maxConcurrency int, refuseReplace bool) *Goal {
 
 	g := &Goal{
 		Type:                    t,
@@ -64,6 +70,11 @@ func NewGoal(t tokens.Type, name tokens.QName, custom bool, props PropertyMap,
 		Aliases:                 aliases,
 		ID:                      id,
 		ReplaceOnChanges:        replaceOnChanges,
+		RetainOnDelete:          retainOnDelete,
+		DeletedWith:             deletedWith,
+		RetryPolicy:             retryPolicy,
+		MaxConcurrency:          maxConcurrency,
+		RefuseReplace:           refuseReplace,
 	}
 
 	if customTimeouts != nil {
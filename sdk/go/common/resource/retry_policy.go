@@ -0,0 +1,27 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import "regexp"
+
+// RetryPolicy configures how the engine retries provider operations that fail with transient errors.
+type RetryPolicy struct {
+	MaxAttempts int      `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"` // attempts, including the first.
+	Backoff     float64  `json:"backoff,omitempty" yaml:"backoff,omitempty"`         // seconds before the first retry.
+	Errors      []string `json:"errors,omitempty" yaml:"errors,omitempty"`           // patterns of retryable errors.
+
+	// ErrorPatterns holds the compiled Errors patterns. It is populated when the policy is validated.
+	ErrorPatterns []*regexp.Regexp `json:"-" yaml:"-"`
+}
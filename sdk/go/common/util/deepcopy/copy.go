@@ -14,7 +14,12 @@
 
 package deepcopy
 
-import "reflect"
+import (
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
 
 // Copy returns a deep copy of the provided value.
 //
@@ -89,6 +94,11 @@ func copy(v reflect.Value) reflect.Value {
 		}
 		return rv
 	case reflect.Struct:
+		// Times are immutable values whose fields are all unexported. Return them as-is.
+		if typ == timeType {
+			return v
+		}
+
 		rv := reflect.New(typ).Elem()
 		for i := 0; i < typ.NumField(); i++ {
 			if f := rv.Field(i); f.CanSet() {
@@ -17,6 +17,7 @@ package deepcopy
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -47,6 +48,7 @@ func TestDeepCopy(t *testing.T) {
 			"a": 42,
 			"b": 24,
 		},
+		time.Date(2022, time.January, 2, 3, 4, 5, 6, time.UTC),
 		struct {
 			Foo int
 			Bar map[int]int
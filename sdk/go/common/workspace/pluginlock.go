@@ -0,0 +1,191 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/blang/semver"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// PluginLockFile is the name of the file, next to a project's Pulumi.yaml, that pins the plugins the project uses.
+const PluginLockFile = "Pulumi.lock"
+
+// PluginLock pins the exact version of each plugin that a project uses, along with the SHA-256 of the plugin's
+// tarball for every platform on which it has been locked. Plugins are verified against the lock when installed.
+type PluginLock struct {
+	Plugins []PluginLockEntry `json:"plugins" yaml:"plugins"`
+}
+
+// PluginLockEntry pins a single version of a plugin.
+type PluginLockEntry struct {
+	Kind              PluginKind        `json:"kind" yaml:"kind"`
+	Name              string            `json:"name" yaml:"name"`
+	Version           string            `json:"version" yaml:"version"`
+	PluginDownloadURL string            `json:"pluginDownloadURL,omitempty" yaml:"pluginDownloadURL,omitempty"`
+	Checksums         map[string]string `json:"checksums" yaml:"checksums"` // SHA-256s, keyed by "<os>-<arch>".
+}
+
+// PluginLockPath returns the path of the plugin lock file for the project rooted at the given directory.
+func PluginLockPath(root string) string {
+	return filepath.Join(root, PluginLockFile)
+}
+
+// LoadPluginLock reads a plugin lock file. If the file does not exist, the error satisfies os.IsNotExist.
+func LoadPluginLock(path string) (*PluginLock, error) {
+	contract.Require(path != "", "path")
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var lock PluginLock
+	if err := encoding.YAML.Unmarshal(b, &lock); err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+	for i, entry := range lock.Plugins {
+		if !IsPluginKind(string(entry.Kind)) || entry.Name == "" {
+			return nil, fmt.Errorf("invalid plugin %d in %s: a kind and name are required", i+1, path)
+		}
+		if _, err := semver.ParseTolerant(entry.Version); err != nil {
+			return nil, fmt.Errorf("invalid version for plugin %s in %s: %w", entry.Name, path, err)
+		}
+	}
+	return &lock, nil
+}
+
+// Save writes the plugin lock to the given path, with its plugins sorted by kind, name and version.
+func (l *PluginLock) Save(path string) error {
+	contract.Require(path != "", "path")
+
+	sort.SliceStable(l.Plugins, func(i, j int) bool {
+		a, b := l.Plugins[i], l.Plugins[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		va, _ := semver.ParseTolerant(a.Version)
+		vb, _ := semver.ParseTolerant(b.Version)
+		return va.LT(vb)
+	})
+
+	b, err := encoding.YAML.Marshal(l)
+	if err != nil {
+		return err
+	}
+	//nolint: gosec
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// Find returns the entry that pins the given plugin's version, or nil if the plugin is not locked at that version.
+func (l *PluginLock) Find(info PluginInfo) *PluginLockEntry {
+	if l == nil || info.Version == nil {
+		return nil
+	}
+	for i, entry := range l.Plugins {
+		if entry.Kind != info.Kind || entry.Name != info.Name {
+			continue
+		}
+		if v, err := semver.ParseTolerant(entry.Version); err == nil && v.EQ(*info.Version) {
+			return &l.Plugins[i]
+		}
+	}
+	return nil
+}
+
+// Pin returns the given plugin at the version that the lock pins it to. A plugin without a version is pinned to the
+// only version of it in the lock. It returns an error if the plugin is not pinned, or if it has no version and the
+// lock pins more than one.
+func (l *PluginLock) Pin(info PluginInfo) (PluginInfo, error) {
+	if info.Version != nil {
+		if l.Find(info) == nil {
+			return PluginInfo{}, fmt.Errorf("%s plugin %s is not pinned in %s; run `pulumi plugin lock` to update it",
+				info.Kind, info, PluginLockFile)
+		}
+		return info, nil
+	}
+
+	var pinned *PluginLockEntry
+	for i, entry := range l.Plugins {
+		if entry.Kind != info.Kind || entry.Name != info.Name {
+			continue
+		}
+		if pinned != nil {
+			return PluginInfo{}, fmt.Errorf("%s plugin %s is pinned at more than one version in %s; "+
+				"specify the version to use", info.Kind, info.Name, PluginLockFile)
+		}
+		pinned = &l.Plugins[i]
+	}
+	if pinned == nil {
+		return PluginInfo{}, fmt.Errorf("%s plugin %s is not pinned in %s; run `pulumi plugin lock` to update it",
+			info.Kind, info.Name, PluginLockFile)
+	}
+
+	version, err := semver.ParseTolerant(pinned.Version)
+	if err != nil {
+		return PluginInfo{}, fmt.Errorf("invalid version for plugin %s in %s: %w", info.Name, PluginLockFile, err)
+	}
+	info.Version = &version
+	if info.PluginDownloadURL == "" {
+		info.PluginDownloadURL = pinned.PluginDownloadURL
+	}
+	return info, nil
+}
+
+// Checksum returns the SHA-256 that the given plugin's tarball must have to be installed on the given platform. It
+// returns an error if the plugin is not locked at its version or has not been locked for the platform.
+func (l *PluginLock) Checksum(info PluginInfo, platform string) (string, error) {
+	entry := l.Find(info)
+	if entry == nil {
+		return "", fmt.Errorf("%s plugin %s is not pinned in %s; run `pulumi plugin lock` to update it",
+			info.Kind, info, PluginLockFile)
+	}
+	checksum, ok := entry.Checksums[platform]
+	if !ok || checksum == "" {
+		return "", fmt.Errorf("%s plugin %s has no checksum for %s in %s; run `pulumi plugin lock --platform %s`",
+			info.Kind, info, platform, PluginLockFile, platform)
+	}
+	return checksum, nil
+}
+
+// Set pins the given plugin's version with the checksum of its tarball for the given platform. Checksums recorded
+// for other platforms are kept if the plugin was already locked at the same version.
+func (l *PluginLock) Set(info PluginInfo, platform, checksum string) {
+	contract.Requiref(info.Version != nil, "info", "plugin must have a version")
+
+	entry := l.Find(info)
+	if entry == nil {
+		l.Plugins = append(l.Plugins, PluginLockEntry{
+			Kind:    info.Kind,
+			Name:    info.Name,
+			Version: info.Version.String(),
+		})
+		entry = &l.Plugins[len(l.Plugins)-1]
+	}
+	entry.PluginDownloadURL = info.PluginDownloadURL
+	if entry.Checksums == nil {
+		entry.Checksums = map[string]string{}
+	}
+	entry.Checksums[platform] = checksum
+}
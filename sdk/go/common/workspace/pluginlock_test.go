@@ -0,0 +1,162 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginLockRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "plugin-lock-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := PluginLockPath(dir)
+
+	_, err = LoadPluginLock(path)
+	assert.True(t, os.IsNotExist(err))
+
+	aws5 := semver.MustParse("5.1.0")
+	aws4 := semver.MustParse("4.38.1")
+	random := semver.MustParse("4.8.0")
+
+	var lock PluginLock
+	lock.Set(PluginInfo{Kind: ResourcePlugin, Name: "random", Version: &random}, "linux-amd64", "aa")
+	lock.Set(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &aws5}, "linux-amd64", "bb")
+	lock.Set(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &aws4}, "linux-amd64", "cc")
+	lock.Set(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &aws5}, "darwin-arm64", "dd")
+	require.NoError(t, lock.Save(path))
+
+	loaded, err := LoadPluginLock(path)
+	require.NoError(t, err)
+	assert.Equal(t, []PluginLockEntry{
+		{Kind: ResourcePlugin, Name: "aws", Version: "4.38.1", Checksums: map[string]string{"linux-amd64": "cc"}},
+		{
+			Kind:      ResourcePlugin,
+			Name:      "aws",
+			Version:   "5.1.0",
+			Checksums: map[string]string{"linux-amd64": "bb", "darwin-arm64": "dd"},
+		},
+		{Kind: ResourcePlugin, Name: "random", Version: "4.8.0", Checksums: map[string]string{"linux-amd64": "aa"}},
+	}, loaded.Plugins)
+
+	// Invalid entries are rejected.
+	require.NoError(t, ioutil.WriteFile(path, []byte("plugins:\n- kind: bogus\n  name: aws\n  version: 1.0.0\n"), 0600))
+	_, err = LoadPluginLock(path)
+	assert.Error(t, err)
+	require.NoError(t, ioutil.WriteFile(path, []byte("plugins:\n- kind: resource\n  name: aws\n  version: x\n"), 0600))
+	_, err = LoadPluginLock(path)
+	assert.Error(t, err)
+}
+
+func TestPluginLockChecksum(t *testing.T) {
+	t.Parallel()
+
+	v1 := semver.MustParse("1.0.0")
+	v2 := semver.MustParse("2.0.0")
+	aws := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v1}
+
+	lock := &PluginLock{Plugins: []PluginLockEntry{{
+		Kind:      ResourcePlugin,
+		Name:      "aws",
+		Version:   "v1.0.0",
+		Checksums: map[string]string{"linux-amd64": "abc"},
+	}}}
+
+	checksum, err := lock.Checksum(aws, "linux-amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", checksum)
+
+	// Plugins must be locked for the platform...
+	_, err = lock.Checksum(aws, "darwin-arm64")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "no checksum for darwin-arm64")
+	}
+
+	// ...and at their exact version.
+	_, err = lock.Checksum(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v2}, "linux-amd64")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "not pinned")
+	}
+	_, err = lock.Checksum(PluginInfo{Kind: AnalyzerPlugin, Name: "aws", Version: &v1}, "linux-amd64")
+	assert.Error(t, err)
+
+	var none *PluginLock
+	assert.Nil(t, none.Find(aws))
+}
+
+func TestPluginLockPin(t *testing.T) {
+	t.Parallel()
+
+	v1 := semver.MustParse("1.0.0")
+	v2 := semver.MustParse("2.0.0")
+	lock := &PluginLock{Plugins: []PluginLockEntry{
+		{Kind: ResourcePlugin, Name: "aws", Version: "1.0.0", PluginDownloadURL: "https://example.com"},
+		{Kind: ResourcePlugin, Name: "random", Version: "1.0.0"},
+		{Kind: ResourcePlugin, Name: "random", Version: "2.0.0"},
+	}}
+
+	// Plugins with versions must be pinned at exactly that version.
+	pinned, err := lock.Pin(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v1})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", pinned.Version.String())
+	_, err = lock.Pin(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v2})
+	assert.Error(t, err)
+
+	// Plugins without versions are pinned to the locked version, if there is only one.
+	pinned, err = lock.Pin(PluginInfo{Kind: ResourcePlugin, Name: "aws"})
+	require.NoError(t, err)
+	require.NotNil(t, pinned.Version)
+	assert.Equal(t, "1.0.0", pinned.Version.String())
+	assert.Equal(t, "https://example.com", pinned.PluginDownloadURL)
+	_, err = lock.Pin(PluginInfo{Kind: ResourcePlugin, Name: "random"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "more than one version")
+	}
+	_, err = lock.Pin(PluginInfo{Kind: ResourcePlugin, Name: "gcp"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "not pinned")
+	}
+}
+
+func TestVerifyPluginTarball(t *testing.T) {
+	t.Parallel()
+
+	content := "not really a tarball"
+	checksum, err := PluginChecksum(strings.NewReader(content))
+	require.NoError(t, err)
+	assert.Len(t, checksum, 64)
+
+	f, err := verifyPluginTarball(strings.NewReader(content), strings.ToUpper(checksum))
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	b, err := ioutil.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(b))
+	assert.NoError(t, f.Close())
+
+	_, err = verifyPluginTarball(strings.NewReader(content+"!"), checksum)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "checksum mismatch")
+	}
+}
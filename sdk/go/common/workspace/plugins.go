@@ -16,6 +16,8 @@ package workspace
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -50,6 +52,10 @@ import (
 
 const (
 	windowsGOOS = "windows"
+
+	// PluginMirrorEnvVar is the name of the environment variable that points plugin downloads at a mirror: either
+	// an HTTP(S) URL or a local directory holding plugin tarballs.
+	PluginMirrorEnvVar = "PULUMI_PLUGIN_MIRROR"
 )
 
 var (
@@ -93,6 +99,7 @@ type PluginInfo struct {
 	LastUsedTime      time.Time       // the last time the plugin was used.
 	PluginDownloadURL string          // an optional server to use when downloading this plugin.
 	PluginDir         string          // if set, will be used as the root plugin dir instead of ~/.pulumi/plugins.
+	Checksum          string          // if set, the SHA-256 that the plugin's tarball must have to be installed.
 }
 
 // Dir gets the expected plugin directory for this plugin.
@@ -182,6 +189,28 @@ func (info PluginInfo) Delete() error {
 	return nil
 }
 
+// Prune removes the plugin from the cache while holding its install lock, so that a plugin is never removed while it
+// is being installed. Unlike Delete, it leaves the lock file in place, since concurrent installs may be waiting on it.
+func (info PluginInfo) Prune() error {
+	unlock, err := info.installLock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	dir, err := info.DirPath()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.Remove(fmt.Sprintf("%s.partial", dir)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
 // SetFileMetadata adds extra metadata from the given file, representing this plugin's directory.
 func (info *PluginInfo) SetFileMetadata(path string) error {
 	// Get the file info.
@@ -218,25 +247,92 @@ func interpolateURL(serverURL string, version semver.Version, os, arch string) s
 
 // Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).
 func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
-	// Figure out the OS/ARCH pair for the download URL.
-	var os string
-	switch runtime.GOOS {
+	platform, err := CurrentPluginPlatform()
+	if err != nil {
+		return nil, -1, err
+	}
+	return info.DownloadForPlatform(platform)
+}
+
+// CurrentPluginPlatform returns the platform, in the form "<os>-<arch>", of the plugins that run on this machine.
+func CurrentPluginPlatform() (string, error) {
+	platform := fmt.Sprintf("%s-%s", runtime.GOOS, runtime.GOARCH)
+	if _, _, err := parsePluginPlatform(platform); err != nil {
+		return "", err
+	}
+	return platform, nil
+}
+
+// parsePluginPlatform splits a platform of the form "<os>-<arch>" into its OS and architecture, and validates that
+// plugins are published for them.
+func parsePluginPlatform(platform string) (string, string, error) {
+	parts := strings.SplitN(platform, "-", 2)
+	if len(parts) != 2 {
+		return "", "", errors.Errorf("invalid plugin platform %q: expected <os>-<arch>", platform)
+	}
+	os, arch := parts[0], parts[1]
+	switch os {
 	case "darwin", "linux", "windows":
-		os = runtime.GOOS
 	default:
-		return nil, -1, errors.Errorf("unsupported plugin OS: %s", runtime.GOOS)
+		return "", "", errors.Errorf("unsupported plugin OS: %s", os)
 	}
-	var arch string
-	switch runtime.GOARCH {
+	switch arch {
 	case "amd64", "arm64":
-		arch = runtime.GOARCH
 	default:
-		return nil, -1, errors.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
+		return "", "", errors.Errorf("unsupported plugin architecture: %s", arch)
 	}
+	return os, arch, nil
+}
 
-	// The plugin version is necessary for the endpoint. If it's not present, return an error.
+// TarballName returns the file name of the plugin's tarball for the given platform, of the form "<os>-<arch>".
+// Plugin servers, mirrors and bundles all name tarballs this way.
+func (info PluginInfo) TarballName(platform string) (string, error) {
+	os, arch, err := parsePluginPlatform(platform)
+	if err != nil {
+		return "", err
+	}
 	if info.Version == nil {
-		return nil, -1, errors.Errorf("unknown version for plugin %s", info.Name)
+		return "", errors.Errorf("unknown version for plugin %s", info.Name)
+	}
+	return fmt.Sprintf("pulumi-%s-%s-v%s-%s-%s.tar.gz", info.Kind, info.Name, info.Version, os, arch), nil
+}
+
+var pluginTarballRegexp = regexp.MustCompile(
+	`^pulumi-(analyzer|language|resource)-(.+?)-v(\d.*)-((?:darwin|linux|windows)-(?:amd64|arm64))\.tar\.gz$`)
+
+// ParsePluginTarballName parses the file name of a plugin's tarball, as returned by TarballName, into the
+// plugin's kind, name and version, and the platform the tarball is for. It returns false if the name is not that of
+// a plugin's tarball.
+func ParsePluginTarballName(name string) (PluginInfo, string, bool) {
+	m := pluginTarballRegexp.FindStringSubmatch(name)
+	if m == nil {
+		return PluginInfo{}, "", false
+	}
+	version, err := semver.Parse(m[3])
+	if err != nil {
+		return PluginInfo{}, "", false
+	}
+	return PluginInfo{Kind: PluginKind(m[1]), Name: m[2], Version: &version}, m[4], true
+}
+
+// DownloadForPlatform fetches an io.ReadCloser for this plugin's tarball for the given platform, of the form
+// "<os>-<arch>", and also returns the size of the response (if known). If PULUMI_PLUGIN_MIRROR is set, the tarball
+// is fetched from the mirror instead of the plugin's server.
+func (info PluginInfo) DownloadForPlatform(platform string) (io.ReadCloser, int64, error) {
+	if mirror := os.Getenv(PluginMirrorEnvVar); mirror != "" {
+		return info.downloadFromMirror(mirror, platform)
+	}
+
+	// Figure out the OS/ARCH pair for the download URL.
+	os, arch, err := parsePluginPlatform(platform)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	// The plugin version is necessary for the endpoint. If it's not present, return an error.
+	name, err := info.TarballName(platform)
+	if err != nil {
+		return nil, -1, err
 	}
 
 	// If the plugin has a server, associated with it, download from there.  Otherwise use the "default" location, which
@@ -251,10 +347,45 @@ func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
 	logging.V(1).Infof("%s downloading from %s", info.Name, serverURL)
 
 	// URL escape the path value to ensure we have the correct path for S3/CloudFront.
-	endpoint := fmt.Sprintf("%s/%s",
-		serverURL,
-		url.QueryEscape(fmt.Sprintf("pulumi-%s-%s-v%s-%s-%s.tar.gz", info.Kind, info.Name, info.Version, os, arch)))
+	endpoint := fmt.Sprintf("%s/%s", serverURL, url.QueryEscape(name))
+
+	return downloadPluginTarball(endpoint)
+}
+
+// downloadFromMirror fetches this plugin's tarball for the given platform from a mirror. The mirror is either an
+// HTTP(S) URL or a local directory, optionally given as a file:// URL, that holds tarballs named as returned by
+// TarballName; a directory extracted from a plugin bundle is a valid mirror.
+func (info PluginInfo) downloadFromMirror(mirror, platform string) (io.ReadCloser, int64, error) {
+	name, err := info.TarballName(platform)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if strings.HasPrefix(mirror, "http://") || strings.HasPrefix(mirror, "https://") {
+		logging.V(1).Infof("%s downloading from mirror %s", info.Name, mirror)
+		return downloadPluginTarball(fmt.Sprintf("%s/%s", strings.TrimSuffix(mirror, "/"), url.PathEscape(name)))
+	}
+
+	path := filepath.Join(strings.TrimPrefix(mirror, "file://"), name)
+	logging.V(1).Infof("%s opening tarball from mirror %s", info.Name, path)
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, -1, errors.Errorf("plugin tarball %s not found in mirror %s", name, mirror)
+		}
+		return nil, -1, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		contract.IgnoreClose(f)
+		return nil, -1, err
+	}
+	return f, stat.Size(), nil
+}
 
+// downloadPluginTarball fetches a plugin's tarball from the given URL and also returns the size of the response (if
+// known).
+func downloadPluginTarball(endpoint string) (io.ReadCloser, int64, error) {
 	logging.V(9).Infof("full plugin download url: %s", endpoint)
 
 	req, err := http.NewRequest("GET", endpoint, nil)
@@ -364,6 +495,20 @@ func (info PluginInfo) Install(tgz io.ReadCloser) error {
 		return finalDirStatErr
 	}
 
+	// If the tarball's checksum is known, verify it before extracting anything.
+	if info.Checksum != "" {
+		verified, err := verifyPluginTarball(tgz, info.Checksum)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			contract.IgnoreClose(verified)
+			contract.IgnoreError(os.Remove(verified.Name()))
+		}()
+		contract.IgnoreClose(tgz)
+		tgz = verified
+	}
+
 	// Create an empty partial file to indicate installation is in-progress.
 	if err := ioutil.WriteFile(partialFilePath, nil, 0600); err != nil {
 		return err
@@ -413,6 +558,41 @@ func (info PluginInfo) Install(tgz io.ReadCloser) error {
 	return os.Remove(partialFilePath)
 }
 
+// verifyPluginTarball copies a plugin's tarball to a temporary file, computing its SHA-256 as it goes, and returns
+// the file, positioned at its start, if the checksum matches the expected one.
+func verifyPluginTarball(tgz io.Reader, checksum string) (*os.File, error) {
+	f, err := ioutil.TempFile("", "pulumi-plugin-*.tar.gz")
+	if err != nil {
+		return nil, err
+	}
+	fail := func(err error) (*os.File, error) {
+		contract.IgnoreClose(f)
+		contract.IgnoreError(os.Remove(f.Name()))
+		return nil, err
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, hash), tgz); err != nil {
+		return fail(err)
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, checksum) {
+		return fail(errors.Errorf("checksum mismatch: expected SHA-256 %s, got %s", checksum, actual))
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fail(err)
+	}
+	return f, nil
+}
+
+// PluginChecksum returns the hex-encoded SHA-256 of a plugin's tarball.
+func PluginChecksum(tgz io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, tgz); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // cleanupTempDirs cleans up leftover temp dirs from failed installs with previous versions of Pulumi.
 func cleanupTempDirs(finalDir string) error {
 	dir := filepath.Dir(finalDir)
@@ -739,6 +919,54 @@ func (sp SortedPluginInfo) Less(i, j int) bool {
 }
 func (sp SortedPluginInfo) Swap(i, j int) { sp[i], sp[j] = sp[j], sp[i] }
 
+// SelectPluginsToPrune selects the plugins in the given list that can be removed from the cache while keeping the
+// newest keep versions of each plugin, as well as every version in the list of referenced plugins. A referenced plugin
+// without a version keeps the newest version of that plugin. Plugins without a version are never selected.
+func SelectPluginsToPrune(plugins []PluginInfo, keep int, referenced []PluginInfo) []PluginInfo {
+	key := func(p PluginInfo) string {
+		return fmt.Sprintf("%s-%s", p.Kind, p.Name)
+	}
+
+	// Group the versioned plugins by kind and name, each sorted from newest to oldest.
+	var keys []string
+	groups := map[string][]PluginInfo{}
+	for _, p := range plugins {
+		if p.Version == nil {
+			continue
+		}
+		k := key(p)
+		if _, has := groups[k]; !has {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], p)
+	}
+	sort.Strings(keys)
+
+	var prune []PluginInfo
+	for _, k := range keys {
+		group := groups[k]
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].Version.GT(*group[j].Version)
+		})
+
+		for i, p := range group {
+			kept := i < keep
+			for _, r := range referenced {
+				if key(r) != k {
+					continue
+				}
+				if (r.Version == nil && i == 0) || (r.Version != nil && r.Version.EQ(*p.Version)) {
+					kept = true
+				}
+			}
+			if !kept {
+				prune = append(prune, p)
+			}
+		}
+	}
+	return prune
+}
+
 // SelectCompatiblePlugin selects a plugin from the list of plugins with the given kind and name that sastisfies the
 // requested semver range. It returns the highest version plugin that satisfies the requested constraints, or an error
 // if no such plugin could be found.
@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 
@@ -172,6 +173,40 @@ func TestInstallNoDeps(t *testing.T) {
 	testDeletePlugin(t, dir, plugin)
 }
 
+func TestInstallChecksum(t *testing.T) {
+	name := "foo.txt"
+	content := []byte("hello\n")
+
+	dir, tarball, plugin := prepareTestDir(t, map[string][]byte{name: content})
+	defer os.RemoveAll(dir)
+
+	tgz, err := ioutil.ReadAll(tarball)
+	assert.NoError(t, err)
+	checksum, err := PluginChecksum(bytes.NewReader(tgz))
+	assert.NoError(t, err)
+
+	// A tarball that does not match the expected checksum is not extracted.
+	plugin.Checksum = strings.Repeat("0", len(checksum))
+	err = plugin.Install(ioutil.NopCloser(bytes.NewReader(tgz)))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "checksum mismatch")
+	}
+	_, err = os.Stat(filepath.Join(dir, plugin.Dir()))
+	assert.True(t, os.IsNotExist(err))
+
+	plugin.Checksum = checksum
+	err = plugin.Install(ioutil.NopCloser(bytes.NewReader(tgz)))
+	assert.NoError(t, err)
+
+	assertPluginInstalled(t, dir, plugin)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, plugin.Dir(), name))
+	assert.NoError(t, err)
+	assert.Equal(t, content, b)
+
+	testDeletePlugin(t, dir, plugin)
+}
+
 func TestConcurrentInstalls(t *testing.T) {
 	name := "foo.txt"
 	content := []byte("hello\n")
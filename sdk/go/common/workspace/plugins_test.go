@@ -15,6 +15,9 @@
 package workspace
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/blang/semver"
@@ -250,3 +253,148 @@ func TestInterpolateURL(t *testing.T) {
 		"https://github.com/org/repo/releases/download/1.0.0/linux/amd64",
 		interpolateURL("https://github.com/org/repo/releases/download/${VERSION}/${OS}/${ARCH}", version, os, arch))
 }
+
+func TestPluginTarballName(t *testing.T) {
+	t.Parallel()
+
+	version := semver.MustParse("4.0.0-alpha.1+dev")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "azure-native", Version: &version}
+	name, err := info.TarballName("linux-arm64")
+	assert.NoError(t, err)
+	assert.Equal(t, "pulumi-resource-azure-native-v4.0.0-alpha.1+dev-linux-arm64.tar.gz", name)
+
+	parsed, platform, ok := ParsePluginTarballName(name)
+	assert.True(t, ok)
+	assert.Equal(t, "linux-arm64", platform)
+	assert.Equal(t, info.Kind, parsed.Kind)
+	assert.Equal(t, info.Name, parsed.Name)
+	assert.Equal(t, version, *parsed.Version)
+
+	_, err = info.TarballName("plan9-amd64")
+	assert.Error(t, err)
+	_, err = PluginInfo{Kind: ResourcePlugin, Name: "aws"}.TarballName("linux-amd64")
+	assert.Error(t, err)
+
+	for _, name := range []string{
+		"pulumi-resource-aws-v5.0.0-linux-amd64.tgz",
+		"pulumi-tool-aws-v5.0.0-linux-amd64.tar.gz",
+		"pulumi-resource-aws-v5.0.0-plan9-amd64.tar.gz",
+		"pulumi-resource-aws-vlatest-linux-amd64.tar.gz",
+	} {
+		_, _, ok := ParsePluginTarballName(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestDownloadFromMirror(t *testing.T) {
+	dir := t.TempDir()
+	version := semver.MustParse("5.0.0")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &version, PluginDownloadURL: "http://localhost:0"}
+	err := ioutil.WriteFile(filepath.Join(dir, "pulumi-resource-aws-v5.0.0-linux-amd64.tar.gz"), []byte("tgz"), 0600)
+	assert.NoError(t, err)
+
+	old, hadOld := os.LookupEnv(PluginMirrorEnvVar)
+	defer func() {
+		if hadOld {
+			os.Setenv(PluginMirrorEnvVar, old)
+		} else {
+			os.Unsetenv(PluginMirrorEnvVar)
+		}
+	}()
+
+	for _, mirror := range []string{dir, "file://" + dir} {
+		os.Setenv(PluginMirrorEnvVar, mirror)
+		tarball, size, err := info.DownloadForPlatform("linux-amd64")
+		if assert.NoError(t, err) {
+			b, err := ioutil.ReadAll(tarball)
+			assert.NoError(t, err)
+			assert.Equal(t, "tgz", string(b))
+			assert.Equal(t, int64(3), size)
+			assert.NoError(t, tarball.Close())
+		}
+	}
+
+	_, _, err = info.DownloadForPlatform("darwin-arm64")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "not found in mirror")
+	}
+}
+
+func TestSelectPluginsToPrune(t *testing.T) {
+	t.Parallel()
+
+	plugin := func(kind PluginKind, name, version string) PluginInfo {
+		info := PluginInfo{Kind: kind, Name: name}
+		if version != "" {
+			v := semver.MustParse(version)
+			info.Version = &v
+		}
+		return info
+	}
+	plugins := []PluginInfo{
+		plugin(ResourcePlugin, "aws", "4.0.0"),
+		plugin(ResourcePlugin, "aws", "5.1.0"),
+		plugin(ResourcePlugin, "aws", "5.0.0"),
+		plugin(ResourcePlugin, "aws", "4.38.1"),
+		plugin(ResourcePlugin, "aws", ""),
+		plugin(AnalyzerPlugin, "aws", "1.0.0"),
+		plugin(ResourcePlugin, "random", "4.8.0"),
+		plugin(ResourcePlugin, "random", "4.7.0"),
+	}
+	names := func(plugins []PluginInfo) []string {
+		var result []string
+		for _, p := range plugins {
+			result = append(result, string(p.Kind)+" "+p.String())
+		}
+		return result
+	}
+
+	// The newest versions of each plugin are kept, as are plugins without versions.
+	assert.Equal(t, []string{
+		"resource aws-5.0.0",
+		"resource aws-4.38.1",
+		"resource aws-4.0.0",
+		"resource random-4.7.0",
+	}, names(SelectPluginsToPrune(plugins, 1, nil)))
+	assert.Equal(t, []string{
+		"resource aws-4.38.1",
+		"resource aws-4.0.0",
+	}, names(SelectPluginsToPrune(plugins, 2, nil)))
+
+	// Referenced versions are kept, and a referenced plugin without a version keeps the newest version.
+	assert.Equal(t, []string{
+		"analyzer aws-1.0.0",
+		"resource aws-5.1.0",
+		"resource aws-5.0.0",
+		"resource aws-4.0.0",
+		"resource random-4.7.0",
+	}, names(SelectPluginsToPrune(plugins, 0, []PluginInfo{
+		plugin(ResourcePlugin, "aws", "4.38.1"),
+		plugin(ResourcePlugin, "random", ""),
+	})))
+}
+
+func TestPrunePlugin(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	version := semver.MustParse("1.0.0")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "test", Version: &version, PluginDir: dir}
+	pluginDir := filepath.Join(dir, info.Dir())
+	assert.NoError(t, os.MkdirAll(pluginDir, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(pluginDir, info.File()), nil, 0600))
+	assert.NoError(t, ioutil.WriteFile(pluginDir+".partial", nil, 0600))
+
+	assert.NoError(t, info.Prune())
+	_, err := os.Stat(pluginDir)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(pluginDir + ".partial")
+	assert.True(t, os.IsNotExist(err))
+
+	// The install lock is left in place for any concurrent installs that are waiting on it.
+	_, err = os.Stat(pluginDir + ".lock")
+	assert.NoError(t, err)
+
+	// Pruning a plugin that is not installed is not an error.
+	assert.NoError(t, info.Prune())
+}
@@ -61,6 +61,48 @@ type ProjectBackend struct {
 type ProjectOptions struct {
 	// Refresh is the ability to always run a refresh as part of a pulumi update / preview / destroy
 	Refresh string `json:"refresh,omitempty" yaml:"refresh,omitempty"`
+	// Retry configures how the engine retries provider operations that fail with transient errors
+	Retry *ProjectRetryOptions `json:"retry,omitempty" yaml:"retry,omitempty"`
+	// Concurrency limits the number of operations the engine runs at once against particular providers and types
+	Concurrency *ProjectConcurrencyOptions `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	// Guard lists resources that updates must never delete or replace
+	Guard *ProjectGuardOptions `json:"guard,omitempty" yaml:"guard,omitempty"`
+}
+
+// ProjectGuardOptions lists resources that the engine must refuse to delete or replace. Each entry is a URN pattern, if
+// it begins with "urn:", or a resource type pattern otherwise; "*" matches any sequence of characters.
+type ProjectGuardOptions struct {
+	// RefuseReplace lists patterns for resources that must not be replaced.
+	RefuseReplace []string `json:"refuseReplace,omitempty" yaml:"refuseReplace,omitempty"`
+	// RefuseDelete lists patterns for resources that must not be deleted, whether on their own or by replacement.
+	RefuseDelete []string `json:"refuseDelete,omitempty" yaml:"refuseDelete,omitempty"`
+}
+
+// ProjectConcurrencyOptions limits the number of operations that the engine runs at once against particular providers
+// and resource types, in addition to the global limit set by --parallel.
+type ProjectConcurrencyOptions struct {
+	// Providers maps package names to the maximum number of concurrent operations against that package's providers.
+	Providers map[string]int `json:"providers,omitempty" yaml:"providers,omitempty"`
+	// Types maps resource type tokens to the maximum number of concurrent operations on resources of that type.
+	Types map[string]int `json:"types,omitempty" yaml:"types,omitempty"`
+}
+
+// ProjectRetryPolicy configures how the engine retries provider operations that fail with transient errors.
+type ProjectRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for each operation, including the first.
+	MaxAttempts int `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"`
+	// Backoff is the delay before the first retry, e.g. "5s". The delay doubles after each further attempt.
+	Backoff string `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	// Errors is a list of regular expressions that match retryable errors. If empty, all errors are retryable.
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// ProjectRetryOptions holds the project's default retry policy along with any per-type overrides.
+type ProjectRetryOptions struct {
+	ProjectRetryPolicy `yaml:",inline"`
+
+	// Types maps resource type tokens to retry policies that override the project's default policy.
+	Types map[string]ProjectRetryPolicy `json:"types,omitempty" yaml:"types,omitempty"`
 }
 
 // Project is a Pulumi project manifest.
@@ -187,6 +229,26 @@ type ProjectStack struct {
 	EncryptionSalt string `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"`
 	// Config is an optional config bag.
 	Config config.Map `json:"config,omitempty" yaml:"config,omitempty"`
+	// PolicyWaivers waive policy violations of particular resources in this stack until they expire.
+	PolicyWaivers []ProjectStackPolicyWaiver `json:"policyWaivers,omitempty" yaml:"policyWaivers,omitempty"`
+}
+
+// ProjectStackPolicyWaiver waives a policy for the stack's resources whose URNs match a pattern. Violations of the
+// policy by those resources are reported as waived advisory violations rather than failing the deployment, until the
+// waiver expires.
+type ProjectStackPolicyWaiver struct {
+	// PolicyPack is the name of the policy pack that contains the policy.
+	PolicyPack string `json:"policyPack" yaml:"policyPack"`
+	// Policy is the name of the waived policy.
+	Policy string `json:"policy" yaml:"policy"`
+	// URN is a pattern matching the resources for which the policy is waived. The pattern matches resource URNs if it
+	// begins with "urn:" and resource types otherwise; a "*" matches any sequence of characters.
+	URN string `json:"urn" yaml:"urn"`
+	// Reason is the justification for the waiver.
+	Reason string `json:"reason" yaml:"reason"`
+	// Expires is the last date on which the waiver applies, e.g. "2022-12-31", or the RFC 3339 time from which it no
+	// longer applies. Dates are in UTC.
+	Expires string `json:"expires" yaml:"expires"`
 }
 
 // Save writes a project definition to a file.
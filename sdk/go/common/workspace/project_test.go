@@ -36,3 +36,40 @@ func TestProjectRuntimeInfoRoundtripYAML(t *testing.T) {
 	doTest(yaml.Marshal, yaml.Unmarshal)
 	doTest(json.Marshal, json.Unmarshal)
 }
+
+func TestProjectRetryOptionsYAML(t *testing.T) {
+	const text = `name: test
+runtime: nodejs
+options:
+  retry:
+    maxAttempts: 3
+    backoff: 2s
+    errors:
+      - Throttling
+    types:
+      aws:s3/bucket:Bucket:
+        maxAttempts: 5
+`
+
+	var proj Project
+	err := yaml.Unmarshal([]byte(text), &proj)
+	assert.NoError(t, err)
+	assert.NotNil(t, proj.Options)
+	assert.NotNil(t, proj.Options.Retry)
+
+	retry := proj.Options.Retry
+	assert.Equal(t, 3, retry.MaxAttempts)
+	assert.Equal(t, "2s", retry.Backoff)
+	assert.Equal(t, []string{"Throttling"}, retry.Errors)
+	assert.Equal(t, ProjectRetryPolicy{MaxAttempts: 5}, retry.Types["aws:s3/bucket:Bucket"])
+
+	// The default policy must be inlined when the options are marshaled as JSON as well.
+	byts, err := json.Marshal(retry)
+	assert.NoError(t, err)
+
+	var roundtrip ProjectRetryOptions
+	err = json.Unmarshal(byts, &roundtrip)
+	assert.NoError(t, err)
+	assert.Equal(t, *retry, roundtrip)
+	assert.Contains(t, string(byts), `"maxAttempts":3`)
+}
@@ -0,0 +1,26 @@
+@echo off
+
+REM Save the first two arguments.
+set "pulumi_policy_go_engine_address=%1"
+set "pulumi_policy_go_program=%2"
+
+REM Parse the -binary command line argument.
+set pulumi_policy_go_binary=
+:parse
+if "%~1"=="" goto endparse
+if "%~1"=="-binary" (
+    REM Get the value as a fully-qualified path.
+    set "pulumi_policy_go_binary=%~f2"
+    goto endparse
+)
+shift /1
+goto parse
+:endparse
+
+if defined pulumi_policy_go_binary (
+    REM Run the prebuilt policy pack binary.
+    "%pulumi_policy_go_binary%" %pulumi_policy_go_engine_address% %pulumi_policy_go_program%
+) else (
+    REM Otherwise, build and run the policy pack in the current directory.
+    go run . %pulumi_policy_go_engine_address% %pulumi_policy_go_program%
+)
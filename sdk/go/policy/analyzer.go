@@ -0,0 +1,330 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/blang/semver"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/deepcopy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+type analyzer struct {
+	pack PolicyPack
+
+	m      sync.RWMutex
+	config map[string]plugin.AnalyzerPolicyConfig // the configuration of each policy, by name.
+}
+
+// NewAnalyzer returns an analyzer that runs the policies in pack. The engine talks to this analyzer when the pack is
+// run by Main; tests can call it directly to check a pack's policies against resources.
+func NewAnalyzer(pack PolicyPack) (plugin.Analyzer, error) {
+	if pack.Name == "" {
+		return nil, errors.New("policy pack is missing a name")
+	}
+	if pack.EnforcementLevel != "" && !pack.EnforcementLevel.IsValid() {
+		return nil, fmt.Errorf("policy pack %q has an invalid enforcement level %q", pack.Name, pack.EnforcementLevel)
+	}
+
+	// Policies may be given by value or by pointer; the analyzer works with values.
+	policies := make([]Policy, len(pack.Policies))
+	for i, p := range pack.Policies {
+		switch p := p.(type) {
+		case *ResourceValidationPolicy:
+			policies[i] = *p
+		case *ResourceRemediationPolicy:
+			policies[i] = *p
+		case *StackValidationPolicy:
+			policies[i] = *p
+		default:
+			policies[i] = p
+		}
+	}
+	pack.Policies = policies
+
+	names := make(map[string]bool)
+	for _, p := range pack.Policies {
+		info := p.info()
+		switch {
+		case info.Name == "":
+			return nil, fmt.Errorf("policy pack %q has a policy that is missing a name", pack.Name)
+		case names[info.Name]:
+			return nil, fmt.Errorf("policy pack %q has more than one policy named %q", pack.Name, info.Name)
+		case info.EnforcementLevel != "" && !info.EnforcementLevel.IsValid():
+			return nil, fmt.Errorf("policy %q has an invalid enforcement level %q", info.Name, info.EnforcementLevel)
+		}
+		names[info.Name] = true
+
+		if _, ok := p.(ResourceRemediationPolicy); !ok && info.EnforcementLevel == Remediate {
+			return nil, fmt.Errorf("policy %q cannot use the remediate enforcement level as it is not a "+
+				"resource remediation policy", info.Name)
+		}
+
+		switch p := p.(type) {
+		case ResourceValidationPolicy:
+			if p.ValidateResource == nil {
+				return nil, fmt.Errorf("resource validation policy %q is missing ValidateResource", p.Name)
+			}
+		case ResourceRemediationPolicy:
+			if p.RemediateResource == nil {
+				return nil, fmt.Errorf("resource remediation policy %q is missing RemediateResource", p.Name)
+			}
+		case StackValidationPolicy:
+			if p.ValidateStack == nil {
+				return nil, fmt.Errorf("stack validation policy %q is missing ValidateStack", p.Name)
+			}
+		}
+	}
+
+	return &analyzer{pack: pack}, nil
+}
+
+func (a *analyzer) Close() error {
+	return nil
+}
+
+func (a *analyzer) Name() tokens.QName {
+	return tokens.QName(a.pack.Name)
+}
+
+// enforcementLevel returns the enforcement level of a policy, taking its configuration into account. Policies other
+// than remediation policies cannot remediate, so they are advisory when the pack's enforcement level is Remediate.
+func (a *analyzer) enforcementLevel(p Policy) EnforcementLevel {
+	a.m.RLock()
+	defer a.m.RUnlock()
+
+	info := p.info()
+	_, remediation := p.(ResourceRemediationPolicy)
+
+	level := Advisory
+	if remediation {
+		level = Remediate
+	}
+	if config, ok := a.config[info.Name]; ok && config.EnforcementLevel != "" {
+		level = config.EnforcementLevel
+	} else if info.EnforcementLevel != "" {
+		level = info.EnforcementLevel
+	} else if a.pack.EnforcementLevel != "" {
+		level = a.pack.EnforcementLevel
+	}
+	if level == Remediate && !remediation {
+		return Advisory
+	}
+	return level
+}
+
+// policyConfig returns the configuration of a policy, with the defaults from its schema applied.
+func (a *analyzer) policyConfig(info plugin.AnalyzerPolicyInfo) map[string]interface{} {
+	a.m.RLock()
+	defer a.m.RUnlock()
+
+	config := make(map[string]interface{})
+	if info.ConfigSchema != nil {
+		for k, schema := range info.ConfigSchema.Properties {
+			if v, ok := schema["default"]; ok {
+				config[k] = v
+			}
+		}
+	}
+	for k, v := range a.config[info.Name].Properties {
+		config[k] = v
+	}
+	return config
+}
+
+func (a *analyzer) diagnostic(info plugin.AnalyzerPolicyInfo, level EnforcementLevel, message string,
+	urn resource.URN) plugin.AnalyzeDiagnostic {
+
+	if message == "" {
+		message = info.Description
+	}
+	return plugin.AnalyzeDiagnostic{
+		PolicyName:        info.Name,
+		PolicyPackName:    a.pack.Name,
+		PolicyPackVersion: a.pack.Version,
+		Description:       info.Description,
+		Message:           message,
+		EnforcementLevel:  level,
+		URN:               urn,
+	}
+}
+
+func (a *analyzer) Analyze(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+	var diagnostics []plugin.AnalyzeDiagnostic
+	for _, p := range a.pack.Policies {
+		info := p.info()
+		level := a.enforcementLevel(p)
+		if level == Disabled || level == Remediate {
+			continue
+		}
+
+		switch p := p.(type) {
+		case ResourceValidationPolicy:
+			args := ResourceValidationArgs{AnalyzerResource: r, Config: a.policyConfig(info)}
+			err := p.ValidateResource(args, func(message string) {
+				diagnostics = append(diagnostics, a.diagnostic(info, level, message, r.URN))
+			})
+			if err != nil {
+				return nil, fmt.Errorf("policy %q failed to validate %s: %w", p.Name, r.URN, err)
+			}
+		case ResourceRemediationPolicy:
+			// A remediation policy that is not allowed to remediate reports the resources it would change.
+			props, err := a.remediate(p, r)
+			if err != nil {
+				return nil, err
+			}
+			if props != nil {
+				diagnostics = append(diagnostics, a.diagnostic(info, level, "", r.URN))
+			}
+		}
+	}
+	return diagnostics, nil
+}
+
+// remediate runs a remediation policy against a resource, returning its remediated properties, or nil if the policy
+// did not change them.
+func (a *analyzer) remediate(p ResourceRemediationPolicy, r plugin.AnalyzerResource) (resource.PropertyMap, error) {
+	args := ResourceValidationArgs{AnalyzerResource: r, Config: a.policyConfig(p.info())}
+	args.Properties = deepcopy.Copy(r.Properties).(resource.PropertyMap)
+	props, err := p.RemediateResource(args)
+	if err != nil {
+		return nil, fmt.Errorf("policy %q failed to remediate %s: %w", p.Name, r.URN, err)
+	}
+	if props == nil || props.DeepEquals(r.Properties) {
+		return nil, nil
+	}
+	return props, nil
+}
+
+func (a *analyzer) Remediate(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+	var remediations []plugin.Remediation
+	for _, p := range a.pack.Policies {
+		p, ok := p.(ResourceRemediationPolicy)
+		if !ok || a.enforcementLevel(p) != Remediate {
+			continue
+		}
+
+		// Each remediation policy sees the changes made by the ones before it.
+		props, err := a.remediate(p, r)
+		if err != nil {
+			return nil, err
+		}
+		if props == nil {
+			continue
+		}
+		r.Properties = props
+		remediations = append(remediations, plugin.Remediation{
+			PolicyName:        p.Name,
+			PolicyPackName:    a.pack.Name,
+			PolicyPackVersion: a.pack.Version,
+			Description:       p.Description,
+			Properties:        props,
+		})
+	}
+	return remediations, nil
+}
+
+func (a *analyzer) AnalyzeStack(resources []plugin.AnalyzerStackResource) ([]plugin.AnalyzeDiagnostic, error) {
+	var diagnostics []plugin.AnalyzeDiagnostic
+	for _, p := range a.pack.Policies {
+		p, ok := p.(StackValidationPolicy)
+		if !ok {
+			continue
+		}
+		info := p.info()
+		level := a.enforcementLevel(p)
+		if level == Disabled {
+			continue
+		}
+
+		args := StackValidationArgs{Resources: resources, Config: a.policyConfig(info)}
+		err := p.ValidateStack(args, func(message string, urn resource.URN) {
+			diagnostics = append(diagnostics, a.diagnostic(info, level, message, urn))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("policy %q failed to validate the stack: %w", p.Name, err)
+		}
+	}
+	return diagnostics, nil
+}
+
+func (a *analyzer) GetAnalyzerInfo() (plugin.AnalyzerInfo, error) {
+	policies := make([]plugin.AnalyzerPolicyInfo, len(a.pack.Policies))
+	for i, p := range a.pack.Policies {
+		info := p.info()
+		if info.EnforcementLevel == "" {
+			info.EnforcementLevel = a.enforcementLevel(p)
+		}
+		policies[i] = info
+	}
+
+	return plugin.AnalyzerInfo{
+		Name:           a.pack.Name,
+		Version:        a.pack.Version,
+		SupportsConfig: true,
+		Policies:       policies,
+	}, nil
+}
+
+func (a *analyzer) GetPluginInfo() (workspace.PluginInfo, error) {
+	var version *semver.Version
+	if a.pack.Version != "" {
+		v, err := semver.ParseTolerant(a.pack.Version)
+		if err != nil {
+			return workspace.PluginInfo{}, fmt.Errorf("invalid version %q: %w", a.pack.Version, err)
+		}
+		version = &v
+	}
+
+	return workspace.PluginInfo{
+		Name:    a.pack.Name,
+		Kind:    workspace.AnalyzerPlugin,
+		Version: version,
+	}, nil
+}
+
+func (a *analyzer) Configure(policyConfig map[string]plugin.AnalyzerPolicyConfig) error {
+	for name, config := range policyConfig {
+		if config.EnforcementLevel != "" && !config.EnforcementLevel.IsValid() {
+			return fmt.Errorf("policy %q has an invalid enforcement level %q", name, config.EnforcementLevel)
+		}
+		if config.EnforcementLevel == Remediate && !a.canRemediate(name) {
+			return fmt.Errorf("policy %q cannot use the remediate enforcement level as it is not a "+
+				"resource remediation policy", name)
+		}
+	}
+
+	a.m.Lock()
+	defer a.m.Unlock()
+	a.config = policyConfig
+	return nil
+}
+
+// canRemediate returns true if the named policy is a remediation policy.
+func (a *analyzer) canRemediate(name string) bool {
+	for _, p := range a.pack.Policies {
+		if p, ok := p.(ResourceRemediationPolicy); ok && p.Name == name {
+			return true
+		}
+	}
+	return false
+}
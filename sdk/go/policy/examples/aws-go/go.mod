@@ -0,0 +1,6 @@
+module aws-go
+
+go 1.17
+
+// The policy package is first released in v3.22.0 of the SDK.
+require github.com/pulumi/pulumi/sdk/v3 v3.22.0
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/policy"
+)
+
+// Bucket holds the properties of an S3 bucket that the policies check.
+type Bucket struct {
+	ACL string `json:"acl"`
+}
+
+// policies is the Policy Pack. It is a plain value so that main_test.go can test it.
+var policies = policy.PolicyPack{
+	Name: "aws-go",
+	Policies: []policy.Policy{
+		policy.ResourceValidationPolicy{
+			Name:             "s3-no-public-read",
+			Description:      "Prohibits setting the publicRead or publicReadWrite permission on AWS S3 buckets.",
+			EnforcementLevel: policy.Mandatory,
+			ValidateResource: policy.ValidateResourceOfType("aws:s3/bucket:Bucket",
+				func(bucket *Bucket, args policy.ResourceValidationArgs, reportViolation policy.ReportViolation) error {
+					if bucket.ACL == "public-read" || bucket.ACL == "public-read-write" {
+						reportViolation("You cannot set public-read or public-read-write on an S3 bucket. Read more " +
+							"about ACLs here: https://docs.aws.amazon.com/AmazonS3/latest/dev/acl-overview.html")
+					}
+					return nil
+				}),
+		},
+	},
+}
+
+func main() {
+	if err := policy.Main(policies); err != nil {
+		cmdutil.ExitError(err.Error())
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/policy"
+)
+
+func TestS3NoPublicRead(t *testing.T) {
+	analyzer, err := policy.NewAnalyzer(policies)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for acl, violations := range map[string]int{"private": 0, "public-read": 1, "public-read-write": 1} {
+		diagnostics, err := analyzer.Analyze(plugin.AnalyzerResource{
+			URN:        "urn:pulumi:dev::project::aws:s3/bucket:Bucket::my-bucket",
+			Type:       "aws:s3/bucket:Bucket",
+			Name:       "my-bucket",
+			Properties: resource.PropertyMap{"acl": resource.NewStringProperty(acl)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(diagnostics) != violations {
+			t.Errorf("expected %d violations for acl %q, got %d", violations, acl, len(diagnostics))
+		}
+	}
+}
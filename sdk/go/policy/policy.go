@@ -0,0 +1,290 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy is the Go SDK for writing Pulumi policy packs.
+//
+// A policy pack is a Go program whose main function calls Main with a PolicyPack. The PolicyPack's resource
+// validation policies are called for each resource as it is registered, and its stack validation policies are called
+// with all of the stack's resources once the preview or update has finished:
+//
+//	func main() {
+//		err := policy.Main(policy.PolicyPack{
+//			Name: "aws-policies",
+//			Policies: []policy.Policy{
+//				policy.ResourceValidationPolicy{
+//					Name:             "s3-no-public-read",
+//					Description:      "Prohibits setting the publicRead or publicReadWrite ACL on S3 buckets.",
+//					EnforcementLevel: policy.Mandatory,
+//					ValidateResource: policy.ValidateResourceOfType("aws:s3/bucket:Bucket",
+//						func(b *Bucket, args policy.ResourceValidationArgs, report policy.ReportViolation) error {
+//							if b.ACL == "public-read" || b.ACL == "public-read-write" {
+//								report("You cannot set public-read or public-read-write on an S3 bucket.")
+//							}
+//							return nil
+//						}),
+//				},
+//			},
+//		})
+//		if err != nil {
+//			cmdutil.ExitError(err.Error())
+//		}
+//	}
+//
+// The policy pack's PulumiPolicy.yaml uses the go runtime. Because a PolicyPack is plain data, its policies can be
+// unit tested with `go test` by calling them through NewAnalyzer.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// EnforcementLevel indicates how a policy violation is handled.
+type EnforcementLevel = apitype.EnforcementLevel
+
+const (
+	// Advisory violations are reported as warnings and do not block the update.
+	Advisory = apitype.Advisory
+	// Mandatory violations are reported as errors and block the update.
+	Mandatory = apitype.Mandatory
+	// Disabled policies are not run.
+	Disabled = apitype.Disabled
+	// Remediate policies fix violations by changing the resource's inputs instead of reporting them. Only
+	// ResourceRemediationPolicy supports this enforcement level.
+	Remediate = apitype.Remediate
+)
+
+// ConfigSchema describes the configuration that a policy accepts. Each property is a JSON schema; a property's
+// "default" is used when the property is not configured.
+type ConfigSchema = plugin.AnalyzerPolicyConfigSchema
+
+// JSONSchema is the JSON schema of a single configuration property.
+type JSONSchema = plugin.JSONSchema
+
+// PolicyPack is a named, versioned set of policies.
+type PolicyPack struct {
+	// Name is the name of the policy pack. It must be unique within an organization.
+	Name string
+	// Version is the version of the policy pack. The version in PulumiPolicy.yaml, if any, takes precedence.
+	Version string
+	// EnforcementLevel is the enforcement level of policies that do not set their own. Defaults to Advisory, or
+	// Remediate for remediation policies. Only remediation policies use the Remediate level; others are advisory.
+	EnforcementLevel EnforcementLevel
+	// Policies are the policies in the pack.
+	Policies []Policy
+}
+
+// Policy is a single policy in a policy pack: a ResourceValidationPolicy, a ResourceRemediationPolicy or a
+// StackValidationPolicy.
+type Policy interface {
+	// info returns the policy's metadata.
+	info() plugin.AnalyzerPolicyInfo
+}
+
+// ResourceValidationPolicy is a policy that validates each resource as it is registered, before it is created or
+// updated.
+type ResourceValidationPolicy struct {
+	// Name is the policy's name, which must be unique within the pack.
+	Name string
+	// Description describes the purpose of the policy. It is used as the message of violations reported without one.
+	Description string
+	// EnforcementLevel is the policy's enforcement level. Defaults to the pack's enforcement level.
+	EnforcementLevel EnforcementLevel
+	// ConfigSchema describes the configuration the policy accepts, if any.
+	ConfigSchema *ConfigSchema
+	// ValidateResource validates a single resource.
+	ValidateResource ResourceValidation
+}
+
+func (p ResourceValidationPolicy) info() plugin.AnalyzerPolicyInfo {
+	return plugin.AnalyzerPolicyInfo{
+		Name:             p.Name,
+		Description:      p.Description,
+		EnforcementLevel: p.EnforcementLevel,
+		ConfigSchema:     p.ConfigSchema,
+	}
+}
+
+// ResourceRemediationPolicy is a policy that fixes a resource's inputs before they are checked by the resource's
+// provider, e.g. by adding mandatory tags or enabling encryption. With the Remediate enforcement level, which is its
+// default, the engine applies the changed inputs and shows them in the preview. With the Advisory or Mandatory
+// enforcement levels, a resource that would be changed is reported as a violation instead.
+type ResourceRemediationPolicy struct {
+	// Name is the policy's name, which must be unique within the pack.
+	Name string
+	// Description describes the purpose of the policy. It is used as the message of the violations it reports.
+	Description string
+	// EnforcementLevel is the policy's enforcement level. Defaults to the pack's enforcement level, or Remediate.
+	EnforcementLevel EnforcementLevel
+	// ConfigSchema describes the configuration the policy accepts, if any.
+	ConfigSchema *ConfigSchema
+	// RemediateResource remediates a single resource.
+	RemediateResource ResourceRemediation
+}
+
+func (p ResourceRemediationPolicy) info() plugin.AnalyzerPolicyInfo {
+	return plugin.AnalyzerPolicyInfo{
+		Name:             p.Name,
+		Description:      p.Description,
+		EnforcementLevel: p.EnforcementLevel,
+		ConfigSchema:     p.ConfigSchema,
+	}
+}
+
+// StackValidationPolicy is a policy that validates all of a stack's resources once a preview or update has
+// finished.
+type StackValidationPolicy struct {
+	// Name is the policy's name, which must be unique within the pack.
+	Name string
+	// Description describes the purpose of the policy. It is used as the message of violations reported without one.
+	Description string
+	// EnforcementLevel is the policy's enforcement level. Defaults to the pack's enforcement level.
+	EnforcementLevel EnforcementLevel
+	// ConfigSchema describes the configuration the policy accepts, if any.
+	ConfigSchema *ConfigSchema
+	// ValidateStack validates the stack's resources.
+	ValidateStack StackValidation
+}
+
+func (p StackValidationPolicy) info() plugin.AnalyzerPolicyInfo {
+	return plugin.AnalyzerPolicyInfo{
+		Name:             p.Name,
+		Description:      p.Description,
+		EnforcementLevel: p.EnforcementLevel,
+		ConfigSchema:     p.ConfigSchema,
+	}
+}
+
+// ResourceValidationArgs is the resource passed to a ResourceValidation.
+type ResourceValidationArgs struct {
+	plugin.AnalyzerResource
+
+	// Config is the policy's configuration, with defaults from its schema applied.
+	Config map[string]interface{}
+}
+
+// ReportViolation reports that the resource being validated violates a policy. If message is empty, the policy's
+// description is used.
+type ReportViolation func(message string)
+
+// ResourceValidation validates a single resource, reporting any violations. A returned error fails the preview or
+// update.
+type ResourceValidation func(args ResourceValidationArgs, reportViolation ReportViolation) error
+
+// ResourceRemediation returns the remediated inputs of a single resource, or nil to leave them as they are. The
+// properties in args are a copy that may be modified and returned. A returned error fails the preview or update.
+type ResourceRemediation func(args ResourceValidationArgs) (resource.PropertyMap, error)
+
+// StackValidationArgs are the resources passed to a StackValidation.
+type StackValidationArgs struct {
+	// Resources are all of the stack's resources.
+	Resources []plugin.AnalyzerStackResource
+
+	// Config is the policy's configuration, with defaults from its schema applied.
+	Config map[string]interface{}
+}
+
+// ResourcesOfType returns the stack's resources of the given type.
+func (args StackValidationArgs) ResourcesOfType(typ tokens.Type) []plugin.AnalyzerStackResource {
+	var resources []plugin.AnalyzerStackResource
+	for _, r := range args.Resources {
+		if r.Type == typ {
+			resources = append(resources, r)
+		}
+	}
+	return resources
+}
+
+// ReportStackViolation reports that the stack violates a policy. The urn of the offending resource may be empty. If
+// message is empty, the policy's description is used.
+type ReportStackViolation func(message string, urn resource.URN)
+
+// StackValidation validates a stack's resources, reporting any violations. A returned error fails the preview or
+// update.
+type StackValidation func(args StackValidationArgs, reportViolation ReportStackViolation) error
+
+var (
+	resourceValidationArgsType = reflect.TypeOf(ResourceValidationArgs{})
+	reportViolationType        = reflect.TypeOf(ReportViolation(nil))
+	errorType                  = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// ValidateResourceOfType returns a ResourceValidation that only validates resources of the given type. validate
+// must be a function of the form
+//
+//	func(props *T, args ResourceValidationArgs, reportViolation ReportViolation) error
+//
+// where T is a struct into which the resource's properties are decoded with DecodeProperties.
+func ValidateResourceOfType(typ tokens.Type, validate interface{}) ResourceValidation {
+	fn := reflect.ValueOf(validate)
+	ft := fn.Type()
+	contract.Assertf(ft.Kind() == reflect.Func && ft.NumIn() == 3 && ft.NumOut() == 1 &&
+		ft.In(0).Kind() == reflect.Ptr && ft.In(1) == resourceValidationArgsType && ft.In(2) == reportViolationType &&
+		ft.Out(0) == errorType,
+		"validate must be a func(*T, policy.ResourceValidationArgs, policy.ReportViolation) error, not %v", ft)
+
+	return func(args ResourceValidationArgs, reportViolation ReportViolation) error {
+		if args.Type != typ {
+			return nil
+		}
+
+		props := reflect.New(ft.In(0).Elem())
+		if err := DecodeProperties(args.Properties, props.Interface()); err != nil {
+			return fmt.Errorf("decoding properties of %s: %w", args.URN, err)
+		}
+		result := fn.Call([]reflect.Value{props, reflect.ValueOf(args), reflect.ValueOf(reportViolation)})
+		err, _ := result[0].Interface().(error)
+		return err
+	}
+}
+
+// DecodeProperties decodes a resource's properties into v, which is typically a pointer to a struct whose fields
+// have `json` tags naming the properties. Secrets are decoded as their plaintext values, resource references as their
+// URNs, and values that are not known yet (during a preview) are decoded as zero values; check the property itself
+// with ContainsUnknowns where the difference matters.
+func DecodeProperties(props resource.PropertyMap, v interface{}) error {
+	bytes, err := json.Marshal(plainPropertyMap(props))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, v)
+}
+
+func plainPropertyMap(props resource.PropertyMap) map[string]interface{} {
+	return props.MapRepl(nil, plainPropertyValue)
+}
+
+func plainPropertyValue(v resource.PropertyValue) (interface{}, bool) {
+	switch {
+	case v.IsSecret():
+		return v.SecretValue().Element.MapRepl(nil, plainPropertyValue), true
+	case v.IsComputed():
+		return nil, true
+	case v.IsOutput():
+		if !v.OutputValue().Known {
+			return nil, true
+		}
+		return v.OutputValue().Element.MapRepl(nil, plainPropertyValue), true
+	case v.IsResourceReference():
+		return string(v.ResourceReferenceValue().URN), true
+	}
+	return nil, false
+}
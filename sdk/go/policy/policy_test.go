@@ -0,0 +1,418 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+type bucket struct {
+	ACL  string            `json:"acl"`
+	Size int               `json:"size"`
+	Tags map[string]string `json:"tags"`
+}
+
+func testPack() PolicyPack {
+	return PolicyPack{
+		Name:    "test-pack",
+		Version: "1.0.0",
+		Policies: []Policy{
+			ResourceValidationPolicy{
+				Name:             "no-public-buckets",
+				Description:      "Buckets must not be public.",
+				EnforcementLevel: Mandatory,
+				ValidateResource: ValidateResourceOfType("test:index:Bucket",
+					func(b *bucket, args ResourceValidationArgs, report ReportViolation) error {
+						if b.ACL == "public-read" {
+							report("")
+						}
+						return nil
+					}),
+			},
+			&ResourceValidationPolicy{
+				Name:        "max-size",
+				Description: "Buckets must not be too large.",
+				ConfigSchema: &ConfigSchema{
+					Properties: map[string]JSONSchema{
+						"maxSize": {"type": "integer", "default": 10},
+					},
+				},
+				ValidateResource: ValidateResourceOfType("test:index:Bucket",
+					func(b *bucket, args ResourceValidationArgs, report ReportViolation) error {
+						var max int
+						if _, err := fmt.Sscan(fmt.Sprint(args.Config["maxSize"]), &max); err != nil {
+							return err
+						}
+						if b.Size > max {
+							report(fmt.Sprintf("size %d is larger than %d", b.Size, max))
+						}
+						if b.Size < 0 {
+							return errors.New("negative size")
+						}
+						return nil
+					}),
+			},
+			StackValidationPolicy{
+				Name:        "one-bucket",
+				Description: "Stacks must have at most one bucket.",
+				ValidateStack: func(args StackValidationArgs, report ReportStackViolation) error {
+					if buckets := args.ResourcesOfType("test:index:Bucket"); len(buckets) > 1 {
+						report(fmt.Sprintf("found %d buckets", len(buckets)), buckets[1].URN)
+					}
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func bucketResource(name string, props resource.PropertyMap) plugin.AnalyzerResource {
+	urn := resource.NewURN("dev", "proj", "", "test:index:Bucket", tokens.QName(name))
+	return plugin.AnalyzerResource{URN: urn, Type: urn.Type(), Name: urn.Name(), Properties: props}
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+
+	analyzer, err := NewAnalyzer(testPack())
+	require.NoError(t, err)
+
+	// Compliant resources have no violations. Secrets are decoded as their plaintext values.
+	diags, err := analyzer.Analyze(bucketResource("b", resource.PropertyMap{
+		"acl":  resource.MakeSecret(resource.NewStringProperty("private")),
+		"size": resource.NewNumberProperty(5),
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, diags)
+
+	// Violations are reported with the policy's enforcement level, and its description if there is no message.
+	diags, err = analyzer.Analyze(bucketResource("b", resource.PropertyMap{
+		"acl":  resource.NewStringProperty("public-read"),
+		"size": resource.NewNumberProperty(20),
+		"tags": resource.MakeComputed(resource.NewStringProperty("")),
+	}))
+	require.NoError(t, err)
+	urn := bucketResource("b", nil).URN
+	assert.Equal(t, []plugin.AnalyzeDiagnostic{
+		{
+			PolicyName:        "no-public-buckets",
+			PolicyPackName:    "test-pack",
+			PolicyPackVersion: "1.0.0",
+			Description:       "Buckets must not be public.",
+			Message:           "Buckets must not be public.",
+			EnforcementLevel:  Mandatory,
+			URN:               urn,
+		},
+		{
+			PolicyName:        "max-size",
+			PolicyPackName:    "test-pack",
+			PolicyPackVersion: "1.0.0",
+			Description:       "Buckets must not be too large.",
+			Message:           "size 20 is larger than 10",
+			EnforcementLevel:  Advisory,
+			URN:               urn,
+		},
+	}, diags)
+
+	// Resources of other types are not validated.
+	other := bucketResource("b", resource.PropertyMap{"acl": resource.NewStringProperty("public-read")})
+	other.Type = "test:index:Other"
+	diags, err = analyzer.Analyze(other)
+	require.NoError(t, err)
+	assert.Empty(t, diags)
+
+	// Errors fail the analysis.
+	_, err = analyzer.Analyze(bucketResource("b", resource.PropertyMap{"size": resource.NewNumberProperty(-1)}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `policy "max-size" failed to validate`)
+
+	// Configuration overrides enforcement levels and schema defaults.
+	require.NoError(t, analyzer.Configure(map[string]plugin.AnalyzerPolicyConfig{
+		"no-public-buckets": {EnforcementLevel: Disabled},
+		"max-size":          {EnforcementLevel: Mandatory, Properties: map[string]interface{}{"maxSize": 30}},
+	}))
+	diags, err = analyzer.Analyze(bucketResource("b", resource.PropertyMap{
+		"acl":  resource.NewStringProperty("public-read"),
+		"size": resource.NewNumberProperty(40),
+	}))
+	require.NoError(t, err)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "max-size", diags[0].PolicyName)
+	assert.Equal(t, Mandatory, diags[0].EnforcementLevel)
+}
+
+func TestAnalyzeStack(t *testing.T) {
+	t.Parallel()
+
+	analyzer, err := NewAnalyzer(testPack())
+	require.NoError(t, err)
+
+	first := bucketResource("a", nil)
+	second := bucketResource("b", nil)
+	diags, err := analyzer.AnalyzeStack([]plugin.AnalyzerStackResource{{AnalyzerResource: first}})
+	require.NoError(t, err)
+	assert.Empty(t, diags)
+
+	diags, err = analyzer.AnalyzeStack([]plugin.AnalyzerStackResource{
+		{AnalyzerResource: first},
+		{AnalyzerResource: second},
+	})
+	require.NoError(t, err)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "one-bucket", diags[0].PolicyName)
+	assert.Equal(t, "found 2 buckets", diags[0].Message)
+	assert.Equal(t, second.URN, diags[0].URN)
+}
+
+func remediationPack() PolicyPack {
+	return PolicyPack{
+		Name:    "remediation-pack",
+		Version: "1.0.0",
+		Policies: []Policy{
+			ResourceRemediationPolicy{
+				Name:        "private-buckets",
+				Description: "Buckets are made private.",
+				RemediateResource: func(args ResourceValidationArgs) (resource.PropertyMap, error) {
+					args.Properties["acl"] = resource.NewStringProperty("private")
+					return args.Properties, nil
+				},
+			},
+			&ResourceRemediationPolicy{
+				Name:        "owner-tag",
+				Description: "Buckets are tagged with their owner.",
+				RemediateResource: func(args ResourceValidationArgs) (resource.PropertyMap, error) {
+					if args.Properties.HasValue("tags") {
+						return nil, nil
+					}
+					args.Properties["tags"] = resource.NewObjectProperty(resource.PropertyMap{
+						"owner": resource.NewStringProperty("acl-" + args.Properties["acl"].StringValue()),
+					})
+					return args.Properties, nil
+				},
+			},
+			ResourceValidationPolicy{
+				Name:             "no-public-buckets",
+				EnforcementLevel: Mandatory,
+				ValidateResource: func(args ResourceValidationArgs, report ReportViolation) error {
+					if args.Properties["acl"].StringValue() != "private" {
+						report("bucket is public")
+					}
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func TestRemediate(t *testing.T) {
+	t.Parallel()
+
+	analyzer, err := NewAnalyzer(remediationPack())
+	require.NoError(t, err)
+
+	// Remediations are applied in order, each seeing the changes of the ones before it. The resource's own properties
+	// are left as they are.
+	r := bucketResource("b", resource.PropertyMap{"acl": resource.NewStringProperty("public-read")})
+	remediations, err := analyzer.Remediate(r)
+	require.NoError(t, err)
+	require.Len(t, remediations, 2)
+	assert.Equal(t, "private-buckets", remediations[0].PolicyName)
+	assert.Equal(t, "remediation-pack", remediations[0].PolicyPackName)
+	assert.Equal(t, resource.PropertyMap{"acl": resource.NewStringProperty("private")}, remediations[0].Properties)
+	assert.Equal(t, "owner-tag", remediations[1].PolicyName)
+	assert.Equal(t, resource.PropertyMap{
+		"acl":  resource.NewStringProperty("private"),
+		"tags": resource.NewObjectProperty(resource.PropertyMap{"owner": resource.NewStringProperty("acl-private")}),
+	}, remediations[1].Properties)
+	assert.Equal(t, "public-read", r.Properties["acl"].StringValue())
+
+	// Policies that do not change the resource are not reported.
+	remediations, err = analyzer.Remediate(bucketResource("b", remediations[1].Properties))
+	require.NoError(t, err)
+	assert.Empty(t, remediations)
+
+	// Remediation policies do not report violations when they remediate.
+	diags, err := analyzer.Analyze(r)
+	require.NoError(t, err)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "no-public-buckets", diags[0].PolicyName)
+
+	// With another enforcement level, resources that would be changed are reported instead.
+	require.NoError(t, analyzer.Configure(map[string]plugin.AnalyzerPolicyConfig{
+		"private-buckets": {EnforcementLevel: Mandatory},
+	}))
+	remediations, err = analyzer.Remediate(r)
+	require.NoError(t, err)
+	require.Len(t, remediations, 1)
+	assert.Equal(t, "owner-tag", remediations[0].PolicyName)
+	diags, err = analyzer.Analyze(r)
+	require.NoError(t, err)
+	require.Len(t, diags, 2)
+	assert.Equal(t, "private-buckets", diags[0].PolicyName)
+	assert.Equal(t, "Buckets are made private.", diags[0].Message)
+	assert.Equal(t, Mandatory, diags[0].EnforcementLevel)
+
+	// Only remediation policies can remediate.
+	err = analyzer.Configure(map[string]plugin.AnalyzerPolicyConfig{
+		"no-public-buckets": {EnforcementLevel: Remediate},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a resource remediation policy")
+}
+
+func TestRemediateServer(t *testing.T) {
+	t.Parallel()
+
+	analyzer, err := NewAnalyzer(remediationPack())
+	require.NoError(t, err)
+	server := plugin.NewAnalyzerServer(analyzer)
+
+	info, err := server.GetAnalyzerInfo(context.Background(), &pbempty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, pulumirpc.EnforcementLevel_REMEDIATE, info.GetPolicies()[0].GetEnforcementLevel())
+	assert.Equal(t, pulumirpc.EnforcementLevel_MANDATORY, info.GetPolicies()[2].GetEnforcementLevel())
+
+	opts := plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true}
+	r := bucketResource("b", resource.PropertyMap{
+		"acl":  resource.NewStringProperty("public-read"),
+		"tags": resource.MakeSecret(resource.NewObjectProperty(resource.PropertyMap{})),
+	})
+	props, err := plugin.MarshalProperties(r.Properties, opts)
+	require.NoError(t, err)
+	resp, err := server.Remediate(context.Background(), &pulumirpc.AnalyzeRequest{
+		Urn:        string(r.URN),
+		Type:       string(r.Type),
+		Name:       string(r.Name),
+		Properties: props,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetRemediations(), 1)
+	rem := resp.GetRemediations()[0]
+	assert.Equal(t, "private-buckets", rem.GetPolicyName())
+
+	// Secrets survive the round trip.
+	remediated, err := plugin.UnmarshalProperties(rem.GetProperties(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "private", remediated["acl"].StringValue())
+	assert.True(t, remediated["tags"].IsSecret())
+}
+
+func TestNewAnalyzerErrors(t *testing.T) {
+	t.Parallel()
+
+	validate := func(ResourceValidationArgs, ReportViolation) error { return nil }
+	_, err := NewAnalyzer(PolicyPack{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing a name")
+	_, err = NewAnalyzer(PolicyPack{Name: "p", Policies: []Policy{
+		ResourceValidationPolicy{Name: "a", ValidateResource: validate},
+		ResourceValidationPolicy{Name: "a", ValidateResource: validate},
+	}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `more than one policy named "a"`)
+	_, err = NewAnalyzer(PolicyPack{Name: "p", Policies: []Policy{ResourceValidationPolicy{Name: "a"}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing ValidateResource")
+	_, err = NewAnalyzer(PolicyPack{Name: "p", Policies: []Policy{
+		ResourceValidationPolicy{Name: "a", EnforcementLevel: "warn", ValidateResource: validate},
+	}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid enforcement level "warn"`)
+	_, err = NewAnalyzer(PolicyPack{Name: "p", Policies: []Policy{
+		ResourceValidationPolicy{Name: "a", EnforcementLevel: Remediate, ValidateResource: validate},
+	}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a resource remediation policy")
+	_, err = NewAnalyzer(PolicyPack{Name: "p", Policies: []Policy{ResourceRemediationPolicy{Name: "a"}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing RemediateResource")
+
+	assert.Panics(t, func() {
+		ValidateResourceOfType("test:index:Bucket", func(b bucket) error { return nil })
+	})
+}
+
+func TestAnalyzerServer(t *testing.T) {
+	t.Parallel()
+
+	analyzer, err := NewAnalyzer(testPack())
+	require.NoError(t, err)
+	server := plugin.NewAnalyzerServer(analyzer)
+	ctx := context.Background()
+
+	info, err := server.GetAnalyzerInfo(ctx, &pbempty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "test-pack", info.GetName())
+	assert.True(t, info.GetSupportsConfig())
+	require.Len(t, info.GetPolicies(), 3)
+	assert.Equal(t, pulumirpc.EnforcementLevel_MANDATORY, info.GetPolicies()[0].GetEnforcementLevel())
+	schema := info.GetPolicies()[1].GetConfigSchema().GetProperties().GetFields()["maxSize"].GetStructValue()
+	assert.Equal(t, float64(10), schema.GetFields()["default"].GetNumberValue())
+
+	pluginInfo, err := server.GetPluginInfo(ctx, &pbempty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", pluginInfo.GetVersion())
+
+	r := bucketResource("b", resource.PropertyMap{"acl": resource.NewStringProperty("public-read")})
+	props, err := plugin.MarshalProperties(r.Properties, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+	require.NoError(t, err)
+	resp, err := server.Analyze(ctx, &pulumirpc.AnalyzeRequest{
+		Urn:        string(r.URN),
+		Type:       string(r.Type),
+		Name:       string(r.Name),
+		Properties: props,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetDiagnostics(), 1)
+	assert.Equal(t, "no-public-buckets", resp.GetDiagnostics()[0].GetPolicyName())
+	assert.Equal(t, string(r.URN), resp.GetDiagnostics()[0].GetUrn())
+
+	resp, err = server.AnalyzeStack(ctx, &pulumirpc.AnalyzeStackRequest{
+		Resources: []*pulumirpc.AnalyzerResource{
+			{Urn: string(r.URN), Type: string(r.Type), Name: "a"},
+			{Urn: string(r.URN), Type: string(r.Type), Name: "b"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetDiagnostics(), 1)
+	assert.Equal(t, "one-bucket", resp.GetDiagnostics()[0].GetPolicyName())
+
+	_, err = server.Configure(ctx, &pulumirpc.ConfigureAnalyzerRequest{
+		PolicyConfig: map[string]*pulumirpc.PolicyConfig{
+			"one-bucket": {EnforcementLevel: pulumirpc.EnforcementLevel_DISABLED},
+		},
+	})
+	require.NoError(t, err)
+	resp, err = server.AnalyzeStack(ctx, &pulumirpc.AnalyzeStackRequest{
+		Resources: []*pulumirpc.AnalyzerResource{
+			{Urn: string(r.URN), Type: string(r.Type), Name: "a"},
+			{Urn: string(r.URN), Type: string(r.Type), Name: "b"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetDiagnostics())
+}
@@ -822,6 +822,11 @@ func (ctx *Context) registerResource(
 				Version:                 inputs.version,
 				Remote:                  remote,
 				ReplaceOnChanges:        inputs.replaceOnChanges,
+				RetainOnDelete:          inputs.retainOnDelete,
+				DeletedWith:             inputs.deletedWith,
+				RetryPolicy:             inputs.retryPolicy,
+				MaxConcurrency:          inputs.maxConcurrency,
+				RefuseReplace:           inputs.refuseReplace,
 			})
 			if err != nil {
 				logging.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -1185,6 +1190,11 @@ type resourceInputs struct {
 	additionalSecretOutputs []string
 	version                 string
 	replaceOnChanges        []string
+	retainOnDelete          bool
+	deletedWith             string
+	retryPolicy             *pulumirpc.RegisterResourceRequest_RetryPolicy
+	maxConcurrency          int32
+	refuseReplace           bool
 }
 
 // prepareResourceInputs prepares the inputs for a resource operation, shared between read and register.
@@ -1270,6 +1280,11 @@ func (ctx *Context) prepareResourceInputs(res Resource, props Input, t string, o
 		additionalSecretOutputs: resOpts.additionalSecretOutputs,
 		version:                 state.version,
 		replaceOnChanges:        resOpts.replaceOnChanges,
+		retainOnDelete:          resOpts.retainOnDelete,
+		deletedWith:             string(resOpts.deletedWithURN),
+		retryPolicy:             getRetryPolicy(opts.RetryPolicy),
+		maxConcurrency:          int32(opts.MaxConcurrency),
+		refuseReplace:           opts.RefuseReplace,
 	}, nil
 }
 
@@ -1283,6 +1298,17 @@ func getTimeouts(custom *CustomTimeouts) *pulumirpc.RegisterResourceRequest_Cust
 	return &timeouts
 }
 
+func getRetryPolicy(policy *RetryPolicy) *pulumirpc.RegisterResourceRequest_RetryPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &pulumirpc.RegisterResourceRequest_RetryPolicy{
+		MaxAttempts: int32(policy.MaxAttempts),
+		Backoff:     policy.Backoff,
+		Errors:      policy.Errors,
+	}
+}
+
 // Helper struct for the return type of `getOpts`.
 type resourceOpts struct {
 	parentURN               URN
@@ -1295,6 +1321,8 @@ type resourceOpts struct {
 	ignoreChanges           []string
 	additionalSecretOutputs []string
 	replaceOnChanges        []string
+	retainOnDelete          bool
+	deletedWithURN          URN
 }
 
 // getOpts returns a set of resource options from an array of them. This includes the parent URN, any dependency URNs,
@@ -1322,6 +1350,15 @@ func (ctx *Context) getOpts(res Resource, t string, provider ProviderResource, o
 		parentURN = urn
 	}
 
+	var deletedWithURN URN
+	if opts.DeletedWith != nil {
+		urn, _, _, err := opts.DeletedWith.URN().awaitURN(context.TODO())
+		if err != nil {
+			return resourceOpts{}, err
+		}
+		deletedWithURN = urn
+	}
+
 	var depURNs []URN
 	if opts.DependsOn != nil {
 		depSet := urnSet{}
@@ -1369,6 +1406,8 @@ func (ctx *Context) getOpts(res Resource, t string, provider ProviderResource, o
 		ignoreChanges:           opts.IgnoreChanges,
 		additionalSecretOutputs: opts.AdditionalSecretOutputs,
 		replaceOnChanges:        opts.ReplaceOnChanges,
+		retainOnDelete:          opts.RetainOnDelete,
+		deletedWithURN:          deletedWithURN,
 	}, nil
 }
 
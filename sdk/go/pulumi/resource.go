@@ -247,6 +247,16 @@ type CustomTimeouts struct {
 	Delete string
 }
 
+// RetryPolicy configures how the engine retries provider operations on a resource that fail with transient errors.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for each operation, including the first.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, e.g. "5s". The delay doubles after each further attempt.
+	Backoff string
+	// Errors is a list of regular expressions that match retryable errors. If empty, all errors are retryable.
+	Errors []string
+}
+
 type resourceOptions struct {
 	// AdditionalSecretOutputs is an optional list of output properties to mark as secret.
 	AdditionalSecretOutputs []string
@@ -256,6 +266,9 @@ type resourceOptions struct {
 	CustomTimeouts *CustomTimeouts
 	// DeleteBeforeReplace, when set to true, ensures that this resource is deleted prior to replacement.
 	DeleteBeforeReplace bool
+	// DeletedWith is an optional resource whose deletion also deletes this resource. If both resources are deleted in
+	// the same deployment, this resource is removed from the stack's state without calling its provider's delete.
+	DeletedWith Resource
 	// DependsOn is an optional array of explicit dependencies on other resources.
 	DependsOn []func(ctx context.Context) (urnSet, error)
 	// IgnoreChanges ignores changes to any of the specified properties.
@@ -265,6 +278,9 @@ type resourceOptions struct {
 	// current state. Once a resource has been imported, the import property must be removed from the resource's
 	// options.
 	Import IDInput
+	// MaxConcurrency, when set on a provider resource, limits the number of operations that the engine runs at once
+	// against that provider.
+	MaxConcurrency int
 	// Parent is an optional parent resource to which this resource belongs.
 	Parent Resource
 	// Protect, when set to true, ensures that this resource cannot be deleted (without first setting it to false).
@@ -277,6 +293,15 @@ type resourceOptions struct {
 	// changes to any properties will force a replacement.  Initialization errors from previous deployments will
 	// require replacement instead of update only if `"*"` is passed.
 	ReplaceOnChanges []string
+	// RefuseReplace, when set to true, causes any deployment that would replace this resource to fail before the
+	// replacement is made.
+	RefuseReplace bool
+	// RetainOnDelete, when set to true, causes the resource to be removed from the stack's state instead of being
+	// deleted by its provider when it is deleted or replaced.
+	RetainOnDelete bool
+	// RetryPolicy is an optional policy for retrying provider operations that fail with transient errors. It overrides
+	// any retry policy configured in the project.
+	RetryPolicy *RetryPolicy
 	// Transformations is an optional list of transformations to apply to this resource during construction.
 	// The transformations are applied in order, and are applied prior to transformation and to parents
 	// walking from the resource up to the stack.
@@ -361,6 +386,15 @@ func DeleteBeforeReplace(o bool) ResourceOption {
 	})
 }
 
+// DeletedWith is an optional resource whose deletion also deletes this resource, such as a namespace or resource group
+// that contains it. If both resources are deleted in the same deployment, this resource is removed from the stack's
+// state without calling its provider's delete operation.
+func DeletedWith(r Resource) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.DeletedWith = r
+	})
+}
+
 // DependsOn is an optional array of explicit dependencies on other resources.
 func DependsOn(o []Resource) ResourceOption {
 	return resourceOption(func(ro *resourceOptions) {
@@ -482,6 +516,39 @@ func ReplaceOnChanges(o []string) ResourceOption {
 	})
 }
 
+// RetainOnDelete, when set to true, causes the resource to be removed from the stack's state instead of being
+// deleted by its provider when it is deleted or replaced. The cloud resource continues to exist, but is no longer
+// managed by Pulumi.
+func RetainOnDelete(b bool) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.RetainOnDelete = b
+	})
+}
+
+// RefuseReplace, when set to true, causes any deployment that would replace this resource to fail before the
+// replacement is made. Use Protect to also prevent the resource from being deleted when it is removed from the program.
+func RefuseReplace(b bool) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.RefuseReplace = b
+	})
+}
+
+// MaxConcurrency limits the number of operations that the engine runs at once against a provider, such as a provider
+// for a rate-limited API. It may only be used on provider resources.
+func MaxConcurrency(n int) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.MaxConcurrency = n
+	})
+}
+
+// Retries is an optional policy for retrying provider operations that fail with transient errors, such as throttling.
+// It overrides any retry policy configured in the project.
+func Retries(o *RetryPolicy) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.RetryPolicy = o
+	})
+}
+
 // Timeouts is an optional configuration block used for CRUD operations
 func Timeouts(o *CustomTimeouts) ResourceOption {
 	return resourceOption(func(ro *resourceOptions) {
@@ -99,6 +99,17 @@ function deserialize_pulumirpc_PluginInfo(buffer_arg) {
   return plugin_pb.PluginInfo.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_RemediateResponse(arg) {
+  if (!(arg instanceof analyzer_pb.RemediateResponse)) {
+    throw new Error('Expected argument of type pulumirpc.RemediateResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_RemediateResponse(buffer_arg) {
+  return analyzer_pb.RemediateResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 
 // Analyzer provides a pluggable interface for checking resource definitions against some number of
 // resource policies. It is intentionally open-ended, allowing for implementations that check
@@ -168,6 +179,19 @@ configure: {
     responseSerialize: serialize_google_protobuf_Empty,
     responseDeserialize: deserialize_google_protobuf_Empty,
   },
+  // Remediate is called with the "inputs" to a resource, before they are checked by its provider, and returns
+// any changes that the analyzer's remediation policies make to them, e.g. adding mandatory tags.
+remediate: {
+    path: '/pulumirpc.Analyzer/Remediate',
+    requestStream: false,
+    responseStream: false,
+    requestType: analyzer_pb.AnalyzeRequest,
+    responseType: analyzer_pb.RemediateResponse,
+    requestSerialize: serialize_pulumirpc_AnalyzeRequest,
+    requestDeserialize: deserialize_pulumirpc_AnalyzeRequest,
+    responseSerialize: serialize_pulumirpc_RemediateResponse,
+    responseDeserialize: deserialize_pulumirpc_RemediateResponse,
+  },
 };
 
 exports.AnalyzerClient = grpc.makeGenericClientConstructor(AnalyzerService);
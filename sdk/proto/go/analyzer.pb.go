@@ -33,18 +33,21 @@ const (
 	EnforcementLevel_ADVISORY  EnforcementLevel = 0
 	EnforcementLevel_MANDATORY EnforcementLevel = 1
 	EnforcementLevel_DISABLED  EnforcementLevel = 2
+	EnforcementLevel_REMEDIATE EnforcementLevel = 3
 )
 
 var EnforcementLevel_name = map[int32]string{
 	0: "ADVISORY",
 	1: "MANDATORY",
 	2: "DISABLED",
+	3: "REMEDIATE",
 }
 
 var EnforcementLevel_value = map[string]int32{
 	"ADVISORY":  0,
 	"MANDATORY": 1,
 	"DISABLED":  2,
+	"REMEDIATE": 3,
 }
 
 func (x EnforcementLevel) String() string {
@@ -663,6 +666,117 @@ func (m *AnalyzeDiagnostic) GetUrn() string {
 	return ""
 }
 
+// Remediation describes the changes that a remediation policy made to a resource's inputs.
+type Remediation struct {
+	PolicyName           string          `protobuf:"bytes,1,opt,name=policyName,proto3" json:"policyName,omitempty"`
+	PolicyPackName       string          `protobuf:"bytes,2,opt,name=policyPackName,proto3" json:"policyPackName,omitempty"`
+	PolicyPackVersion    string          `protobuf:"bytes,3,opt,name=policyPackVersion,proto3" json:"policyPackVersion,omitempty"`
+	Description          string          `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Properties           *_struct.Struct `protobuf:"bytes,5,opt,name=properties,proto3" json:"properties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Remediation) Reset()         { *m = Remediation{} }
+func (m *Remediation) String() string { return proto.CompactTextString(m) }
+func (*Remediation) ProtoMessage()    {}
+func (*Remediation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fadbb7eccb91f143, []int{8}
+}
+
+func (m *Remediation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Remediation.Unmarshal(m, b)
+}
+func (m *Remediation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Remediation.Marshal(b, m, deterministic)
+}
+func (m *Remediation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Remediation.Merge(m, src)
+}
+func (m *Remediation) XXX_Size() int {
+	return xxx_messageInfo_Remediation.Size(m)
+}
+func (m *Remediation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Remediation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Remediation proto.InternalMessageInfo
+
+func (m *Remediation) GetPolicyName() string {
+	if m != nil {
+		return m.PolicyName
+	}
+	return ""
+}
+
+func (m *Remediation) GetPolicyPackName() string {
+	if m != nil {
+		return m.PolicyPackName
+	}
+	return ""
+}
+
+func (m *Remediation) GetPolicyPackVersion() string {
+	if m != nil {
+		return m.PolicyPackVersion
+	}
+	return ""
+}
+
+func (m *Remediation) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Remediation) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+type RemediateResponse struct {
+	Remediations         []*Remediation `protobuf:"bytes,1,rep,name=remediations,proto3" json:"remediations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RemediateResponse) Reset()         { *m = RemediateResponse{} }
+func (m *RemediateResponse) String() string { return proto.CompactTextString(m) }
+func (*RemediateResponse) ProtoMessage()    {}
+func (*RemediateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fadbb7eccb91f143, []int{9}
+}
+
+func (m *RemediateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemediateResponse.Unmarshal(m, b)
+}
+func (m *RemediateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemediateResponse.Marshal(b, m, deterministic)
+}
+func (m *RemediateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemediateResponse.Merge(m, src)
+}
+func (m *RemediateResponse) XXX_Size() int {
+	return xxx_messageInfo_RemediateResponse.Size(m)
+}
+func (m *RemediateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemediateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemediateResponse proto.InternalMessageInfo
+
+func (m *RemediateResponse) GetRemediations() []*Remediation {
+	if m != nil {
+		return m.Remediations
+	}
+	return nil
+}
+
 // AnalyzerInfo provides metadata about a PolicyPack inside an analyzer.
 type AnalyzerInfo struct {
 	Name                 string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -680,7 +794,7 @@ func (m *AnalyzerInfo) Reset()         { *m = AnalyzerInfo{} }
 func (m *AnalyzerInfo) String() string { return proto.CompactTextString(m) }
 func (*AnalyzerInfo) ProtoMessage()    {}
 func (*AnalyzerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fadbb7eccb91f143, []int{8}
+	return fileDescriptor_fadbb7eccb91f143, []int{10}
 }
 
 func (m *AnalyzerInfo) XXX_Unmarshal(b []byte) error {
@@ -760,7 +874,7 @@ func (m *PolicyInfo) Reset()         { *m = PolicyInfo{} }
 func (m *PolicyInfo) String() string { return proto.CompactTextString(m) }
 func (*PolicyInfo) ProtoMessage()    {}
 func (*PolicyInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fadbb7eccb91f143, []int{9}
+	return fileDescriptor_fadbb7eccb91f143, []int{11}
 }
 
 func (m *PolicyInfo) XXX_Unmarshal(b []byte) error {
@@ -836,7 +950,7 @@ func (m *PolicyConfigSchema) Reset()         { *m = PolicyConfigSchema{} }
 func (m *PolicyConfigSchema) String() string { return proto.CompactTextString(m) }
 func (*PolicyConfigSchema) ProtoMessage()    {}
 func (*PolicyConfigSchema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fadbb7eccb91f143, []int{10}
+	return fileDescriptor_fadbb7eccb91f143, []int{12}
 }
 
 func (m *PolicyConfigSchema) XXX_Unmarshal(b []byte) error {
@@ -884,7 +998,7 @@ func (m *PolicyConfig) Reset()         { *m = PolicyConfig{} }
 func (m *PolicyConfig) String() string { return proto.CompactTextString(m) }
 func (*PolicyConfig) ProtoMessage()    {}
 func (*PolicyConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fadbb7eccb91f143, []int{11}
+	return fileDescriptor_fadbb7eccb91f143, []int{13}
 }
 
 func (m *PolicyConfig) XXX_Unmarshal(b []byte) error {
@@ -931,7 +1045,7 @@ func (m *ConfigureAnalyzerRequest) Reset()         { *m = ConfigureAnalyzerReque
 func (m *ConfigureAnalyzerRequest) String() string { return proto.CompactTextString(m) }
 func (*ConfigureAnalyzerRequest) ProtoMessage()    {}
 func (*ConfigureAnalyzerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fadbb7eccb91f143, []int{12}
+	return fileDescriptor_fadbb7eccb91f143, []int{14}
 }
 
 func (m *ConfigureAnalyzerRequest) XXX_Unmarshal(b []byte) error {
@@ -971,6 +1085,8 @@ func init() {
 	proto.RegisterType((*AnalyzeStackRequest)(nil), "pulumirpc.AnalyzeStackRequest")
 	proto.RegisterType((*AnalyzeResponse)(nil), "pulumirpc.AnalyzeResponse")
 	proto.RegisterType((*AnalyzeDiagnostic)(nil), "pulumirpc.AnalyzeDiagnostic")
+	proto.RegisterType((*Remediation)(nil), "pulumirpc.Remediation")
+	proto.RegisterType((*RemediateResponse)(nil), "pulumirpc.RemediateResponse")
 	proto.RegisterType((*AnalyzerInfo)(nil), "pulumirpc.AnalyzerInfo")
 	proto.RegisterMapType((map[string]*PolicyConfig)(nil), "pulumirpc.AnalyzerInfo.InitialConfigEntry")
 	proto.RegisterType((*PolicyInfo)(nil), "pulumirpc.PolicyInfo")
@@ -983,78 +1099,82 @@ func init() {
 func init() { proto.RegisterFile("analyzer.proto", fileDescriptor_fadbb7eccb91f143) }
 
 var fileDescriptor_fadbb7eccb91f143 = []byte{
-	// 1130 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x57, 0x51, 0x6f, 0x1b, 0x45,
-	0x10, 0xce, 0xd9, 0x69, 0x62, 0x8f, 0x1d, 0xd7, 0xd9, 0x42, 0x73, 0xbd, 0x86, 0x2a, 0xba, 0x22,
-	0x88, 0x2a, 0x70, 0xa9, 0x11, 0xa2, 0x20, 0x0a, 0x38, 0x71, 0x14, 0x05, 0x85, 0xc6, 0xac, 0xab,
-	0xaa, 0x79, 0xbc, 0x9e, 0xc7, 0xee, 0x2a, 0xe7, 0xbb, 0xeb, 0xde, 0x5e, 0x24, 0xf3, 0xc8, 0x23,
-	0x12, 0x12, 0x7f, 0x80, 0x7f, 0xc1, 0xff, 0xe0, 0x85, 0xdf, 0xc0, 0x33, 0x3f, 0x01, 0xed, 0xee,
-	0x9d, 0x73, 0xe7, 0x3b, 0xbb, 0x55, 0x84, 0xc4, 0x03, 0x6f, 0x3b, 0xb3, 0xdf, 0xcc, 0xee, 0x7c,
-	0xf3, 0xdd, 0xac, 0x0d, 0x2d, 0xc7, 0x77, 0xbc, 0xd9, 0x8f, 0xc8, 0x3b, 0x21, 0x0f, 0x44, 0x40,
-	0xea, 0x61, 0xec, 0xc5, 0x53, 0xc6, 0x43, 0xd7, 0x6a, 0x86, 0x5e, 0x3c, 0x61, 0xbe, 0xde, 0xb0,
-	0xee, 0x4e, 0x82, 0x60, 0xe2, 0xe1, 0x43, 0x65, 0xbd, 0x8c, 0xc7, 0x0f, 0x71, 0x1a, 0x8a, 0x59,
-	0xb2, 0xb9, 0xbb, 0xb8, 0x19, 0x09, 0x1e, 0xbb, 0x42, 0xef, 0xda, 0x3f, 0x55, 0xa0, 0xd5, 0xd3,
-	0xc7, 0x50, 0x7c, 0x1d, 0x63, 0x24, 0x08, 0x81, 0x75, 0x31, 0x0b, 0xd1, 0x34, 0xf6, 0x8c, 0xfd,
-	0x3a, 0x55, 0x6b, 0xf2, 0x39, 0x40, 0xc8, 0x83, 0x10, 0xb9, 0x60, 0x18, 0x99, 0x95, 0x3d, 0x63,
-	0xbf, 0xd1, 0xdd, 0xe9, 0xe8, 0xcc, 0x9d, 0x34, 0x73, 0x67, 0xa8, 0x32, 0xd3, 0x0c, 0x94, 0xb4,
-	0xa1, 0x1a, 0x73, 0xdf, 0xac, 0xaa, 0x5c, 0x72, 0x29, 0xd3, 0xfb, 0xce, 0x14, 0xcd, 0x75, 0x9d,
-	0x5e, 0xae, 0xc9, 0x57, 0xb0, 0x19, 0x84, 0x82, 0x05, 0x7e, 0x64, 0xde, 0x50, 0xb9, 0xed, 0xce,
-	0xbc, 0xd6, 0x4e, 0x72, 0x3d, 0x4e, 0x31, 0x0a, 0x62, 0xee, 0xe2, 0x99, 0x46, 0xd2, 0x34, 0x84,
-	0x7c, 0x03, 0xb5, 0x90, 0x07, 0x97, 0x6c, 0x84, 0xdc, 0xdc, 0x50, 0xe1, 0xf7, 0x4b, 0xc2, 0x07,
-	0x09, 0x24, 0x4d, 0x43, 0xe7, 0x41, 0xf6, 0x6f, 0xeb, 0xd0, 0x5e, 0x3c, 0xe5, 0xff, 0x47, 0x03,
-	0xb9, 0x0d, 0x1b, 0xa1, 0xc3, 0xd1, 0x17, 0xe6, 0xa6, 0xba, 0x54, 0x62, 0x11, 0x1b, 0x9a, 0x23,
-	0x0c, 0xd1, 0x1f, 0xa1, 0xef, 0xca, 0xba, 0x6b, 0x7b, 0xd5, 0xfd, 0x3a, 0xcd, 0xf9, 0x08, 0x83,
-	0x77, 0x92, 0x72, 0x67, 0xfd, 0x2c, 0xb6, 0xbe, 0x57, 0xdd, 0x6f, 0x74, 0x3f, 0x5b, 0x51, 0x47,
-	0x67, 0x50, 0x12, 0x77, 0xe4, 0x0b, 0x3e, 0xa3, 0xa5, 0x29, 0xad, 0x10, 0xee, 0x2c, 0x0d, 0x91,
-	0x44, 0x5f, 0xe0, 0x2c, 0x69, 0x9a, 0x5c, 0x92, 0x27, 0x70, 0xe3, 0xd2, 0xf1, 0x62, 0x4c, 0xda,
-	0xf5, 0x61, 0x39, 0x27, 0x85, 0x74, 0x54, 0x47, 0x7d, 0x59, 0x79, 0x6c, 0xd8, 0x7f, 0x56, 0x61,
-	0x67, 0x09, 0xfd, 0xc4, 0x84, 0x4d, 0xd9, 0x78, 0x74, 0x85, 0x3a, 0xb4, 0x46, 0x53, 0x93, 0xbc,
-	0x0f, 0x5b, 0x6c, 0xe2, 0x07, 0x1c, 0x0f, 0x5f, 0x39, 0xfe, 0x44, 0xe9, 0x45, 0xf2, 0x96, 0x77,
-	0x92, 0x4f, 0xe0, 0xd6, 0x08, 0x3d, 0x14, 0x78, 0x80, 0xe3, 0x80, 0x23, 0xc5, 0xd0, 0x73, 0x5c,
-	0x54, 0x4a, 0xa9, 0xd1, 0xb2, 0x2d, 0xf2, 0x35, 0x58, 0x25, 0xee, 0x3e, 0x8e, 0x99, 0x8f, 0x23,
-	0xa5, 0xa7, 0x1a, 0x5d, 0x81, 0x20, 0x8f, 0x61, 0xc7, 0x19, 0x8d, 0x98, 0xbc, 0xbe, 0xe3, 0x0d,
-	0xd1, 0xe5, 0x28, 0xce, 0x62, 0x11, 0xc6, 0x42, 0xaa, 0x4e, 0xde, 0x70, 0xd9, 0xb6, 0xac, 0xd5,
-	0xf1, 0x98, 0x13, 0x61, 0x64, 0x6e, 0x28, 0x64, 0x6a, 0x92, 0x73, 0x68, 0xb9, 0x71, 0x24, 0x82,
-	0xe9, 0x33, 0x36, 0xc5, 0x40, 0xa6, 0xda, 0x54, 0x6c, 0x3f, 0x7a, 0xb3, 0x80, 0x3b, 0x87, 0xb9,
-	0x40, 0xba, 0x90, 0xc8, 0x7a, 0x01, 0xad, 0x3c, 0x42, 0xea, 0xd4, 0xe5, 0xe8, 0x08, 0xfd, 0x6d,
-	0x1a, 0x34, 0xb1, 0xa4, 0x3f, 0x0e, 0x47, 0xd2, 0x5f, 0xd1, 0x7e, 0x6d, 0x49, 0xbf, 0xa6, 0x43,
-	0xb1, 0x6a, 0xd0, 0xc4, 0xb2, 0x7f, 0x31, 0xc0, 0x5c, 0xf6, 0x59, 0xfc, 0x07, 0x9f, 0xbf, 0xdd,
-	0x85, 0xdd, 0x55, 0x8a, 0x94, 0x31, 0x31, 0xf7, 0x23, 0xd3, 0x50, 0xdc, 0xab, 0xb5, 0x3d, 0x80,
-	0x5b, 0x49, 0xcc, 0x50, 0x38, 0xee, 0x45, 0x3a, 0xc3, 0xbf, 0x80, 0x3a, 0x4f, 0x2a, 0xd1, 0xf8,
-	0x46, 0xf7, 0xee, 0x8a, 0x56, 0xd0, 0x2b, 0xb4, 0xfd, 0x03, 0xdc, 0x9c, 0x3f, 0x08, 0x51, 0x18,
-	0xf8, 0x91, 0x54, 0x5c, 0x63, 0xc4, 0x9c, 0x89, 0x1f, 0x44, 0x82, 0xb9, 0x5a, 0xc7, 0x8d, 0xee,
-	0x6e, 0x31, 0x5f, 0x7f, 0x0e, 0xa2, 0xd9, 0x00, 0xfb, 0xf7, 0x0a, 0x6c, 0x17, 0x20, 0xe4, 0x1e,
-	0x40, 0x18, 0x78, 0xcc, 0x9d, 0x3d, 0x95, 0x44, 0x68, 0x9e, 0x33, 0x1e, 0xf2, 0x01, 0xb4, 0xb4,
-	0x35, 0x70, 0xdc, 0x0b, 0x85, 0xa9, 0x28, 0xcc, 0x82, 0x97, 0x7c, 0x04, 0xdb, 0x57, 0x9e, 0xe7,
-	0xc8, 0x23, 0x16, 0xa4, 0x54, 0x17, 0x37, 0xc8, 0x1e, 0x34, 0x46, 0x18, 0xb9, 0x9c, 0x29, 0xf5,
-	0x25, 0xfc, 0x67, 0x5d, 0x52, 0xe5, 0x53, 0x8c, 0x22, 0x67, 0x82, 0x6a, 0x0a, 0xd7, 0x69, 0x6a,
-	0x2a, 0x4d, 0x38, 0x93, 0x54, 0xfc, 0x6a, 0x4d, 0x8e, 0xa1, 0x8d, 0xfe, 0x38, 0xe0, 0x2e, 0x4e,
-	0xd1, 0x17, 0xa7, 0x78, 0x89, 0x9e, 0xd2, 0x7e, 0x2b, 0x47, 0xf8, 0xd1, 0x02, 0x84, 0x16, 0x82,
-	0x52, 0x8d, 0xd4, 0xe6, 0x1a, 0xb1, 0xff, 0xaa, 0x40, 0x33, 0xed, 0xd4, 0x89, 0x3f, 0x0e, 0xe6,
-	0xa2, 0x31, 0x32, 0x6f, 0x86, 0xac, 0x87, 0x45, 0xa1, 0xe7, 0xcc, 0x32, 0x14, 0x65, 0x5d, 0xe4,
-	0x11, 0xd4, 0x14, 0x0d, 0x52, 0xb3, 0x55, 0xd5, 0xba, 0x77, 0x33, 0x37, 0x1b, 0x28, 0x86, 0x64,
-	0x7a, 0x3a, 0x87, 0x49, 0x0a, 0x2e, 0x13, 0x22, 0x35, 0x41, 0xa9, 0x29, 0x9b, 0x12, 0xc5, 0x61,
-	0x18, 0x70, 0x11, 0x1d, 0x06, 0xfe, 0x98, 0x4d, 0x14, 0x47, 0x35, 0xba, 0xe0, 0x25, 0x03, 0xd8,
-	0x62, 0x3e, 0x13, 0xcc, 0xf1, 0x12, 0xd8, 0x86, 0x3a, 0xf9, 0x41, 0x89, 0x08, 0xe5, 0xd9, 0x9d,
-	0x93, 0x2c, 0x58, 0x4f, 0xff, 0x7c, 0x02, 0xeb, 0x1c, 0x48, 0x11, 0x54, 0x32, 0xef, 0x3f, 0xce,
-	0xcf, 0xfb, 0x9d, 0x42, 0xad, 0x3a, 0x3c, 0x3b, 0xdf, 0x7f, 0xae, 0x00, 0x5c, 0xf1, 0x70, 0x4d,
-	0x9a, 0x17, 0x84, 0x55, 0x5d, 0x29, 0xac, 0xf5, 0xbc, 0xb0, 0xca, 0x44, 0x74, 0xe3, 0x3a, 0x22,
-	0xea, 0x41, 0xd3, 0x55, 0xe5, 0x0d, 0xdd, 0x57, 0x38, 0x75, 0x92, 0xdf, 0x01, 0xef, 0x2d, 0xe1,
-	0x40, 0x83, 0x68, 0x2e, 0xc4, 0x66, 0x40, 0x8a, 0x98, 0x85, 0xd1, 0x67, 0xbc, 0xfd, 0xe8, 0xb3,
-	0xa0, 0xc6, 0xf1, 0x75, 0xcc, 0x38, 0x8e, 0x92, 0x07, 0x70, 0x6e, 0xdb, 0xbf, 0x1a, 0xd0, 0xcc,
-	0x9e, 0x55, 0xca, 0x83, 0x71, 0x1d, 0x1e, 0xae, 0x3b, 0xa9, 0xed, 0x3f, 0x0c, 0x30, 0xf5, 0x65,
-	0x62, 0x8e, 0x57, 0x63, 0x52, 0x4f, 0xd5, 0x73, 0x68, 0x86, 0x99, 0xeb, 0x26, 0x83, 0x35, 0xfb,
-	0xe3, 0x66, 0x59, 0x68, 0x8e, 0x76, 0x2d, 0xef, 0x5c, 0x2a, 0xeb, 0x05, 0x6c, 0x17, 0x20, 0xff,
-	0x8a, 0xb8, 0x1f, 0x3c, 0x81, 0xf6, 0x22, 0x61, 0xa4, 0x09, 0xb5, 0x5e, 0xff, 0xf9, 0xc9, 0xf0,
-	0x8c, 0x9e, 0xb7, 0xd7, 0xc8, 0x16, 0xd4, 0xbf, 0xef, 0x3d, 0xed, 0xf7, 0x9e, 0x49, 0xd3, 0x90,
-	0x9b, 0xfd, 0x93, 0x61, 0xef, 0xe0, 0xf4, 0xa8, 0xdf, 0xae, 0x74, 0xff, 0xae, 0x40, 0x2d, 0x2d,
-	0x86, 0x1c, 0xc0, 0x66, 0xb2, 0x26, 0x77, 0x8a, 0x5f, 0x72, 0x52, 0xab, 0x65, 0x95, 0x6d, 0xe9,
-	0xa7, 0xc4, 0x5e, 0x23, 0xa7, 0xf3, 0xa1, 0xa6, 0x5e, 0x2c, 0x72, 0xaf, 0x88, 0xce, 0x3e, 0x65,
-	0x6f, 0xc8, 0xd6, 0x87, 0x9b, 0xc7, 0x28, 0x72, 0x53, 0xf2, 0x76, 0xa1, 0xcf, 0x47, 0xf2, 0xef,
-	0x90, 0xb5, 0xb3, 0x64, 0xf6, 0xd8, 0x6b, 0xe4, 0x5b, 0xd8, 0x3a, 0x46, 0x31, 0x50, 0xff, 0xa9,
-	0x56, 0xe6, 0xc8, 0x4d, 0xce, 0x39, 0xdc, 0x5e, 0x23, 0xdf, 0x41, 0x7d, 0xde, 0x7b, 0x72, 0xff,
-	0x2d, 0x14, 0x61, 0x2d, 0x39, 0xc2, 0x5e, 0x7b, 0xb9, 0xa1, 0x3c, 0x9f, 0xfe, 0x13, 0x00, 0x00,
-	0xff, 0xff, 0x13, 0xd0, 0x20, 0xeb, 0x00, 0x0e, 0x00, 0x00,
+	// 1198 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x57, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0x5e, 0x27, 0xfb, 0x93, 0x9c, 0x64, 0xd3, 0xec, 0x14, 0xba, 0xae, 0xbb, 0x54, 0x2b, 0x17,
+	0xc1, 0xaa, 0x82, 0x94, 0x06, 0x21, 0x4a, 0xc5, 0x5f, 0xda, 0x84, 0x6a, 0xd1, 0xb6, 0x1b, 0x26,
+	0x55, 0xd5, 0xbd, 0x74, 0xed, 0x93, 0x74, 0x54, 0xc7, 0x76, 0xc7, 0xe3, 0x95, 0xc2, 0x25, 0x97,
+	0x48, 0x48, 0xbc, 0x00, 0x6f, 0xc1, 0x05, 0x6f, 0xc1, 0x0d, 0xd7, 0x5c, 0xf2, 0x1c, 0x68, 0xc6,
+	0x76, 0x62, 0xc7, 0x4e, 0xba, 0x5a, 0x21, 0x81, 0xc4, 0xdd, 0x9c, 0x99, 0xef, 0x9c, 0x99, 0xf3,
+	0xcd, 0x77, 0xe6, 0xd8, 0xd0, 0xb2, 0x3c, 0xcb, 0x9d, 0x7d, 0x8f, 0xbc, 0x13, 0x70, 0x5f, 0xf8,
+	0xa4, 0x1e, 0x44, 0x6e, 0x34, 0x65, 0x3c, 0xb0, 0x8d, 0x66, 0xe0, 0x46, 0x13, 0xe6, 0xc5, 0x0b,
+	0xc6, 0x8d, 0x89, 0xef, 0x4f, 0x5c, 0xbc, 0xa3, 0xac, 0x17, 0xd1, 0xf8, 0x0e, 0x4e, 0x03, 0x31,
+	0x4b, 0x16, 0x0f, 0x96, 0x17, 0x43, 0xc1, 0x23, 0x5b, 0xc4, 0xab, 0xe6, 0x0f, 0x15, 0x68, 0xf5,
+	0xe2, 0x6d, 0x28, 0xbe, 0x8e, 0x30, 0x14, 0x84, 0xc0, 0xa6, 0x98, 0x05, 0xa8, 0x6b, 0x87, 0xda,
+	0x51, 0x9d, 0xaa, 0x31, 0xf9, 0x14, 0x20, 0xe0, 0x7e, 0x80, 0x5c, 0x30, 0x0c, 0xf5, 0xca, 0xa1,
+	0x76, 0xd4, 0xe8, 0xee, 0x77, 0xe2, 0xc8, 0x9d, 0x34, 0x72, 0x67, 0xa4, 0x22, 0xd3, 0x0c, 0x94,
+	0xb4, 0xa1, 0x1a, 0x71, 0x4f, 0xaf, 0xaa, 0x58, 0x72, 0x28, 0xc3, 0x7b, 0xd6, 0x14, 0xf5, 0xcd,
+	0x38, 0xbc, 0x1c, 0x93, 0xcf, 0x61, 0xc7, 0x0f, 0x04, 0xf3, 0xbd, 0x50, 0xdf, 0x52, 0xb1, 0xcd,
+	0xce, 0x3c, 0xd7, 0x4e, 0x72, 0x3c, 0x4e, 0x31, 0xf4, 0x23, 0x6e, 0xe3, 0x69, 0x8c, 0xa4, 0xa9,
+	0x0b, 0xf9, 0x0a, 0x6a, 0x01, 0xf7, 0xcf, 0x99, 0x83, 0x5c, 0xdf, 0x56, 0xee, 0xb7, 0x4a, 0xdc,
+	0x87, 0x09, 0x24, 0x0d, 0x43, 0xe7, 0x4e, 0xe6, 0x2f, 0x9b, 0xd0, 0x5e, 0xde, 0xe5, 0xff, 0x47,
+	0x03, 0xb9, 0x06, 0xdb, 0x81, 0xc5, 0xd1, 0x13, 0xfa, 0x8e, 0x3a, 0x54, 0x62, 0x11, 0x13, 0x9a,
+	0x0e, 0x06, 0xe8, 0x39, 0xe8, 0xd9, 0x32, 0xef, 0xda, 0x61, 0xf5, 0xa8, 0x4e, 0x73, 0x73, 0x84,
+	0xc1, 0x5b, 0x49, 0xba, 0xb3, 0x7e, 0x16, 0x5b, 0x3f, 0xac, 0x1e, 0x35, 0xba, 0x9f, 0xac, 0xc9,
+	0xa3, 0x33, 0x2c, 0xf1, 0x1b, 0x78, 0x82, 0xcf, 0x68, 0x69, 0x48, 0x23, 0x80, 0xeb, 0x2b, 0x5d,
+	0x24, 0xd1, 0xaf, 0x70, 0x96, 0x5c, 0x9a, 0x1c, 0x92, 0x2f, 0x60, 0xeb, 0xdc, 0x72, 0x23, 0x4c,
+	0xae, 0xeb, 0xfd, 0x72, 0x4e, 0x0a, 0xe1, 0x68, 0xec, 0x75, 0xbf, 0x72, 0x4f, 0x33, 0xff, 0xa8,
+	0xc2, 0xfe, 0x0a, 0xfa, 0x89, 0x0e, 0x3b, 0xf2, 0xe2, 0xd1, 0x16, 0x6a, 0xd3, 0x1a, 0x4d, 0x4d,
+	0xf2, 0x2e, 0xec, 0xb2, 0x89, 0xe7, 0x73, 0x7c, 0xf8, 0xd2, 0xf2, 0x26, 0x4a, 0x2f, 0x92, 0xb7,
+	0xfc, 0x24, 0xf9, 0x08, 0xae, 0x3a, 0xe8, 0xa2, 0xc0, 0x07, 0x38, 0xf6, 0x39, 0x52, 0x0c, 0x5c,
+	0xcb, 0x46, 0xa5, 0x94, 0x1a, 0x2d, 0x5b, 0x22, 0x5f, 0x82, 0x51, 0x32, 0xdd, 0xc7, 0x31, 0xf3,
+	0xd0, 0x51, 0x7a, 0xaa, 0xd1, 0x35, 0x08, 0x72, 0x0f, 0xf6, 0x2d, 0xc7, 0x61, 0xf2, 0xf8, 0x96,
+	0x3b, 0x42, 0x9b, 0xa3, 0x38, 0x8d, 0x44, 0x10, 0x09, 0xa9, 0x3a, 0x79, 0xc2, 0x55, 0xcb, 0x32,
+	0x57, 0xcb, 0x65, 0x56, 0x88, 0xa1, 0xbe, 0xad, 0x90, 0xa9, 0x49, 0xce, 0xa0, 0x65, 0x47, 0xa1,
+	0xf0, 0xa7, 0x4f, 0xd9, 0x14, 0x7d, 0x19, 0x6a, 0x47, 0xb1, 0x7d, 0xf7, 0xcd, 0x02, 0xee, 0x3c,
+	0xcc, 0x39, 0xd2, 0xa5, 0x40, 0xc6, 0x73, 0x68, 0xe5, 0x11, 0x52, 0xa7, 0x36, 0x47, 0x4b, 0xc4,
+	0xb5, 0xa9, 0xd1, 0xc4, 0x92, 0xf3, 0x51, 0xe0, 0x58, 0x22, 0xbe, 0x6a, 0x8d, 0x26, 0x96, 0x9c,
+	0x8f, 0xe9, 0x50, 0xac, 0x6a, 0x34, 0xb1, 0xcc, 0x9f, 0x34, 0xd0, 0x57, 0x95, 0xc5, 0xbf, 0x50,
+	0xfe, 0x66, 0x17, 0x0e, 0xd6, 0x29, 0x52, 0xfa, 0x44, 0xdc, 0x0b, 0x75, 0x4d, 0x71, 0xaf, 0xc6,
+	0xe6, 0x10, 0xae, 0x26, 0x3e, 0x23, 0x61, 0xd9, 0xaf, 0xd2, 0x37, 0xfc, 0x33, 0xa8, 0xf3, 0x24,
+	0x93, 0x18, 0xdf, 0xe8, 0xde, 0x58, 0x73, 0x15, 0x74, 0x81, 0x36, 0xbf, 0x83, 0x2b, 0xf3, 0x86,
+	0x10, 0x06, 0xbe, 0x17, 0x4a, 0xc5, 0x35, 0x1c, 0x66, 0x4d, 0x3c, 0x3f, 0x14, 0xcc, 0x8e, 0x75,
+	0xdc, 0xe8, 0x1e, 0x14, 0xe3, 0xf5, 0xe7, 0x20, 0x9a, 0x75, 0x30, 0x7f, 0xad, 0xc0, 0x5e, 0x01,
+	0x42, 0x6e, 0x02, 0x04, 0xbe, 0xcb, 0xec, 0xd9, 0x13, 0x6b, 0x9a, 0xf2, 0x9c, 0x99, 0x21, 0xef,
+	0x41, 0x2b, 0xb6, 0x86, 0x96, 0xfd, 0x4a, 0x61, 0x2a, 0x0a, 0xb3, 0x34, 0x4b, 0x3e, 0x80, 0xbd,
+	0xc5, 0xcc, 0x33, 0xe4, 0x21, 0xf3, 0x53, 0xaa, 0x8b, 0x0b, 0xe4, 0x10, 0x1a, 0x0e, 0x86, 0x36,
+	0x67, 0x4a, 0x7d, 0x09, 0xff, 0xd9, 0x29, 0xa9, 0xf2, 0x29, 0x86, 0xa1, 0x35, 0x41, 0xf5, 0x0a,
+	0xd7, 0x69, 0x6a, 0x2a, 0x4d, 0x58, 0x93, 0x54, 0xfc, 0x6a, 0x4c, 0x1e, 0x41, 0x1b, 0xbd, 0xb1,
+	0xcf, 0x6d, 0x9c, 0xa2, 0x27, 0x4e, 0xf0, 0x1c, 0x5d, 0xa5, 0xfd, 0x56, 0x8e, 0xf0, 0xc1, 0x12,
+	0x84, 0x16, 0x9c, 0x52, 0x8d, 0xd4, 0xe6, 0x1a, 0x31, 0xff, 0xd4, 0xa0, 0x41, 0x71, 0x8a, 0x0e,
+	0xb3, 0xd4, 0xc1, 0xfe, 0xab, 0x84, 0xe5, 0xcb, 0x62, 0xeb, 0xc2, 0x65, 0x61, 0x9e, 0xc2, 0x5e,
+	0x9a, 0xdf, 0x42, 0x6c, 0xf7, 0xa1, 0xc9, 0x17, 0x49, 0xa7, 0xea, 0xbd, 0x96, 0x21, 0x33, 0xc3,
+	0x09, 0xcd, 0x61, 0xcd, 0xbf, 0x2a, 0xd0, 0x4c, 0xb5, 0x7d, 0xec, 0x8d, 0xfd, 0x79, 0x99, 0x69,
+	0x99, 0x2e, 0x2b, 0x13, 0x62, 0x61, 0xe0, 0x5a, 0xb3, 0x0c, 0x47, 0xd9, 0x29, 0x72, 0x17, 0x6a,
+	0x8a, 0x07, 0x99, 0x4e, 0x55, 0x6d, 0xff, 0x76, 0x66, 0xfb, 0xa1, 0xa2, 0x48, 0x86, 0xa7, 0x73,
+	0x98, 0x14, 0xcd, 0x79, 0xc2, 0x64, 0xcc, 0x50, 0x6a, 0xca, 0x5b, 0x09, 0xa3, 0x20, 0xf0, 0xb9,
+	0x08, 0x1f, 0xfa, 0xde, 0x98, 0x4d, 0x14, 0x43, 0x35, 0xba, 0x34, 0x4b, 0x86, 0xb0, 0xcb, 0x3c,
+	0x26, 0x98, 0xe5, 0x26, 0xb0, 0x6d, 0xb5, 0xf3, 0xed, 0x92, 0xb2, 0x95, 0x7b, 0x77, 0x8e, 0xb3,
+	0xe0, 0xb8, 0x5f, 0xe6, 0x03, 0x18, 0x67, 0x40, 0x8a, 0xa0, 0x92, 0x0e, 0xf9, 0x61, 0xbe, 0x43,
+	0xee, 0x17, 0x72, 0x8d, 0xdd, 0xb3, 0x1d, 0xf1, 0xc7, 0x0a, 0xc0, 0x82, 0x87, 0x4b, 0xd2, 0xbc,
+	0xa4, 0xac, 0xea, 0xda, 0x52, 0xdc, 0xcc, 0x97, 0x62, 0x59, 0xd9, 0x6d, 0x5d, 0xa6, 0xec, 0x7a,
+	0xd0, 0xb4, 0x55, 0x7a, 0x23, 0xfb, 0x25, 0x4e, 0xad, 0xe4, 0xcb, 0xe9, 0x9d, 0x15, 0x1c, 0xc4,
+	0x20, 0x9a, 0x73, 0x31, 0x19, 0x90, 0x22, 0x66, 0xa9, 0x2a, 0xb4, 0x8b, 0x37, 0x0b, 0x03, 0x6a,
+	0x1c, 0x5f, 0x47, 0x8c, 0xa3, 0x93, 0x7c, 0x32, 0xcc, 0x6d, 0xf3, 0x67, 0x0d, 0x9a, 0xd9, 0xbd,
+	0x4a, 0x79, 0xd0, 0x2e, 0xc3, 0xc3, 0x65, 0x7b, 0x9b, 0xf9, 0xbb, 0x06, 0x7a, 0x7c, 0x98, 0x88,
+	0xe3, 0xa2, 0xb1, 0xc4, 0x7d, 0xe8, 0x0c, 0x9a, 0x41, 0xe6, 0xb8, 0xba, 0x56, 0xf8, 0x1c, 0x5c,
+	0xe5, 0x9a, 0xa3, 0x3d, 0x96, 0x77, 0x2e, 0x94, 0xf1, 0x1c, 0xf6, 0x0a, 0x90, 0x7f, 0x44, 0xdc,
+	0xb7, 0x4f, 0xa0, 0xbd, 0x4c, 0x18, 0x69, 0x42, 0xad, 0xd7, 0x7f, 0x76, 0x3c, 0x3a, 0xa5, 0x67,
+	0xed, 0x0d, 0xb2, 0x0b, 0xf5, 0xc7, 0xbd, 0x27, 0xfd, 0xde, 0x53, 0x69, 0x6a, 0x72, 0xb1, 0x7f,
+	0x3c, 0xea, 0x3d, 0x38, 0x19, 0xf4, 0xdb, 0x15, 0xb9, 0x48, 0x07, 0x8f, 0x07, 0xfd, 0xe3, 0xde,
+	0xd3, 0x41, 0xbb, 0xda, 0xfd, 0xad, 0x0a, 0xb5, 0x34, 0x37, 0xf2, 0x00, 0x76, 0x92, 0x31, 0xb9,
+	0x5e, 0x2c, 0xec, 0x24, 0x75, 0xc3, 0x28, 0x5b, 0x8a, 0x9f, 0x47, 0x73, 0x83, 0x9c, 0x40, 0x33,
+	0xdb, 0xf2, 0xc9, 0xcd, 0x22, 0x3a, 0xfb, 0x2d, 0xf0, 0x86, 0x68, 0x7d, 0xb8, 0xf2, 0x08, 0x45,
+	0xee, 0xd1, 0xbc, 0x56, 0xb8, 0xf6, 0x81, 0xfc, 0x9f, 0x34, 0xf6, 0x57, 0x3c, 0x45, 0xe6, 0x06,
+	0xf9, 0x1a, 0x76, 0x1f, 0xa1, 0x18, 0xaa, 0x9f, 0xd2, 0xb5, 0x31, 0x72, 0x0f, 0xe9, 0x1c, 0x6e,
+	0x6e, 0x90, 0x6f, 0xa1, 0x3e, 0x97, 0x02, 0xb9, 0x75, 0x01, 0x81, 0x18, 0x2b, 0xb6, 0x30, 0x37,
+	0xc8, 0x37, 0x50, 0x9f, 0xf7, 0x95, 0x75, 0x3c, 0x1f, 0x94, 0x34, 0x95, 0x0c, 0x37, 0x2f, 0xb6,
+	0x55, 0xe4, 0x8f, 0xff, 0x1e, 0x00, 0x5b, 0xc9, 0x1c, 0xda, 0x89, 0x0f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1082,6 +1202,9 @@ type AnalyzerClient interface {
 	GetPluginInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PluginInfo, error)
 	// Configure configures the analyzer, passing configuration properties for each policy.
 	Configure(ctx context.Context, in *ConfigureAnalyzerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Remediate is called with the "inputs" to a resource, before they are checked by its provider, and returns
+	// any changes that the analyzer's remediation policies make to them, e.g. adding mandatory tags.
+	Remediate(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*RemediateResponse, error)
 }
 
 type analyzerClient struct {
@@ -1137,6 +1260,15 @@ func (c *analyzerClient) Configure(ctx context.Context, in *ConfigureAnalyzerReq
 	return out, nil
 }
 
+func (c *analyzerClient) Remediate(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*RemediateResponse, error) {
+	out := new(RemediateResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.Analyzer/Remediate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AnalyzerServer is the server API for Analyzer service.
 type AnalyzerServer interface {
 	// Analyze analyzes a single resource object, and returns any errors that it finds.
@@ -1152,6 +1284,9 @@ type AnalyzerServer interface {
 	GetPluginInfo(context.Context, *empty.Empty) (*PluginInfo, error)
 	// Configure configures the analyzer, passing configuration properties for each policy.
 	Configure(context.Context, *ConfigureAnalyzerRequest) (*empty.Empty, error)
+	// Remediate is called with the "inputs" to a resource, before they are checked by its provider, and returns
+	// any changes that the analyzer's remediation policies make to them, e.g. adding mandatory tags.
+	Remediate(context.Context, *AnalyzeRequest) (*RemediateResponse, error)
 }
 
 // UnimplementedAnalyzerServer can be embedded to have forward compatible implementations.
@@ -1173,6 +1308,9 @@ func (*UnimplementedAnalyzerServer) GetPluginInfo(ctx context.Context, req *empt
 func (*UnimplementedAnalyzerServer) Configure(ctx context.Context, req *ConfigureAnalyzerRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
+func (*UnimplementedAnalyzerServer) Remediate(ctx context.Context, req *AnalyzeRequest) (*RemediateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remediate not implemented")
+}
 
 func RegisterAnalyzerServer(s *grpc.Server, srv AnalyzerServer) {
 	s.RegisterService(&_Analyzer_serviceDesc, srv)
@@ -1268,6 +1406,24 @@ func _Analyzer_Configure_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Analyzer_Remediate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Remediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.Analyzer/Remediate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Remediate(ctx, req.(*AnalyzeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Analyzer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pulumirpc.Analyzer",
 	HandlerType: (*AnalyzerServer)(nil),
@@ -1292,6 +1448,10 @@ var _Analyzer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Configure",
 			Handler:    _Analyzer_Configure_Handler,
 		},
+		{
+			MethodName: "Remediate",
+			Handler:    _Analyzer_Remediate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "analyzer.proto",
@@ -316,6 +316,11 @@ type RegisterResourceRequest struct {
 	Providers                  map[string]string                                        `protobuf:"bytes,22,rep,name=providers,proto3" json:"providers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	ReplaceOnChanges           []string                                                 `protobuf:"bytes,23,rep,name=replaceOnChanges,proto3" json:"replaceOnChanges,omitempty"`
 	PluginDownloadURL          string                                                   `protobuf:"bytes,24,opt,name=pluginDownloadURL,proto3" json:"pluginDownloadURL,omitempty"`
+	RetainOnDelete             bool                                                     `protobuf:"varint,25,opt,name=retainOnDelete,proto3" json:"retainOnDelete,omitempty"`
+	DeletedWith                string                                                   `protobuf:"bytes,26,opt,name=deletedWith,proto3" json:"deletedWith,omitempty"`
+	RetryPolicy                *RegisterResourceRequest_RetryPolicy                     `protobuf:"bytes,27,opt,name=retryPolicy,proto3" json:"retryPolicy,omitempty"`
+	MaxConcurrency             int32                                                    `protobuf:"varint,28,opt,name=maxConcurrency,proto3" json:"maxConcurrency,omitempty"`
+	RefuseReplace              bool                                                     `protobuf:"varint,29,opt,name=refuseReplace,proto3" json:"refuseReplace,omitempty"`
 	XXX_NoUnkeyedLiteral       struct{}                                                 `json:"-"`
 	XXX_unrecognized           []byte                                                   `json:"-"`
 	XXX_sizecache              int32                                                    `json:"-"`
@@ -514,6 +519,41 @@ func (m *RegisterResourceRequest) GetPluginDownloadURL() string {
 	return ""
 }
 
+func (m *RegisterResourceRequest) GetRetainOnDelete() bool {
+	if m != nil {
+		return m.RetainOnDelete
+	}
+	return false
+}
+
+func (m *RegisterResourceRequest) GetDeletedWith() string {
+	if m != nil {
+		return m.DeletedWith
+	}
+	return ""
+}
+
+func (m *RegisterResourceRequest) GetRetryPolicy() *RegisterResourceRequest_RetryPolicy {
+	if m != nil {
+		return m.RetryPolicy
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest) GetMaxConcurrency() int32 {
+	if m != nil {
+		return m.MaxConcurrency
+	}
+	return 0
+}
+
+func (m *RegisterResourceRequest) GetRefuseReplace() bool {
+	if m != nil {
+		return m.RefuseReplace
+	}
+	return false
+}
+
 // PropertyDependencies describes the resources that a particular property depends on.
 type RegisterResourceRequest_PropertyDependencies struct {
 	Urns                 []string `protobuf:"bytes,1,rep,name=urns,proto3" json:"urns,omitempty"`
@@ -616,6 +656,62 @@ func (m *RegisterResourceRequest_CustomTimeouts) GetDelete() string {
 	return ""
 }
 
+// RetryPolicy configures how the engine retries provider operations that fail with transient errors.
+type RegisterResourceRequest_RetryPolicy struct {
+	MaxAttempts          int32    `protobuf:"varint,1,opt,name=maxAttempts,proto3" json:"maxAttempts,omitempty"`
+	Backoff              string   `protobuf:"bytes,2,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	Errors               []string `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterResourceRequest_RetryPolicy) Reset()         { *m = RegisterResourceRequest_RetryPolicy{} }
+func (m *RegisterResourceRequest_RetryPolicy) String() string { return proto.CompactTextString(m) }
+func (*RegisterResourceRequest_RetryPolicy) ProtoMessage()    {}
+func (*RegisterResourceRequest_RetryPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d1b72f771c35e3b8, []int{4, 2}
+}
+
+func (m *RegisterResourceRequest_RetryPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterResourceRequest_RetryPolicy.Unmarshal(m, b)
+}
+func (m *RegisterResourceRequest_RetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterResourceRequest_RetryPolicy.Marshal(b, m, deterministic)
+}
+func (m *RegisterResourceRequest_RetryPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterResourceRequest_RetryPolicy.Merge(m, src)
+}
+func (m *RegisterResourceRequest_RetryPolicy) XXX_Size() int {
+	return xxx_messageInfo_RegisterResourceRequest_RetryPolicy.Size(m)
+}
+func (m *RegisterResourceRequest_RetryPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterResourceRequest_RetryPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterResourceRequest_RetryPolicy proto.InternalMessageInfo
+
+func (m *RegisterResourceRequest_RetryPolicy) GetMaxAttempts() int32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *RegisterResourceRequest_RetryPolicy) GetBackoff() string {
+	if m != nil {
+		return m.Backoff
+	}
+	return ""
+}
+
+func (m *RegisterResourceRequest_RetryPolicy) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
 // RegisterResourceResponse is returned by the engine after a resource has finished being initialized.  It includes the
 // auto-assigned URN, the provider-assigned ID, and any other properties initialized by the engine.
 type RegisterResourceResponse struct {
@@ -799,6 +895,7 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "pulumirpc.RegisterResourceRequest.ProvidersEntry")
 	proto.RegisterType((*RegisterResourceRequest_PropertyDependencies)(nil), "pulumirpc.RegisterResourceRequest.PropertyDependencies")
 	proto.RegisterType((*RegisterResourceRequest_CustomTimeouts)(nil), "pulumirpc.RegisterResourceRequest.CustomTimeouts")
+	proto.RegisterType((*RegisterResourceRequest_RetryPolicy)(nil), "pulumirpc.RegisterResourceRequest.RetryPolicy")
 	proto.RegisterType((*RegisterResourceResponse)(nil), "pulumirpc.RegisterResourceResponse")
 	proto.RegisterMapType((map[string]*RegisterResourceResponse_PropertyDependencies)(nil), "pulumirpc.RegisterResourceResponse.PropertyDependenciesEntry")
 	proto.RegisterType((*RegisterResourceResponse_PropertyDependencies)(nil), "pulumirpc.RegisterResourceResponse.PropertyDependencies")
@@ -808,72 +905,81 @@ func init() {
 func init() { proto.RegisterFile("resource.proto", fileDescriptor_d1b72f771c35e3b8) }
 
 var fileDescriptor_d1b72f771c35e3b8 = []byte{
-	// 1038 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x5f, 0x6f, 0x1b, 0x45,
-	0x10, 0x8f, 0xed, 0xd4, 0xb1, 0x27, 0x89, 0x93, 0x6e, 0x5c, 0x7b, 0x7b, 0xa0, 0x10, 0x0e, 0x1e,
-	0x4c, 0x85, 0x9c, 0x26, 0x20, 0x35, 0x45, 0x05, 0x24, 0x92, 0x82, 0x2a, 0x51, 0x52, 0x2e, 0x80,
-	0x00, 0x09, 0xa4, 0x8d, 0x6f, 0xe2, 0x1e, 0x39, 0xdf, 0x5e, 0x77, 0xf7, 0x82, 0xfc, 0x06, 0x5f,
-	0x0d, 0xf1, 0xc4, 0x57, 0xe2, 0x05, 0xed, 0xee, 0x9d, 0x7b, 0xe7, 0x3b, 0x27, 0x4e, 0x79, 0xbb,
-	0xf9, 0xef, 0x9d, 0xf9, 0xcd, 0x6f, 0xd7, 0xd0, 0x11, 0x28, 0x79, 0x22, 0x46, 0x38, 0x8c, 0x05,
-	0x57, 0x9c, 0xb4, 0xe3, 0x24, 0x4c, 0x26, 0x81, 0x88, 0x47, 0xce, 0x5b, 0x63, 0xce, 0xc7, 0x21,
-	0xee, 0x1b, 0xc3, 0x79, 0x72, 0xb1, 0x8f, 0x93, 0x58, 0x4d, 0xad, 0x9f, 0xf3, 0xf6, 0xbc, 0x51,
-	0x2a, 0x91, 0x8c, 0x54, 0x6a, 0xed, 0xc4, 0x82, 0x5f, 0x05, 0x3e, 0x0a, 0x2b, 0xbb, 0x03, 0xe8,
-	0x9d, 0x25, 0x71, 0xcc, 0x85, 0x92, 0x5f, 0x22, 0x53, 0x89, 0x40, 0x0f, 0x5f, 0x25, 0x28, 0x15,
-	0xe9, 0x40, 0x3d, 0xf0, 0x69, 0x6d, 0xaf, 0x36, 0x68, 0x7b, 0xf5, 0xc0, 0x77, 0x1f, 0x43, 0xbf,
-	0xe4, 0x29, 0x63, 0x1e, 0x49, 0x24, 0xbb, 0x00, 0x2f, 0x99, 0x4c, 0xad, 0x26, 0xa4, 0xe5, 0xe5,
-	0x34, 0xee, 0x3f, 0x0d, 0xd8, 0xf1, 0x90, 0xf9, 0x5e, 0x7a, 0xa2, 0x05, 0x25, 0x08, 0x81, 0x55,
-	0x35, 0x8d, 0x91, 0xd6, 0x8d, 0xc6, 0x7c, 0x6b, 0x5d, 0xc4, 0x26, 0x48, 0x1b, 0x56, 0xa7, 0xbf,
-	0x49, 0x0f, 0x9a, 0x31, 0x13, 0x18, 0x29, 0xba, 0x6a, 0xb4, 0xa9, 0x44, 0x1e, 0x01, 0xc4, 0x82,
-	0xc7, 0x28, 0x54, 0x80, 0x92, 0xde, 0xd9, 0xab, 0x0d, 0xd6, 0x0f, 0xfb, 0x43, 0xdb, 0x8f, 0x61,
-	0xd6, 0x8f, 0xe1, 0x99, 0xe9, 0x87, 0x97, 0x73, 0x25, 0x2e, 0x6c, 0xf8, 0x18, 0x63, 0xe4, 0x63,
-	0x34, 0xd2, 0xa1, 0xcd, 0xbd, 0xc6, 0xa0, 0xed, 0x15, 0x74, 0xc4, 0x81, 0x56, 0xd6, 0x3b, 0xba,
-	0x66, 0xca, 0xce, 0x64, 0x42, 0x61, 0xed, 0x0a, 0x85, 0x0c, 0x78, 0x44, 0x5b, 0xc6, 0x94, 0x89,
-	0xe4, 0x7d, 0xd8, 0x64, 0xa3, 0x11, 0xc6, 0xea, 0x0c, 0x47, 0x02, 0x95, 0xa4, 0x6d, 0xd3, 0x9d,
-	0xa2, 0x92, 0x1c, 0x41, 0x9f, 0xf9, 0x7e, 0xa0, 0x02, 0x1e, 0xb1, 0xd0, 0x2a, 0x4f, 0x13, 0x15,
-	0x27, 0x4a, 0x52, 0x30, 0x3f, 0x65, 0x91, 0x59, 0x57, 0x66, 0x61, 0xc0, 0x24, 0x4a, 0xba, 0x6e,
-	0x3c, 0x33, 0x91, 0x0c, 0x60, 0xcb, 0x16, 0xc9, 0xba, 0x2e, 0xe9, 0x86, 0xa9, 0x3d, 0xaf, 0x26,
-	0x1f, 0xc2, 0xdd, 0x38, 0x4c, 0xc6, 0x41, 0x74, 0xc2, 0x7f, 0x8f, 0x42, 0xce, 0xfc, 0xef, 0xbd,
-	0xaf, 0xe9, 0xa6, 0x39, 0x47, 0xd9, 0xe0, 0x32, 0xe8, 0x16, 0x67, 0x99, 0x82, 0x60, 0x1b, 0x1a,
-	0x89, 0x88, 0xd2, 0x69, 0xea, 0xcf, 0xb9, 0x71, 0xd4, 0x97, 0x1e, 0x87, 0xfb, 0x2f, 0x40, 0xdf,
-	0xc3, 0x71, 0x20, 0x15, 0x8a, 0x79, 0xcc, 0x64, 0x18, 0xa9, 0x55, 0x60, 0xa4, 0x5e, 0x89, 0x91,
-	0x46, 0x01, 0x23, 0x3d, 0x68, 0x8e, 0x12, 0xa9, 0xf8, 0xc4, 0x60, 0xa7, 0xe5, 0xa5, 0x12, 0xd9,
-	0x87, 0x26, 0x3f, 0xff, 0x0d, 0x47, 0xea, 0x26, 0xdc, 0xa4, 0x6e, 0xba, 0xf3, 0xda, 0xa4, 0x23,
-	0x9a, 0x26, 0x53, 0x26, 0x96, 0xd0, 0xb4, 0x76, 0x03, 0x9a, 0x5a, 0x73, 0x68, 0x8a, 0xa1, 0x9b,
-	0x36, 0x63, 0x7a, 0x92, 0xcf, 0xd3, 0xde, 0x6b, 0x0c, 0xd6, 0x0f, 0x9f, 0x0c, 0x67, 0x44, 0x30,
-	0x5c, 0xd0, 0xa4, 0xe1, 0x8b, 0x8a, 0xf0, 0xa7, 0x91, 0x12, 0x53, 0xaf, 0x32, 0x33, 0x79, 0x08,
-	0x3b, 0x3e, 0x86, 0xa8, 0xf0, 0x0b, 0xbc, 0xe0, 0x7a, 0xb1, 0xe3, 0x90, 0x8d, 0x90, 0x82, 0x39,
-	0x57, 0x95, 0x29, 0x8f, 0xf8, 0xf5, 0x12, 0xe2, 0x83, 0x71, 0xc4, 0x05, 0x1e, 0xbf, 0x64, 0xd1,
-	0xd8, 0xa0, 0x4e, 0x1f, 0xbf, 0xa8, 0x2c, 0xef, 0xc5, 0xe6, 0x2d, 0xf7, 0xa2, 0xb3, 0xf4, 0x5e,
-	0x6c, 0x15, 0xf7, 0xc2, 0x81, 0x56, 0x30, 0xd1, 0xb4, 0xf4, 0xcc, 0xa7, 0xdb, 0xb6, 0xf3, 0x99,
-	0x4c, 0x7e, 0x82, 0x8e, 0x85, 0xc3, 0x77, 0xc1, 0x04, 0xb9, 0x2e, 0x73, 0xd7, 0x80, 0xe1, 0x60,
-	0x89, 0x9e, 0x1f, 0x17, 0x02, 0xbd, 0xb9, 0x44, 0xe4, 0x33, 0x70, 0x2a, 0xfa, 0x78, 0x82, 0x17,
-	0x41, 0x84, 0x3e, 0x25, 0xe6, 0xf4, 0xd7, 0x78, 0x90, 0x8f, 0xe1, 0x9e, 0x4c, 0xe9, 0xf7, 0x05,
-	0x13, 0x2a, 0x60, 0xe1, 0x0f, 0x2c, 0x4c, 0x50, 0xd2, 0x1d, 0x13, 0x5a, 0x6d, 0xd4, 0x68, 0x17,
-	0x38, 0xe1, 0x0a, 0x69, 0xd7, 0xa2, 0xdd, 0x4a, 0x55, 0xe4, 0x70, 0xaf, 0x9a, 0x1c, 0x4e, 0xa1,
-	0x9d, 0x01, 0x53, 0xd2, 0x9e, 0x41, 0xe0, 0xc1, 0x72, 0x08, 0xb4, 0x31, 0x16, 0x76, 0xaf, 0x73,
-	0x90, 0x07, 0xb0, 0x2d, 0xec, 0xd1, 0x4e, 0xa3, 0x0c, 0x22, 0x7d, 0x33, 0xa2, 0x92, 0xbe, 0x9a,
-	0x99, 0xe8, 0x02, 0x66, 0x72, 0x1e, 0x40, 0xb7, 0x0a, 0xf8, 0x9a, 0x1e, 0x12, 0x11, 0x49, 0x5a,
-	0x33, 0x55, 0xcc, 0xb7, 0xf3, 0x23, 0x74, 0x8a, 0x03, 0x33, 0xc4, 0x20, 0x90, 0xa9, 0x8c, 0x5a,
-	0x52, 0x49, 0xeb, 0x93, 0xd8, 0xd7, 0x7a, 0x4b, 0x2f, 0xa9, 0xa4, 0xf5, 0x76, 0x5c, 0x19, 0xc1,
-	0x58, 0xc9, 0xf9, 0xa3, 0x06, 0xf7, 0x17, 0xee, 0x9f, 0x66, 0xc9, 0x4b, 0x9c, 0x66, 0x2c, 0x79,
-	0x89, 0x53, 0xf2, 0x1c, 0xee, 0x5c, 0xe9, 0x61, 0xa5, 0x04, 0xf9, 0xe8, 0x0d, 0xd7, 0xdb, 0xb3,
-	0x59, 0x3e, 0xa9, 0x1f, 0xd5, 0x9c, 0x27, 0xd0, 0x29, 0xf6, 0xbf, 0xa2, 0x6c, 0x37, 0x5f, 0xb6,
-	0x9d, 0x8b, 0x76, 0xff, 0x6a, 0x00, 0x2d, 0x57, 0x5e, 0xc8, 0xf2, 0xf6, 0x12, 0xaf, 0xcf, 0x2e,
-	0xf1, 0xd7, 0x44, 0xda, 0x58, 0x8e, 0x48, 0x7b, 0xd0, 0x94, 0x8a, 0x9d, 0x87, 0x98, 0x31, 0xb2,
-	0x95, 0xf4, 0x0a, 0xdb, 0x2f, 0x7d, 0x95, 0x9b, 0x15, 0x4e, 0x45, 0xf2, 0x6a, 0x01, 0x41, 0x36,
-	0x0d, 0x3c, 0x3f, 0xbd, 0xb6, 0x83, 0xf6, 0x1c, 0xb7, 0x65, 0xc8, 0x5b, 0x61, 0xeb, 0xcf, 0x5b,
-	0x22, 0xe0, 0x9b, 0x22, 0x02, 0x8e, 0xde, 0xf4, 0xf7, 0xe7, 0x87, 0x88, 0xb0, 0x3b, 0x1f, 0x9b,
-	0x52, 0x63, 0x76, 0x91, 0x96, 0x27, 0x79, 0x00, 0x6b, 0x3c, 0x65, 0xd7, 0x1b, 0x2e, 0xeb, 0xcc,
-	0xef, 0xf0, 0xef, 0x55, 0xd8, 0xca, 0xf2, 0x3f, 0xe7, 0x51, 0xa0, 0xb8, 0x20, 0x3f, 0xc3, 0xd6,
-	0xdc, 0x43, 0x91, 0xbc, 0x9b, 0x3b, 0x52, 0xf5, 0x73, 0xd3, 0x71, 0xaf, 0x73, 0xb1, 0x87, 0x76,
-	0x57, 0xc8, 0xe7, 0xd0, 0x7c, 0x16, 0x5d, 0xf1, 0x4b, 0x24, 0x34, 0xe7, 0x6f, 0x55, 0x59, 0xa6,
-	0xfb, 0x15, 0x96, 0x59, 0x82, 0xaf, 0x60, 0xe3, 0x4c, 0x09, 0x64, 0x93, 0xff, 0x95, 0xe6, 0x61,
-	0x8d, 0x3c, 0x86, 0xd5, 0x63, 0x16, 0x86, 0xa4, 0x97, 0x73, 0xd3, 0x8a, 0x2c, 0xbc, 0x5f, 0xd2,
-	0xcf, 0x7e, 0xc3, 0xb7, 0xb0, 0x91, 0x7f, 0x41, 0x91, 0xdd, 0xc2, 0xc0, 0x4b, 0xcf, 0x64, 0xe7,
-	0x9d, 0x85, 0xf6, 0x59, 0xca, 0x5f, 0x60, 0x7b, 0x7e, 0xdc, 0xc4, 0xbd, 0x99, 0x49, 0x9c, 0xf7,
-	0x96, 0xc0, 0x9a, 0xbb, 0x42, 0x7e, 0x2d, 0xbf, 0xc7, 0xb2, 0x8b, 0xf6, 0x83, 0x6b, 0x32, 0x14,
-	0x11, 0xe7, 0xf4, 0x4a, 0x70, 0x7a, 0xaa, 0xff, 0xb7, 0xb8, 0x2b, 0xe7, 0x4d, 0xa3, 0xf9, 0xe8,
-	0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x35, 0x49, 0xe5, 0xd6, 0xf4, 0x0c, 0x00, 0x00,
+	// 1172 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x5f, 0x73, 0xdb, 0x44,
+	0x10, 0xaf, 0xed, 0xc4, 0xb1, 0xd7, 0x89, 0x93, 0x5e, 0x53, 0xfb, 0xaa, 0x96, 0x62, 0x04, 0xc3,
+	0x98, 0x0e, 0xe3, 0xb4, 0x81, 0x99, 0xa6, 0x4c, 0x81, 0x81, 0xa4, 0x30, 0x9d, 0xa1, 0x24, 0x28,
+	0xfc, 0x9f, 0x81, 0x99, 0x8b, 0xb4, 0x76, 0x44, 0x64, 0x9d, 0x7a, 0x77, 0x0a, 0xf5, 0x1b, 0x7c,
+	0x35, 0x86, 0x07, 0x86, 0x4f, 0xc5, 0xdc, 0x49, 0x72, 0x24, 0x5b, 0x4e, 0x9c, 0xf2, 0xa6, 0xfd,
+	0xed, 0xed, 0xee, 0xed, 0xde, 0xef, 0x76, 0x4f, 0xd0, 0x16, 0x28, 0x79, 0x2c, 0x5c, 0x1c, 0x44,
+	0x82, 0x2b, 0x4e, 0x9a, 0x51, 0x1c, 0xc4, 0x63, 0x5f, 0x44, 0xae, 0x75, 0x77, 0xc4, 0xf9, 0x28,
+	0xc0, 0x1d, 0xa3, 0x38, 0x89, 0x87, 0x3b, 0x38, 0x8e, 0xd4, 0x24, 0x59, 0x67, 0xdd, 0x9b, 0x55,
+	0x4a, 0x25, 0x62, 0x57, 0xa5, 0xda, 0x76, 0x24, 0xf8, 0xb9, 0xef, 0xa1, 0x48, 0x64, 0xbb, 0x0f,
+	0x9d, 0xe3, 0x38, 0x8a, 0xb8, 0x50, 0xf2, 0x0b, 0x64, 0x2a, 0x16, 0xe8, 0xe0, 0xcb, 0x18, 0xa5,
+	0x22, 0x6d, 0xa8, 0xfa, 0x1e, 0xad, 0xf4, 0x2a, 0xfd, 0xa6, 0x53, 0xf5, 0x3d, 0xfb, 0x09, 0x74,
+	0xe7, 0x56, 0xca, 0x88, 0x87, 0x12, 0xc9, 0x7d, 0x80, 0x53, 0x26, 0x53, 0xad, 0x31, 0x69, 0x38,
+	0x39, 0xc4, 0xfe, 0xb7, 0x06, 0xb7, 0x1c, 0x64, 0x9e, 0x93, 0x66, 0xb4, 0x20, 0x04, 0x21, 0xb0,
+	0xa2, 0x26, 0x11, 0xd2, 0xaa, 0x41, 0xcc, 0xb7, 0xc6, 0x42, 0x36, 0x46, 0x5a, 0x4b, 0x30, 0xfd,
+	0x4d, 0x3a, 0x50, 0x8f, 0x98, 0xc0, 0x50, 0xd1, 0x15, 0x83, 0xa6, 0x12, 0x79, 0x0c, 0x10, 0x09,
+	0x1e, 0xa1, 0x50, 0x3e, 0x4a, 0xba, 0xda, 0xab, 0xf4, 0x5b, 0xbb, 0xdd, 0x41, 0x52, 0x8f, 0x41,
+	0x56, 0x8f, 0xc1, 0xb1, 0xa9, 0x87, 0x93, 0x5b, 0x4a, 0x6c, 0x58, 0xf7, 0x30, 0xc2, 0xd0, 0xc3,
+	0xd0, 0xd5, 0xa6, 0xf5, 0x5e, 0xad, 0xdf, 0x74, 0x0a, 0x18, 0xb1, 0xa0, 0x91, 0xd5, 0x8e, 0xae,
+	0x99, 0xb0, 0x53, 0x99, 0x50, 0x58, 0x3b, 0x47, 0x21, 0x7d, 0x1e, 0xd2, 0x86, 0x51, 0x65, 0x22,
+	0x79, 0x07, 0x36, 0x98, 0xeb, 0x62, 0xa4, 0x8e, 0xd1, 0x15, 0xa8, 0x24, 0x6d, 0x9a, 0xea, 0x14,
+	0x41, 0xb2, 0x07, 0x5d, 0xe6, 0x79, 0xbe, 0xf2, 0x79, 0xc8, 0x82, 0x04, 0x3c, 0x8c, 0x55, 0x14,
+	0x2b, 0x49, 0xc1, 0x6c, 0x65, 0x91, 0x5a, 0x47, 0x66, 0x81, 0xcf, 0x24, 0x4a, 0xda, 0x32, 0x2b,
+	0x33, 0x91, 0xf4, 0x61, 0x33, 0x09, 0x92, 0x55, 0x5d, 0xd2, 0x75, 0x13, 0x7b, 0x16, 0x26, 0xef,
+	0xc3, 0xcd, 0x28, 0x88, 0x47, 0x7e, 0x78, 0xc0, 0x7f, 0x0f, 0x03, 0xce, 0xbc, 0xef, 0x9c, 0xaf,
+	0xe8, 0x86, 0xc9, 0x63, 0x5e, 0x61, 0x33, 0xd8, 0x2e, 0x9e, 0x65, 0x4a, 0x82, 0x2d, 0xa8, 0xc5,
+	0x22, 0x4c, 0x4f, 0x53, 0x7f, 0xce, 0x1c, 0x47, 0x75, 0xe9, 0xe3, 0xb0, 0xff, 0xd9, 0x80, 0xae,
+	0x83, 0x23, 0x5f, 0x2a, 0x14, 0xb3, 0x9c, 0xc9, 0x38, 0x52, 0x29, 0xe1, 0x48, 0xb5, 0x94, 0x23,
+	0xb5, 0x02, 0x47, 0x3a, 0x50, 0x77, 0x63, 0xa9, 0xf8, 0xd8, 0x70, 0xa7, 0xe1, 0xa4, 0x12, 0xd9,
+	0x81, 0x3a, 0x3f, 0xf9, 0x0d, 0x5d, 0x75, 0x15, 0x6f, 0xd2, 0x65, 0xba, 0xf2, 0x5a, 0xa5, 0x2d,
+	0xea, 0xc6, 0x53, 0x26, 0xce, 0xb1, 0x69, 0xed, 0x0a, 0x36, 0x35, 0x66, 0xd8, 0x14, 0xc1, 0x76,
+	0x5a, 0x8c, 0xc9, 0x41, 0xde, 0x4f, 0xb3, 0x57, 0xeb, 0xb7, 0x76, 0x9f, 0x0e, 0xa6, 0x8d, 0x60,
+	0xb0, 0xa0, 0x48, 0x83, 0xa3, 0x12, 0xf3, 0x67, 0xa1, 0x12, 0x13, 0xa7, 0xd4, 0x33, 0x79, 0x08,
+	0xb7, 0x3c, 0x0c, 0x50, 0xe1, 0xe7, 0x38, 0xe4, 0x02, 0x1d, 0x8c, 0x02, 0xe6, 0x22, 0x05, 0x93,
+	0x57, 0x99, 0x2a, 0xcf, 0xf8, 0xd6, 0x1c, 0xe3, 0xfd, 0x51, 0xc8, 0x05, 0xee, 0x9f, 0xb2, 0x70,
+	0x64, 0x58, 0xa7, 0xd3, 0x2f, 0x82, 0xf3, 0xf7, 0x62, 0xe3, 0x9a, 0xf7, 0xa2, 0xbd, 0xf4, 0xbd,
+	0xd8, 0x2c, 0xde, 0x0b, 0x0b, 0x1a, 0xfe, 0x38, 0xe2, 0x42, 0x3d, 0xf7, 0xe8, 0x56, 0x52, 0xf9,
+	0x4c, 0x26, 0x3f, 0x41, 0x3b, 0xa1, 0xc3, 0xb7, 0xfe, 0x18, 0xb9, 0x0e, 0x73, 0xd3, 0x90, 0xe1,
+	0xd1, 0x12, 0x35, 0xdf, 0x2f, 0x18, 0x3a, 0x33, 0x8e, 0xc8, 0x27, 0x60, 0x95, 0xd4, 0xf1, 0x00,
+	0x87, 0x7e, 0x88, 0x1e, 0x25, 0x26, 0xfb, 0x4b, 0x56, 0x90, 0x0f, 0xe1, 0xb6, 0x4c, 0xdb, 0xef,
+	0x11, 0x13, 0xca, 0x67, 0xc1, 0xf7, 0x2c, 0x88, 0x51, 0xd2, 0x5b, 0xc6, 0xb4, 0x5c, 0xa9, 0xd9,
+	0x2e, 0x70, 0xcc, 0x15, 0xd2, 0xed, 0x84, 0xed, 0x89, 0x54, 0xd6, 0x1c, 0x6e, 0x97, 0x37, 0x87,
+	0x43, 0x68, 0x66, 0xc4, 0x94, 0xb4, 0xd3, 0xab, 0x2d, 0x59, 0x8d, 0xa3, 0xcc, 0x26, 0xa1, 0xdd,
+	0x85, 0x0f, 0xf2, 0x00, 0xb6, 0x44, 0x92, 0xda, 0x61, 0x98, 0x51, 0xa4, 0x6b, 0x8e, 0x68, 0x0e,
+	0x2f, 0xef, 0x4c, 0x74, 0x41, 0x67, 0x22, 0xef, 0xea, 0x99, 0xa9, 0x98, 0x1f, 0x1e, 0x86, 0x07,
+	0xa6, 0x90, 0xf4, 0x8e, 0xc9, 0x69, 0x06, 0x25, 0x3d, 0x68, 0x25, 0x85, 0xf6, 0x7e, 0xf0, 0xd5,
+	0x29, 0xb5, 0x8c, 0xbf, 0x3c, 0x44, 0x8e, 0xa0, 0x25, 0x50, 0x89, 0xc9, 0x11, 0x0f, 0x7c, 0x77,
+	0x42, 0xef, 0x1a, 0x12, 0x0c, 0x96, 0x48, 0xdb, 0xb9, 0xb0, 0x72, 0xf2, 0x2e, 0xf4, 0xde, 0xc6,
+	0xec, 0xd5, 0x3e, 0x0f, 0xdd, 0x58, 0x08, 0x0c, 0xdd, 0x09, 0xbd, 0xd7, 0xab, 0xf4, 0x57, 0x9d,
+	0x19, 0x54, 0xdf, 0x0b, 0x81, 0xc3, 0x58, 0x4e, 0xef, 0xe0, 0x1b, 0xc9, 0xbd, 0x28, 0x80, 0xd6,
+	0x03, 0xd8, 0x2e, 0xbb, 0xe2, 0xba, 0x11, 0xc6, 0x22, 0x94, 0xb4, 0x62, 0xea, 0x69, 0xbe, 0xad,
+	0x1f, 0xa1, 0x5d, 0xa4, 0xa6, 0x69, 0x81, 0x02, 0x99, 0xca, 0x9a, 0x68, 0x2a, 0x69, 0x3c, 0x8e,
+	0x3c, 0xa6, 0xb2, 0x46, 0x9a, 0x4a, 0x1a, 0x4f, 0x8a, 0x93, 0xb5, 0xd2, 0x44, 0xb2, 0x18, 0xb4,
+	0x72, 0xf9, 0xea, 0xb2, 0x8e, 0xd9, 0xab, 0xcf, 0x94, 0xc2, 0x71, 0xa4, 0xa4, 0xf1, 0xbd, 0xea,
+	0xe4, 0x21, 0x7d, 0x29, 0x4f, 0x98, 0x7b, 0xc6, 0x87, 0xc3, 0x34, 0x42, 0x26, 0xea, 0x10, 0x28,
+	0x04, 0x17, 0x92, 0xd6, 0xcc, 0xd6, 0x53, 0xc9, 0xfa, 0xa3, 0x02, 0x77, 0x16, 0x36, 0x33, 0x3d,
+	0x72, 0xce, 0x70, 0x92, 0x8d, 0x9c, 0x33, 0x9c, 0x90, 0x17, 0xb0, 0x7a, 0xae, 0x99, 0x9f, 0x4e,
+	0x9b, 0xc7, 0xaf, 0xd9, 0x2b, 0x9d, 0xc4, 0xcb, 0x47, 0xd5, 0xbd, 0x8a, 0xf5, 0x14, 0xda, 0x45,
+	0x32, 0x97, 0x84, 0xdd, 0xce, 0x87, 0x6d, 0xe6, 0xac, 0xed, 0xbf, 0x6a, 0x40, 0xe7, 0x23, 0x2f,
+	0x1c, 0x99, 0xc9, 0x8b, 0xa8, 0x3a, 0x7d, 0x11, 0x5d, 0x4c, 0xa5, 0xda, 0x72, 0x53, 0xa9, 0x03,
+	0x75, 0xa9, 0xd8, 0x49, 0x80, 0xd9, 0x78, 0x4b, 0x24, 0x5d, 0xfa, 0xe4, 0x4b, 0xbf, 0x8b, 0x4c,
+	0x3f, 0x4c, 0x45, 0xf2, 0x72, 0xc1, 0xb4, 0xa9, 0x9b, 0xbb, 0xfe, 0xf1, 0xa5, 0x15, 0x4c, 0xf2,
+	0xb8, 0xee, 0xb8, 0xb9, 0x16, 0x7d, 0xff, 0xbc, 0x26, 0x03, 0xbe, 0x2e, 0x32, 0x60, 0xef, 0x75,
+	0xf7, 0x9f, 0x3f, 0x44, 0x84, 0xfb, 0xb3, 0xb6, 0xe9, 0x9c, 0xc9, 0x5e, 0x25, 0xf3, 0x27, 0xf9,
+	0x08, 0xd6, 0x78, 0x3a, 0xaa, 0xae, 0x78, 0xf9, 0x64, 0xeb, 0x76, 0xff, 0x5e, 0x81, 0xcd, 0xcc,
+	0xff, 0x0b, 0x1e, 0xfa, 0x8a, 0x0b, 0xf2, 0x33, 0x6c, 0xce, 0xbc, 0xba, 0xc9, 0x5b, 0xb9, 0x94,
+	0xca, 0xdf, 0xee, 0x96, 0x7d, 0xd9, 0x92, 0x24, 0x69, 0xfb, 0x06, 0xf9, 0x14, 0xea, 0xcf, 0xc3,
+	0x73, 0x7e, 0x86, 0x84, 0xe6, 0xd6, 0x27, 0x50, 0xe6, 0xe9, 0x4e, 0x89, 0x66, 0xea, 0xe0, 0x4b,
+	0x58, 0x3f, 0x56, 0x02, 0xd9, 0xf8, 0x7f, 0xb9, 0x79, 0x58, 0x21, 0x4f, 0x60, 0x65, 0x9f, 0x05,
+	0x01, 0xe9, 0xe4, 0x96, 0x69, 0x20, 0x33, 0xef, 0xce, 0xe1, 0xd3, 0x3d, 0x7c, 0x03, 0xeb, 0xf9,
+	0xe7, 0x28, 0xb9, 0x5f, 0x38, 0xf0, 0xb9, 0x7f, 0x0e, 0xeb, 0xcd, 0x85, 0xfa, 0xa9, 0xcb, 0x5f,
+	0x60, 0x6b, 0xf6, 0xb8, 0x89, 0x7d, 0x75, 0x27, 0xb1, 0xde, 0x5e, 0x82, 0x6b, 0xf6, 0x0d, 0xf2,
+	0x2b, 0x74, 0x17, 0xb0, 0x89, 0xbc, 0x77, 0x89, 0x87, 0x22, 0xe3, 0xac, 0xce, 0x1c, 0x9d, 0x9e,
+	0xe9, 0x9f, 0x40, 0xfb, 0xc6, 0x49, 0xdd, 0x20, 0x1f, 0xfc, 0x37, 0x00, 0x03, 0x2c, 0x54, 0xf7,
+	0x41, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -44,6 +44,11 @@ class AnalyzerStub(object):
         request_serializer=analyzer__pb2.ConfigureAnalyzerRequest.SerializeToString,
         response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
         )
+    self.Remediate = channel.unary_unary(
+        '/pulumirpc.Analyzer/Remediate',
+        request_serializer=analyzer__pb2.AnalyzeRequest.SerializeToString,
+        response_deserializer=analyzer__pb2.RemediateResponse.FromString,
+        )
 
 
 class AnalyzerServicer(object):
@@ -91,6 +96,14 @@ class AnalyzerServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def Remediate(self, request, context):
+    """Remediate is called with the "inputs" to a resource, before they are checked by its provider, and returns
+    any changes that the analyzer's remediation policies make to them, e.g. adding mandatory tags.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
 
 def add_AnalyzerServicer_to_server(servicer, server):
   rpc_method_handlers = {
@@ -119,6 +132,11 @@ def add_AnalyzerServicer_to_server(servicer, server):
           request_deserializer=analyzer__pb2.ConfigureAnalyzerRequest.FromString,
           response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
       ),
+      'Remediate': grpc.unary_unary_rpc_method_handler(
+          servicer.Remediate,
+          request_deserializer=analyzer__pb2.AnalyzeRequest.FromString,
+          response_serializer=analyzer__pb2.RemediateResponse.SerializeToString,
+      ),
   }
   generic_handler = grpc.method_handlers_generic_handler(
       'pulumirpc.Analyzer', rpc_method_handlers)